@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/kevensen/go-passage-of-time-mcp-server/internal/handlers"
 	"github.com/kevensen/go-passage-of-time-mcp-server/internal/handlers/mcp"
@@ -16,12 +18,79 @@ import (
 
 var port = flag.Int("port", -1, "Port to run the server on")
 var host = flag.String("host", "0.0.0.0", "Host to run the server on")
+var holidays = flag.String("holidays", "", "Path to a JSON file of additional holiday calendars")
+var fiscal = flag.String("fiscal-calendars", "", "Path to a JSON file of additional fiscal calendars")
+var timers = flag.String("timers", "", "Path to a JSON file in which to keep named timers across restarts")
+var events = flag.String("events", "", "Path to a JSON file in which to keep registered events")
+var zoneinfo = flag.String("zoneinfo", "", "Path to a zoneinfo zip file or directory to load time zones from instead of the built-in tzdata")
+var preferSystemTZData = flag.Bool("prefer-system-tzdata", false, "Load time zones from the system's zoneinfo when it is a newer tzdata release than the built-in one")
 
 func main() {
 	flag.Parse()
 	ctx := context.Background()
 	eg := errgroup.Group{}
 
+	router := handlers.RouterByName("/mcp")
+	if router == nil {
+		slog.ErrorContext(ctx, "Router not found", slog.String("name", "/mcp"))
+		os.Exit(1)
+	}
+	server, ok := router.(*mcp.Server)
+	if !ok {
+		slog.ErrorContext(ctx, "Router is not a MCP Server", slog.String("name", "/mcp"))
+		os.Exit(1)
+	}
+
+	switch {
+	case *zoneinfo != "":
+		db, err := mcp.OpenTimeZoneDatabase(*zoneinfo)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error loading time zone database", slog.Any("error", err))
+			os.Exit(1)
+		}
+		mcp.SetTimeZoneDatabase(db)
+	case *preferSystemTZData:
+		if db, err := mcp.SystemTimeZoneDatabase(); err == nil && db.NewerThan(mcp.EmbeddedTimeZoneDatabase()) {
+			mcp.SetTimeZoneDatabase(db)
+		}
+	}
+	db := mcp.CurrentTimeZoneDatabase()
+	slog.InfoContext(ctx, "Loading time zones", slog.String("source", db.Source), slog.String("tzdata", db.Version))
+
+	if *holidays != "" {
+		if err := server.Holidays.LoadFile(*holidays); err != nil {
+			slog.ErrorContext(ctx, "Error loading holiday calendars", slog.Any("error", err))
+			os.Exit(1)
+		}
+		slog.InfoContext(ctx, "Loaded holiday calendars", slog.String("path", *holidays), slog.Any("calendars", server.Holidays.IDs()))
+	}
+
+	if *fiscal != "" {
+		if err := server.Fiscal.LoadFile(*fiscal); err != nil {
+			slog.ErrorContext(ctx, "Error loading fiscal calendars", slog.Any("error", err))
+			os.Exit(1)
+		}
+		slog.InfoContext(ctx, "Loaded fiscal calendars", slog.String("path", *fiscal), slog.Any("calendars", server.Fiscal.IDs()))
+	}
+
+	if *timers != "" {
+		if err := server.Timers.SetFile(*timers); err != nil {
+			slog.ErrorContext(ctx, "Error loading timers", slog.Any("error", err))
+			os.Exit(1)
+		}
+		slog.InfoContext(ctx, "Keeping timers in file", slog.String("path", *timers))
+	}
+
+	if *events != "" {
+		store, err := mcp.NewFileEventStore(*events)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error loading events", slog.Any("error", err))
+			os.Exit(1)
+		}
+		server.Events = store
+		slog.InfoContext(ctx, "Keeping events in file", slog.String("path", *events))
+	}
+
 	if *port >= 0 {
 		addr := fmt.Sprintf("%s:%d", *host, *port)
 		slog.InfoContext(ctx, "Starting server", slog.String("address", addr))
@@ -35,17 +104,10 @@ func main() {
 		os.Exit(0)
 	}
 
-	router := handlers.RouterByName("/mcp")
-	if router == nil {
-		slog.ErrorContext(ctx, "Router not found", slog.String("name", "/mcp"))
-		os.Exit(1)
-	}
-	server, ok := router.(*mcp.Server)
-	if !ok {
-		slog.ErrorContext(ctx, "Router is not a MCP Server", slog.String("name", "/mcp"))
-		os.Exit(1)
-	}
-	if err := mcp_go_server.ServeStdio(server.MCPServer); err != nil {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+	stdio := mcp_go_server.NewStdioServer(server.MCPServer)
+	if err := stdio.Listen(ctx, server.SubscriptionReader(os.Stdin), os.Stdout); err != nil {
 		slog.ErrorContext(ctx, "Error starting MCP server", slog.Any("error", err))
 		os.Exit(1)
 	}
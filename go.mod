@@ -2,11 +2,14 @@ module github.com/kevensen/go-passage-of-time-mcp-server
 
 go 1.24.4
 
-require github.com/mark3labs/mcp-go v0.34.0
+require (
+	github.com/google/jsonschema-go v0.4.2
+	github.com/mark3labs/mcp-go v0.47.1
+)
 
 require (
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/spf13/cast v1.7.1 // indirect
-	github.com/yosida95/uritemplate/v3 v3.0.2 // indirect
+	github.com/yosida95/uritemplate/v3 v3.0.2
 	golang.org/x/sync v0.16.0
 )
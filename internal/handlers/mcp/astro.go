@@ -0,0 +1,240 @@
+package mcp
+
+import (
+	"math"
+	"time"
+)
+
+// The astronomy here follows Jean Meeus, Astronomical Algorithms (2nd ed.),
+// to the precision calendars and sunrise tables need: the Sun's position to
+// about 0.01° and new moons to within a minute.  Moments are measured in
+// days, as fixed day numbers (see fixedFromTime) with a fraction, in
+// Universal Time.
+
+const (
+	// meanSynodicMonth is the mean time from one new moon to the next, in
+	// days.
+	meanSynodicMonth = 29.530588861
+	// meanTropicalYear is the mean time from one March equinox to the next,
+	// in days.
+	meanTropicalYear = 365.242189
+	// fixedToJulianDay converts a moment to a Julian date.
+	fixedToJulianDay = 1721424.5
+)
+
+// degrees reduces x to [0, 360).
+func degrees(x float64) float64 {
+	return x - 360*math.Floor(x/360)
+}
+
+// signedDegrees reduces x to [-180, 180).
+func signedDegrees(x float64) float64 {
+	return degrees(x+180) - 180
+}
+
+// sinDeg, cosDeg and tanDeg take their argument in degrees, and asinDeg,
+// acosDeg and atan2Deg return theirs in degrees.
+func sinDeg(x float64) float64      { return math.Sin(x * math.Pi / 180) }
+func cosDeg(x float64) float64      { return math.Cos(x * math.Pi / 180) }
+func tanDeg(x float64) float64      { return math.Tan(x * math.Pi / 180) }
+func asinDeg(x float64) float64     { return math.Asin(x) * 180 / math.Pi }
+func acosDeg(x float64) float64     { return math.Acos(x) * 180 / math.Pi }
+func atan2Deg(y, x float64) float64 { return math.Atan2(y, x) * 180 / math.Pi }
+
+// unixEpochFixed is the fixed day number of 1 January 1970.
+const unixEpochFixed = 719163
+
+// momentFromTime returns the moment of t.
+func momentFromTime(t time.Time) float64 {
+	return float64(t.Unix())/86400 + float64(t.Nanosecond())/86400e9 + unixEpochFixed
+}
+
+// timeFromMoment returns the instant of a moment, to the second.
+func timeFromMoment(moment float64) time.Time {
+	return time.Unix(int64(math.Round((moment-unixEpochFixed)*86400)), 0).UTC()
+}
+
+// deltaT estimates the difference between Terrestrial Time and Universal
+// Time, in days, for a moment, by the polynomials of Espenak and Meeus.
+func deltaT(moment float64) float64 {
+	y := 2000 + (moment+fixedToJulianDay-2451544.5)/meanTropicalYear
+	var seconds float64
+	switch {
+	case y >= 2005 && y < 2050:
+		t := y - 2000
+		seconds = 62.92 + 0.32217*t + 0.005589*t*t
+	case y >= 1986 && y < 2005:
+		t := y - 2000
+		seconds = 63.86 + 0.3345*t - 0.060374*t*t + 0.0017275*t*t*t + 0.000651814*t*t*t*t + 0.00002373599*t*t*t*t*t
+	case y >= 1961 && y < 1986:
+		t := y - 1975
+		seconds = 45.45 + 1.067*t - t*t/260 - t*t*t/718
+	case y >= 1941 && y < 1961:
+		t := y - 1950
+		seconds = 29.07 + 0.407*t - t*t/233 + t*t*t/2547
+	case y >= 1920 && y < 1941:
+		t := y - 1920
+		seconds = 21.20 + 0.84493*t - 0.076100*t*t + 0.0020936*t*t*t
+	case y >= 1900 && y < 1920:
+		t := y - 1900
+		seconds = -2.79 + 1.494119*t - 0.0598939*t*t + 0.0061966*t*t*t - 0.000197*t*t*t*t
+	case y >= 1860 && y < 1900:
+		t := y - 1860
+		seconds = 7.62 + 0.5737*t - 0.251754*t*t + 0.01680668*t*t*t - 0.0004473624*t*t*t*t + t*t*t*t*t/233174
+	case y >= 1800 && y < 1860:
+		t := y - 1800
+		seconds = 13.72 - 0.332447*t + 0.0068612*t*t + 0.0041116*t*t*t - 0.00037436*t*t*t*t + 0.0000121272*t*t*t*t*t - 0.0000001699*t*t*t*t*t*t + 0.000000000875*t*t*t*t*t*t*t
+	case y >= 1700 && y < 1800:
+		t := y - 1700
+		seconds = 8.83 + 0.1603*t - 0.0059285*t*t + 0.00013336*t*t*t - t*t*t*t/1174000
+	case y >= 1600 && y < 1700:
+		t := y - 1600
+		seconds = 120 - 0.9808*t - 0.01532*t*t + t*t*t/7129
+	case y >= 2050 && y < 2150:
+		u := (y - 1820) / 100
+		seconds = -20 + 32*u*u - 0.5628*(2150-y)
+	default:
+		u := (y - 1820) / 100
+		seconds = -20 + 32*u*u
+	}
+	return seconds / 86400
+}
+
+// julianCenturies counts the Julian centuries of Terrestrial Time from
+// J2000.0 to a moment.
+func julianCenturies(moment float64) float64 {
+	return (moment + deltaT(moment) + fixedToJulianDay - 2451545) / 36525
+}
+
+// solarLongitude returns the Sun's apparent ecliptic longitude at a moment,
+// in degrees: 0 at the March equinox, 90 at the June solstice and so on.
+func solarLongitude(moment float64) float64 {
+	t := julianCenturies(moment)
+	meanLongitude := 280.46646 + 36000.76983*t + 0.0003032*t*t
+	meanAnomaly := 357.52911 + 35999.05029*t - 0.0001537*t*t
+	center := (1.914602-0.004817*t-0.000014*t*t)*sinDeg(meanAnomaly) +
+		(0.019993-0.000101*t)*sinDeg(2*meanAnomaly) +
+		0.000289*sinDeg(3*meanAnomaly)
+	omega := 125.04 - 1934.136*t
+	return degrees(meanLongitude + center - 0.00569 - 0.00478*sinDeg(omega))
+}
+
+// sunEquatorial returns the Sun's apparent right ascension and
+// declination at a moment, in degrees.
+func sunEquatorial(moment float64) (rightAscension, declination float64) {
+	t := julianCenturies(moment)
+	longitude := solarLongitude(moment)
+	omega := 125.04 - 1934.136*t
+	obliquity := 23.439291111 - 0.013004167*t - 0.000000164*t*t + 0.000000504*t*t*t + 0.00256*cosDeg(omega)
+	rightAscension = degrees(atan2Deg(cosDeg(obliquity)*sinDeg(longitude), cosDeg(longitude)))
+	declination = asinDeg(sinDeg(obliquity) * sinDeg(longitude))
+	return rightAscension, declination
+}
+
+// siderealDegreesPerDay is the rate the Earth turns relative to the stars.
+const siderealDegreesPerDay = 360.98564736629
+
+// greenwichSiderealTime returns the mean sidereal time at Greenwich at a
+// moment, in degrees.
+func greenwichSiderealTime(moment float64) float64 {
+	d := moment + fixedToJulianDay - 2451545
+	t := d / 36525
+	return degrees(280.46061837 + siderealDegreesPerDay*d + 0.000387933*t*t - t*t*t/38710000)
+}
+
+// sunHourAngle returns the Sun's local hour angle at a moment seen from
+// longitude, in degrees from -180 to 180: negative before it crosses the
+// meridian and positive after.
+func sunHourAngle(moment, longitude float64) float64 {
+	rightAscension, _ := sunEquatorial(moment)
+	return signedDegrees(greenwichSiderealTime(moment) + longitude - rightAscension)
+}
+
+// sunAltitudeAzimuth returns the Sun's elevation above the horizon,
+// corrected for atmospheric refraction, and its azimuth east of north,
+// both in degrees, at a moment seen from latitude and longitude.
+func sunAltitudeAzimuth(moment, latitude, longitude float64) (elevation, azimuth float64) {
+	_, declination := sunEquatorial(moment)
+	h := sunHourAngle(moment, longitude)
+	elevation = asinDeg(sinDeg(latitude)*sinDeg(declination) + cosDeg(latitude)*cosDeg(declination)*cosDeg(h))
+	azimuth = degrees(atan2Deg(sinDeg(h), cosDeg(h)*sinDeg(latitude)-tanDeg(declination)*cosDeg(latitude)) + 180)
+	if elevation > -1 {
+		// Sæmundsson's formula, in arcminutes.
+		elevation += 1.02 / tanDeg(elevation+10.3/(elevation+5.11)) / 60
+	}
+	return elevation, azimuth
+}
+
+// estimatePriorSolarLongitude returns a moment shortly before the last time
+// at or before moment that the Sun's longitude was lambda.
+func estimatePriorSolarLongitude(lambda, moment float64) float64 {
+	rate := meanTropicalYear / 360
+	tau := moment - rate*degrees(solarLongitude(moment)-lambda)
+	delta := degrees(solarLongitude(tau)-lambda+180) - 180
+	return math.Min(moment, tau-rate*delta)
+}
+
+// nthNewMoon returns the moment of new moon number n, counted from the new
+// moon of 6 January 2000.
+func nthNewMoon(n int) float64 {
+	k := float64(n)
+	t := k / 1236.85
+	jde := 2451550.09766 + meanSynodicMonth*k + 0.00015437*t*t - 0.000000150*t*t*t + 0.00000000073*t*t*t*t
+	e := 1 - 0.002516*t - 0.0000074*t*t
+	sun := 2.5534 + 29.10535670*k - 0.0000014*t*t - 0.00000011*t*t*t
+	moon := 201.5643 + 385.81693528*k + 0.0107582*t*t + 0.00001238*t*t*t - 0.000000058*t*t*t*t
+	f := 160.7108 + 390.67050284*k - 0.0016118*t*t - 0.00000227*t*t*t + 0.000000011*t*t*t*t
+	omega := 124.7746 - 1.56375588*k + 0.0020672*t*t + 0.00000215*t*t*t
+	correction := -0.40720*sinDeg(moon) +
+		0.17241*e*sinDeg(sun) +
+		0.01608*sinDeg(2*moon) +
+		0.01039*sinDeg(2*f) +
+		0.00739*e*sinDeg(moon-sun) -
+		0.00514*e*sinDeg(moon+sun) +
+		0.00208*e*e*sinDeg(2*sun) -
+		0.00111*sinDeg(moon-2*f) -
+		0.00057*sinDeg(moon+2*f) +
+		0.00056*e*sinDeg(2*moon+sun) -
+		0.00042*sinDeg(3*moon) +
+		0.00042*e*sinDeg(sun+2*f) +
+		0.00038*e*sinDeg(sun-2*f) -
+		0.00024*e*sinDeg(2*moon-sun) -
+		0.00017*sinDeg(omega) -
+		0.00007*sinDeg(moon+2*sun) +
+		0.00004*sinDeg(2*moon-2*f) +
+		0.00004*sinDeg(3*sun) +
+		0.00003*sinDeg(moon+sun-2*f) +
+		0.00003*sinDeg(2*moon+2*f) -
+		0.00003*sinDeg(moon+sun+2*f) +
+		0.00003*sinDeg(moon-sun+2*f) -
+		0.00002*sinDeg(moon-sun-2*f) -
+		0.00002*sinDeg(3*moon+sun) +
+		0.00002*sinDeg(4*moon)
+	moment := jde + correction - fixedToJulianDay
+	return moment - deltaT(moment)
+}
+
+// newMoonAtOrAfter returns the moment of the first new moon at or after
+// moment.
+func newMoonAtOrAfter(moment float64) float64 {
+	n := int(math.Round((moment + fixedToJulianDay - 2451550.09766) / meanSynodicMonth))
+	for nthNewMoon(n) < moment {
+		n++
+	}
+	for nthNewMoon(n-1) >= moment {
+		n--
+	}
+	return nthNewMoon(n)
+}
+
+// newMoonBefore returns the moment of the last new moon before moment.
+func newMoonBefore(moment float64) float64 {
+	n := int(math.Round((moment + fixedToJulianDay - 2451550.09766) / meanSynodicMonth))
+	for nthNewMoon(n) >= moment {
+		n--
+	}
+	for nthNewMoon(n+1) < moment {
+		n++
+	}
+	return nthNewMoon(n)
+}
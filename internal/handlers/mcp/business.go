@@ -0,0 +1,139 @@
+package mcp
+
+import (
+	"time"
+)
+
+// businessCalendar decides which days are working days from a holiday
+// calendar, which may be nil, and a weekend.
+type businessCalendar struct {
+	calendar HolidayCalendar
+	weekend  Weekend
+	years    map[int]map[time.Time]Holiday
+}
+
+func newBusinessCalendar(calendar HolidayCalendar, weekend Weekend) *businessCalendar {
+	return &businessCalendar{calendar: calendar, weekend: weekend, years: map[int]map[time.Time]Holiday{}}
+}
+
+// civilDate returns t's calendar date in its own location as midnight UTC.
+func civilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// holiday returns the holiday observed on the calendar date d, if any.
+func (b *businessCalendar) holiday(d time.Time) (Holiday, bool) {
+	if b.calendar == nil {
+		return Holiday{}, false
+	}
+	d = civilDate(d)
+	byDate, ok := b.years[d.Year()]
+	if !ok {
+		byDate = map[time.Time]Holiday{}
+		for _, h := range b.calendar.Holidays(d.Year()) {
+			if _, dup := byDate[h.Date]; !dup {
+				byDate[h.Date] = h
+			}
+		}
+		b.years[d.Year()] = byDate
+	}
+	h, ok := byDate[d]
+	return h, ok
+}
+
+// isBusinessDay reports whether d's calendar date is neither a weekend day nor
+// a holiday.
+func (b *businessCalendar) isBusinessDay(d time.Time) bool {
+	if b.weekend.Contains(d.Weekday()) {
+		return false
+	}
+	_, holiday := b.holiday(d)
+	return !holiday
+}
+
+// maxBusinessDaySpan bounds the calendar days addBusinessDays and between
+// walk: 400 years.
+const maxBusinessDaySpan = 146097
+
+// addBusinessDays moves t by n working days, keeping its time of day, and
+// returns the holidays that were skipped on the way.  A zero n leaves t
+// unchanged.  It fails rather than walk more than maxBusinessDaySpan days.
+func (b *businessCalendar) addBusinessDays(t time.Time, n int, dst DSTPolicy) (time.Time, []Holiday, error) {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	if n > maxBusinessDaySpan {
+		return time.Time{}, nil, NewBusinessDaySpanError(n)
+	}
+	day := civilDate(t)
+	var skipped []Holiday
+	for walked := 1; n > 0; walked++ {
+		if walked > maxBusinessDaySpan {
+			return time.Time{}, nil, NewBusinessDaySpanError(walked)
+		}
+		day = day.AddDate(0, 0, step)
+		if b.weekend.Contains(day.Weekday()) {
+			continue
+		}
+		if h, ok := b.holiday(day); ok {
+			skipped = append(skipped, h)
+			continue
+		}
+		n--
+	}
+
+	hour, min, sec := t.Clock()
+	wc, err := resolveWallClock(day.Year(), day.Month(), day.Day(), hour, min, sec, t.Nanosecond(), t.Location(), dst)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	return wc.Time, skipped, nil
+}
+
+// businessDaySpan counts the days from the calendar date of first up to, but
+// not including, that of second.  Counts are negative when second is before
+// first.
+type businessDaySpan struct {
+	BusinessDays int
+	CalendarDays int
+	WeekendDays  int
+	Holidays     []Holiday
+}
+
+// between fails for spans of more than maxBusinessDaySpan days.
+func (b *businessCalendar) between(first, second time.Time) (businessDaySpan, error) {
+	start, end := civilDate(first), civilDate(second)
+	sign := 1
+	if end.Before(start) {
+		start, end, sign = end, start, -1
+	}
+	if days := int((end.Unix() - start.Unix()) / 86400); days > maxBusinessDaySpan {
+		return businessDaySpan{}, NewBusinessDaySpanError(days)
+	}
+
+	var span businessDaySpan
+	for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+		span.CalendarDays++
+		switch h, holiday := b.holiday(d); {
+		case b.weekend.Contains(d.Weekday()):
+			span.WeekendDays++
+		case holiday:
+			span.Holidays = append(span.Holidays, h)
+		default:
+			span.BusinessDays++
+		}
+	}
+	span.BusinessDays *= sign
+	span.CalendarDays *= sign
+	span.WeekendDays *= sign
+	return span, nil
+}
+
+// calendarID returns the ID of the holiday calendar, or "" without one.
+func (b *businessCalendar) calendarID() string {
+	if b.calendar == nil {
+		return ""
+	}
+	return b.calendar.ID()
+}
@@ -0,0 +1,517 @@
+package mcp
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// The conversions here count days as fixed day numbers, with day 1 on
+// Monday 1 January of year 1 in the proleptic Gregorian calendar, and
+// follow Reingold and Dershowitz, Calendrical Calculations.
+
+// fixedEpoch is the day before fixed day 1.
+var fixedEpoch = time.Date(0, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+// fixedToJulianDayNumber converts a fixed day number to a Julian day
+// number, which counts days from 1 January 4713 BC (Julian).
+const fixedToJulianDayNumber = 1721425
+
+// fixedFromTime returns the fixed day number of the calendar date of t.
+func fixedFromTime(t time.Time) int {
+	return int((civilDate(t).Unix() - fixedEpoch.Unix()) / 86400)
+}
+
+// timeFromFixed returns the Gregorian date of a fixed day number.
+func timeFromFixed(fixed int) time.Time {
+	return time.Unix(fixedEpoch.Unix()+int64(fixed)*86400, 0).UTC()
+}
+
+// CalendarDate is a date in one of the calendars of CalendarSystems.
+type CalendarDate struct {
+	Calendar string
+	Year     int
+	// Month is from 1; for the ISO calendar it is the week and Day the
+	// weekday, from 1 for Monday.
+	Month     int
+	Day       int
+	LeapMonth bool
+	LeapYear  bool
+}
+
+// CalendarSystem converts between fixed day numbers and the dates of a
+// calendar.
+type CalendarSystem struct {
+	ID   string
+	Name string
+	// MinYear and MaxYear bound the Gregorian years the conversion is
+	// reliable for.
+	MinYear, MaxYear int
+	// MaxMonth and MaxDay are the largest month and day numbers a date
+	// may have.
+	MaxMonth, MaxDay int
+	// RepeatsMonths is whether a leap month shares the number of the month
+	// before it, so that converting a date needs to know which it is in.
+	RepeatsMonths bool
+	fromFixed     func(fixed int) CalendarDate
+	toFixed       func(year, month, day int, leapMonth bool) int
+	monthName     func(d CalendarDate) string
+	format        func(d CalendarDate, month string) string
+	// yearName, if set, names years, as the Chinese calendar does.
+	yearName func(year int) string
+}
+
+// CalendarSystems lists the calendars convertCalendar supports.
+var CalendarSystems = []*CalendarSystem{
+	{
+		ID: "gregorian", Name: "Gregorian", MinYear: 1, MaxYear: 9999, MaxMonth: 12, MaxDay: 31,
+		fromFixed: func(fixed int) CalendarDate {
+			t := timeFromFixed(fixed)
+			return CalendarDate{Year: t.Year(), Month: int(t.Month()), Day: t.Day(), LeapYear: daysIn(time.February, t.Year()) == 29}
+		},
+		toFixed: func(year, month, day int, _ bool) int {
+			return fixedFromTime(time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC))
+		},
+		monthName: func(d CalendarDate) string { return time.Month(d.Month).String() },
+		format:    dayMonthYear(""),
+	},
+	{
+		ID: "julian", Name: "Julian", MinYear: 1, MaxYear: 9999, MaxMonth: 12, MaxDay: 31,
+		fromFixed: julianFromFixed,
+		toFixed:   func(year, month, day int, _ bool) int { return fixedFromJulian(year, month, day) },
+		monthName: func(d CalendarDate) string { return time.Month(d.Month).String() },
+		format:    dayMonthYear(""),
+	},
+	{
+		ID: "iso", Name: "ISO 8601 week date", MinYear: 1, MaxYear: 9999, MaxMonth: 53, MaxDay: 7,
+		fromFixed: isoFromFixed,
+		toFixed:   func(year, week, day int, _ bool) int { return fixedFromISO(year, week, day) },
+		monthName: func(d CalendarDate) string { return fmt.Sprintf("Week %d", d.Month) },
+		format: func(d CalendarDate, _ string) string {
+			return fmt.Sprintf("%04d-W%02d-%d", d.Year, d.Month, d.Day)
+		},
+	},
+	{
+		ID: "hebrew", Name: "Hebrew", MinYear: 1, MaxYear: 9999, MaxMonth: 13, MaxDay: 30,
+		fromFixed: hebrewFromFixed,
+		toFixed:   func(year, month, day int, _ bool) int { return fixedFromHebrew(year, month, day) },
+		monthName: hebrewMonthName,
+		format:    dayMonthYear(" AM"),
+	},
+	{
+		ID: "islamic", Name: "Islamic (tabular, civil epoch)", MinYear: 622, MaxYear: 9999, MaxMonth: 12, MaxDay: 30,
+		fromFixed: islamicFromFixed,
+		toFixed:   func(year, month, day int, _ bool) int { return fixedFromIslamic(year, month, day) },
+		monthName: func(d CalendarDate) string { return islamicMonths[d.Month-1] },
+		format:    dayMonthYear(" AH"),
+	},
+	{
+		ID: "persian", Name: "Persian (Solar Hijri)", MinYear: 622, MaxYear: 3700, MaxMonth: 12, MaxDay: 31,
+		fromFixed: persianFromFixed,
+		toFixed:   func(year, month, day int, _ bool) int { return fixedFromPersian(year, month, day) },
+		monthName: func(d CalendarDate) string { return persianMonths[d.Month-1] },
+		format:    dayMonthYear(" SH"),
+	},
+	{
+		ID: "chinese", Name: "Chinese lunisolar", MinYear: 1645, MaxYear: 2200, MaxMonth: 12, MaxDay: 30, RepeatsMonths: true,
+		fromFixed: chineseFromFixed,
+		toFixed:   fixedFromChinese,
+		monthName: chineseMonthName,
+		format:    formatChineseDate,
+		yearName:  chineseYearName,
+	},
+	{
+		ID: "coptic", Name: "Coptic", MinYear: 285, MaxYear: 9999, MaxMonth: 13, MaxDay: 30,
+		fromFixed: func(fixed int) CalendarDate { return copticFromFixed(fixed, copticEpoch) },
+		toFixed: func(year, month, day int, _ bool) int {
+			return fixedFromCoptic(year, month, day, copticEpoch)
+		},
+		monthName: func(d CalendarDate) string { return copticMonths[d.Month-1] },
+		format:    dayMonthYear(" AM"),
+	},
+	{
+		ID: "ethiopian", Name: "Ethiopian", MinYear: 9, MaxYear: 9999, MaxMonth: 13, MaxDay: 30,
+		fromFixed: func(fixed int) CalendarDate { return copticFromFixed(fixed, ethiopianEpoch) },
+		toFixed: func(year, month, day int, _ bool) int {
+			return fixedFromCoptic(year, month, day, ethiopianEpoch)
+		},
+		monthName: func(d CalendarDate) string { return ethiopianMonths[d.Month-1] },
+		format:    dayMonthYear(" EC"),
+	},
+}
+
+// calendarSystemIDs lists the IDs of CalendarSystems.
+func calendarSystemIDs() []string {
+	var ids []string
+	for _, c := range CalendarSystems {
+		ids = append(ids, c.ID)
+	}
+	return ids
+}
+
+// LookupCalendarSystem returns the calendar with the given case-insensitive
+// ID.
+func LookupCalendarSystem(id string) (*CalendarSystem, error) {
+	normalized := strings.ToLower(strings.TrimSpace(id))
+	for _, c := range CalendarSystems {
+		if c.ID == normalized {
+			return c, nil
+		}
+	}
+	return nil, NewUnknownCalendarSystemError(id, calendarSystemIDs())
+}
+
+// Supports reports whether the conversion of fixed is reliable in c.
+func (c *CalendarSystem) Supports(fixed int) bool {
+	year := timeFromFixed(fixed).Year()
+	return year >= c.MinYear && year <= c.MaxYear
+}
+
+// yearRange returns the years in c's own numbering that overlap the
+// Gregorian years c supports.
+func (c *CalendarSystem) yearRange() (minYear, maxYear int) {
+	first := fixedFromTime(time.Date(c.MinYear, time.January, 1, 0, 0, 0, 0, time.UTC))
+	last := fixedFromTime(time.Date(c.MaxYear, time.December, 31, 0, 0, 0, 0, time.UTC))
+	return c.fromFixed(first).Year, c.fromFixed(last).Year
+}
+
+// FromFixed returns the date in c of a fixed day number.
+func (c *CalendarSystem) FromFixed(fixed int) CalendarDate {
+	d := c.fromFixed(fixed)
+	d.Calendar = c.ID
+	return d
+}
+
+// ToFixed returns the fixed day number of a date in c, or an error if the
+// calendar has no such date, such as 30 Heshvan in a year when Heshvan has
+// 29 days.
+func (c *CalendarSystem) ToFixed(year, month, day int, leapMonth bool) (int, error) {
+	if !c.RepeatsMonths {
+		leapMonth = false
+	}
+	minYear, maxYear := c.yearRange()
+	if year < minYear || year > maxYear || month < 1 || month > c.MaxMonth || day < 1 || day > c.MaxDay {
+		return 0, NewInvalidCalendarDateError(c.Name, year, month, day, leapMonth)
+	}
+	fixed := c.toFixed(year, month, day, leapMonth)
+	d := c.fromFixed(fixed)
+	if d.Year != year || d.Month != month || d.Day != day || (c.RepeatsMonths && d.LeapMonth != leapMonth) || !c.Supports(fixed) {
+		return 0, NewInvalidCalendarDateError(c.Name, year, month, day, leapMonth)
+	}
+	return fixed, nil
+}
+
+// MonthName names the month of d, e.g. "Tishri" or "Leap Liuyue".
+func (c *CalendarSystem) MonthName(d CalendarDate) string {
+	return c.monthName(d)
+}
+
+// Format writes d out in full, e.g. "9 Tishri 5786 AM".
+func (c *CalendarSystem) Format(d CalendarDate) string {
+	return c.format(d, c.monthName(d))
+}
+
+// YearName names the year of d, or returns "" if c does not name years.
+func (c *CalendarSystem) YearName(d CalendarDate) string {
+	if c.yearName == nil {
+		return ""
+	}
+	return c.yearName(d.Year)
+}
+
+// dayMonthYear formats dates as the day, month name and year followed by
+// an era.
+func dayMonthYear(era string) func(d CalendarDate, month string) string {
+	return func(d CalendarDate, month string) string {
+		return fmt.Sprintf("%d %s %d%s", d.Day, month, d.Year, era)
+	}
+}
+
+// julianEpoch is the fixed day number of 1 January 1 in the Julian
+// calendar.
+const julianEpoch = -1
+
+func isJulianLeapYear(year int) bool {
+	if year > 0 {
+		return floorMod(year, 4) == 0
+	}
+	return floorMod(year, 4) == 3
+}
+
+// fixedFromJulian counts years as historians do, with 1 BC before AD 1.
+func fixedFromJulian(year, month, day int) int {
+	y := year
+	if year < 0 {
+		y++
+	}
+	fixed := julianEpoch - 1 + 365*(y-1) + floorDiv(y-1, 4) + floorDiv(367*month-362, 12) + day
+	switch {
+	case month <= 2:
+	case isJulianLeapYear(year):
+		fixed--
+	default:
+		fixed -= 2
+	}
+	return fixed
+}
+
+func julianFromFixed(fixed int) CalendarDate {
+	approx := floorDiv(4*(fixed-julianEpoch)+1464, 1461)
+	year := approx
+	if approx <= 0 {
+		year--
+	}
+	priorDays := fixed - fixedFromJulian(year, 1, 1)
+	correction := 0
+	if fixed >= fixedFromJulian(year, 3, 1) {
+		correction = 2
+		if isJulianLeapYear(year) {
+			correction = 1
+		}
+	}
+	month := floorDiv(12*(priorDays+correction)+373, 367)
+	day := fixed - fixedFromJulian(year, month, 1) + 1
+	return CalendarDate{Year: year, Month: month, Day: day, LeapYear: isJulianLeapYear(year)}
+}
+
+func isoFromFixed(fixed int) CalendarDate {
+	t := timeFromFixed(fixed)
+	year, week := t.ISOWeek()
+	_, lastWeek := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return CalendarDate{Year: year, Month: week, Day: (int(t.Weekday())+6)%7 + 1, LeapYear: lastWeek == 53}
+}
+
+func fixedFromISO(year, week, day int) int {
+	jan4 := fixedFromTime(time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC))
+	monday := jan4 - floorMod(jan4-1, 7)
+	return monday + 7*(week-1) + day - 1
+}
+
+// hebrewEpoch is the fixed day number of 1 Tishri AM 1.
+const hebrewEpoch = -1373427
+
+// Hebrew months are numbered from Nisan, as in the Bible, although the
+// year starts with Tishri, month 7.  Leap years add Adar II as month 13.
+var hebrewMonths = []string{"Nisan", "Iyyar", "Sivan", "Tammuz", "Av", "Elul", "Tishri", "Heshvan", "Kislev", "Tevet", "Shevat", "Adar"}
+
+func isHebrewLeapYear(year int) bool {
+	return floorMod(7*year+1, 19) < 7
+}
+
+func lastMonthOfHebrewYear(year int) int {
+	if isHebrewLeapYear(year) {
+		return 13
+	}
+	return 12
+}
+
+// hebrewCalendarElapsedDays counts the days from the epoch to the molad of
+// Tishri of year, delayed when it would fall on a Sunday, Wednesday or
+// Friday.
+func hebrewCalendarElapsedDays(year int) int {
+	monthsElapsed := floorDiv(235*year-234, 19)
+	partsElapsed := 12084 + 13753*monthsElapsed
+	days := 29*monthsElapsed + floorDiv(partsElapsed, 25920)
+	if floorMod(3*(days+1), 7) < 3 {
+		return days + 1
+	}
+	return days
+}
+
+// hebrewYearLengthCorrection delays the new year to keep years to lengths
+// of 353 to 355 or 383 to 385 days.
+func hebrewYearLengthCorrection(year int) int {
+	ny0 := hebrewCalendarElapsedDays(year - 1)
+	ny1 := hebrewCalendarElapsedDays(year)
+	ny2 := hebrewCalendarElapsedDays(year + 1)
+	switch {
+	case ny2-ny1 == 356:
+		return 2
+	case ny1-ny0 == 382:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func hebrewNewYear(year int) int {
+	return hebrewEpoch + hebrewCalendarElapsedDays(year) + hebrewYearLengthCorrection(year)
+}
+
+func daysInHebrewYear(year int) int {
+	return hebrewNewYear(year+1) - hebrewNewYear(year)
+}
+
+func lastDayOfHebrewMonth(month, year int) int {
+	days := daysInHebrewYear(year)
+	switch {
+	case month == 2 || month == 4 || month == 6 || month == 10 || month == 13,
+		month == 12 && !isHebrewLeapYear(year),
+		month == 8 && days%10 != 5,
+		month == 9 && days%10 == 3:
+		// Heshvan is long in complete years of 355 or 385 days and
+		// Kislev short in deficient ones of 353 or 383.
+		return 29
+	default:
+		return 30
+	}
+}
+
+func fixedFromHebrew(year, month, day int) int {
+	fixed := hebrewNewYear(year) + day - 1
+	if month < 7 {
+		for m := 7; m <= lastMonthOfHebrewYear(year); m++ {
+			fixed += lastDayOfHebrewMonth(m, year)
+		}
+		for m := 1; m < month; m++ {
+			fixed += lastDayOfHebrewMonth(m, year)
+		}
+		return fixed
+	}
+	for m := 7; m < month; m++ {
+		fixed += lastDayOfHebrewMonth(m, year)
+	}
+	return fixed
+}
+
+func hebrewFromFixed(fixed int) CalendarDate {
+	year := int(math.Floor(float64(fixed-hebrewEpoch) / (35975351.0 / 98496)))
+	for hebrewNewYear(year+1) <= fixed {
+		year++
+	}
+	month := 1
+	if fixed < fixedFromHebrew(year, 1, 1) {
+		month = 7
+	}
+	for fixed > fixedFromHebrew(year, month, lastDayOfHebrewMonth(month, year)) {
+		month++
+	}
+	leap := isHebrewLeapYear(year)
+	return CalendarDate{
+		Year:      year,
+		Month:     month,
+		Day:       fixed - fixedFromHebrew(year, month, 1) + 1,
+		LeapMonth: leap && month == 12,
+		LeapYear:  leap,
+	}
+}
+
+// hebrewMonthName names Adar "Adar I" in leap years, when Adar II follows
+// it.
+func hebrewMonthName(d CalendarDate) string {
+	switch {
+	case d.Month == 13:
+		return "Adar II"
+	case d.Month == 12 && d.LeapYear:
+		return "Adar I"
+	default:
+		return hebrewMonths[d.Month-1]
+	}
+}
+
+// islamicEpoch is the fixed day number of 1 Muharram AH 1 (16 July 622,
+// Julian) in the tabular calendar.
+const islamicEpoch = 227015
+
+var islamicMonths = []string{"Muharram", "Safar", "Rabi' al-awwal", "Rabi' al-thani", "Jumada al-awwal", "Jumada al-thani", "Rajab", "Sha'ban", "Ramadan", "Shawwal", "Dhu al-Qa'dah", "Dhu al-Hijjah"}
+
+// isIslamicLeapYear follows the common tabular rule: 11 leap years, of 355
+// days, in each cycle of 30.
+func isIslamicLeapYear(year int) bool {
+	return floorMod(14+11*year, 30) < 11
+}
+
+func fixedFromIslamic(year, month, day int) int {
+	return day + 29*(month-1) + floorDiv(6*month-1, 11) + (year-1)*354 + floorDiv(3+11*year, 30) + islamicEpoch - 1
+}
+
+func islamicFromFixed(fixed int) CalendarDate {
+	year := floorDiv(30*(fixed-islamicEpoch)+10646, 10631)
+	priorDays := fixed - fixedFromIslamic(year, 1, 1)
+	month := floorDiv(11*priorDays+330, 325)
+	return CalendarDate{Year: year, Month: month, Day: fixed - fixedFromIslamic(year, month, 1) + 1, LeapYear: isIslamicLeapYear(year)}
+}
+
+var persianMonths = []string{"Farvardin", "Ordibehesht", "Khordad", "Tir", "Mordad", "Shahrivar", "Mehr", "Aban", "Azar", "Dey", "Bahman", "Esfand"}
+
+// persianBreaks are the years of the Solar Hijri calendar at which its
+// pattern of 33-year cycles changes, following Kazimierz Borkowski's
+// algorithm, which matches the astronomical calendar from AP -61 to 3177.
+var persianBreaks = []int{-61, 9, 38, 199, 426, 686, 756, 818, 1111, 1181, 1210, 1635, 2060, 2097, 2192, 2262, 2324, 2394, 2456, 3178}
+
+// persianYear returns the Gregorian year year starts in, the day of March
+// it starts on and whether it is a leap year.
+func persianYear(year int) (gregorianYear, march int, leap bool) {
+	gregorianYear = year + 621
+	leapJ := -14
+	jp := persianBreaks[0]
+	jump := 0
+	for _, jm := range persianBreaks[1:] {
+		jump = jm - jp
+		if year < jm {
+			break
+		}
+		leapJ += jump/33*8 + jump%33/4
+		jp = jm
+	}
+	n := year - jp
+	leapJ += n/33*8 + (n%33+3)/4
+	if jump%33 == 4 && jump-n == 4 {
+		leapJ++
+	}
+	leapG := gregorianYear/4 - (gregorianYear/100+1)*3/4 - 150
+	march = 20 + leapJ - leapG
+	if jump-n < 6 {
+		n = n - jump + (jump+4)/33*33
+	}
+	return gregorianYear, march, ((n+1)%33-1)%4 == 0
+}
+
+func fixedFromPersian(year, month, day int) int {
+	gregorianYear, march, _ := persianYear(year)
+	nowruz := fixedFromTime(time.Date(gregorianYear, time.March, march, 0, 0, 0, 0, time.UTC))
+	if month <= 7 {
+		return nowruz + 31*(month-1) + day - 1
+	}
+	return nowruz + 186 + 30*(month-7) + day - 1
+}
+
+func persianFromFixed(fixed int) CalendarDate {
+	year := timeFromFixed(fixed).Year() - 621
+	if fixed < fixedFromPersian(year, 1, 1) {
+		year--
+	}
+	_, _, leap := persianYear(year)
+	days := fixed - fixedFromPersian(year, 1, 1)
+	month := 7 + (days-186)/30
+	if days < 186 {
+		month = 1 + days/31
+	}
+	return CalendarDate{Year: year, Month: month, Day: fixed - fixedFromPersian(year, month, 1) + 1, LeapYear: leap}
+}
+
+// copticEpoch and ethiopianEpoch are the fixed day numbers of 1 Thout AM 1
+// (29 August 284, Julian) and 1 Meskerem 1 (29 August 8, Julian).
+const (
+	copticEpoch    = 103605
+	ethiopianEpoch = 2796
+)
+
+var (
+	copticMonths    = []string{"Thout", "Paopi", "Hathor", "Koiak", "Tobi", "Meshir", "Paremhat", "Parmouti", "Pashons", "Paoni", "Epip", "Mesori", "Pi Kogi Enavot"}
+	ethiopianMonths = []string{"Meskerem", "Tikimt", "Hidar", "Tahsas", "Tir", "Yekatit", "Megabit", "Miyazya", "Ginbot", "Sene", "Hamle", "Nehase", "Pagume"}
+)
+
+// fixedFromCoptic converts a date in the Coptic calendar, or with the
+// Ethiopian epoch the Ethiopian calendar, which share twelve months of 30
+// days and a thirteenth of 5 or, every fourth year, 6.
+func fixedFromCoptic(year, month, day, epoch int) int {
+	return epoch - 1 + 365*(year-1) + floorDiv(year, 4) + 30*(month-1) + day
+}
+
+func copticFromFixed(fixed, epoch int) CalendarDate {
+	year := floorDiv(4*(fixed-epoch)+1463, 1461)
+	month := (fixed-fixedFromCoptic(year, 1, 1, epoch))/30 + 1
+	return CalendarDate{Year: year, Month: month, Day: fixed + 1 - fixedFromCoptic(year, month, 1, epoch), LeapYear: floorMod(year, 4) == 3}
+}
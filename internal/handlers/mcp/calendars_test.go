@@ -0,0 +1,140 @@
+package mcp
+
+import (
+	"testing"
+	"time"
+)
+
+// calendarReferenceDates are conversions published in Reingold and
+// Dershowitz, Calendrical Calculations, and by the calendars' authorities.
+var calendarReferenceDates = []struct {
+	gregorian string
+	calendar  string
+	want      CalendarDate
+}{
+	{gregorian: "1945-11-12", calendar: "julian", want: CalendarDate{Year: 1945, Month: 10, Day: 30}},
+	{gregorian: "1582-10-15", calendar: "julian", want: CalendarDate{Year: 1582, Month: 10, Day: 5}},
+	{gregorian: "2094-07-18", calendar: "julian", want: CalendarDate{Year: 2094, Month: 7, Day: 5}},
+	{gregorian: "1945-11-12", calendar: "hebrew", want: CalendarDate{Year: 5706, Month: 9, Day: 7}},
+	{gregorian: "2094-07-18", calendar: "hebrew", want: CalendarDate{Year: 5854, Month: 5, Day: 5}},
+	{gregorian: "2025-09-23", calendar: "hebrew", want: CalendarDate{Year: 5786, Month: 7, Day: 1}},
+	{gregorian: "2024-03-11", calendar: "hebrew", want: CalendarDate{Year: 5784, Month: 13, Day: 1}},
+	{gregorian: "2024-02-10", calendar: "hebrew", want: CalendarDate{Year: 5784, Month: 12, Day: 1, LeapMonth: true}},
+	{gregorian: "1945-11-12", calendar: "islamic", want: CalendarDate{Year: 1364, Month: 12, Day: 6}},
+	{gregorian: "2094-07-18", calendar: "islamic", want: CalendarDate{Year: 1518, Month: 3, Day: 5}},
+	{gregorian: "2024-03-21", calendar: "persian", want: CalendarDate{Year: 1403, Month: 1, Day: 2}},
+	{gregorian: "2025-03-20", calendar: "persian", want: CalendarDate{Year: 1403, Month: 12, Day: 30}},
+	{gregorian: "2025-03-21", calendar: "persian", want: CalendarDate{Year: 1404, Month: 1, Day: 1}},
+	{gregorian: "2025-10-01", calendar: "persian", want: CalendarDate{Year: 1404, Month: 7, Day: 9}},
+	{gregorian: "1945-11-12", calendar: "coptic", want: CalendarDate{Year: 1662, Month: 3, Day: 3}},
+	{gregorian: "2094-07-18", calendar: "coptic", want: CalendarDate{Year: 1810, Month: 11, Day: 11}},
+	{gregorian: "1945-11-12", calendar: "ethiopian", want: CalendarDate{Year: 1938, Month: 3, Day: 3}},
+	{gregorian: "2025-09-11", calendar: "ethiopian", want: CalendarDate{Year: 2018, Month: 1, Day: 1}},
+	{gregorian: "2024-02-10", calendar: "chinese", want: CalendarDate{Year: 2024, Month: 1, Day: 1}},
+	{gregorian: "2025-01-29", calendar: "chinese", want: CalendarDate{Year: 2025, Month: 1, Day: 1}},
+	{gregorian: "2025-01-28", calendar: "chinese", want: CalendarDate{Year: 2024, Month: 12, Day: 29}},
+	{gregorian: "2025-10-06", calendar: "chinese", want: CalendarDate{Year: 2025, Month: 8, Day: 15}},
+	{gregorian: "2025-07-25", calendar: "chinese", want: CalendarDate{Year: 2025, Month: 6, Day: 1, LeapMonth: true}},
+	{gregorian: "2023-03-22", calendar: "chinese", want: CalendarDate{Year: 2023, Month: 2, Day: 1, LeapMonth: true}},
+	{gregorian: "2020-05-23", calendar: "chinese", want: CalendarDate{Year: 2020, Month: 4, Day: 1, LeapMonth: true}},
+	{gregorian: "2033-12-22", calendar: "chinese", want: CalendarDate{Year: 2033, Month: 11, Day: 1, LeapMonth: true}},
+	{gregorian: "1900-01-31", calendar: "chinese", want: CalendarDate{Year: 1900, Month: 1, Day: 1}},
+	{gregorian: "2021-01-04", calendar: "iso", want: CalendarDate{Year: 2021, Month: 1, Day: 1}},
+	{gregorian: "2021-01-03", calendar: "iso", want: CalendarDate{Year: 2020, Month: 53, Day: 7}},
+}
+
+func TestCalendarConversions(t *testing.T) {
+	for _, tc := range calendarReferenceDates {
+		t.Run(tc.calendar+" "+tc.gregorian, func(t *testing.T) {
+			c, err := LookupCalendarSystem(tc.calendar)
+			if err != nil {
+				t.Fatal(err)
+			}
+			g, _ := time.Parse(dateFormat, tc.gregorian)
+			fixed := fixedFromTime(g)
+			got := c.FromFixed(fixed)
+			if got.Year != tc.want.Year || got.Month != tc.want.Month || got.Day != tc.want.Day || got.LeapMonth != tc.want.LeapMonth {
+				t.Errorf("FromFixed(%s) = %d-%d-%d leap month %t, want %d-%d-%d leap month %t", tc.gregorian, got.Year, got.Month, got.Day, got.LeapMonth, tc.want.Year, tc.want.Month, tc.want.Day, tc.want.LeapMonth)
+			}
+			back, err := c.ToFixed(tc.want.Year, tc.want.Month, tc.want.Day, tc.want.LeapMonth)
+			if err != nil {
+				t.Fatalf("ToFixed() error = %v", err)
+			}
+			if back != fixed {
+				t.Errorf("ToFixed() = %s, want %s", timeFromFixed(back).Format(dateFormat), tc.gregorian)
+			}
+		})
+	}
+}
+
+func TestCalendarRoundTrips(t *testing.T) {
+	start := fixedFromTime(time.Date(2019, time.December, 1, 0, 0, 0, 0, time.UTC))
+	for _, c := range CalendarSystems {
+		step := 1
+		if c.ID == "chinese" {
+			// The Chinese calendar is costly to compute.
+			step = 7
+		}
+		for fixed := start; fixed < start+800; fixed += step {
+			d := c.FromFixed(fixed)
+			back, err := c.ToFixed(d.Year, d.Month, d.Day, d.LeapMonth)
+			if err != nil || back != fixed {
+				t.Fatalf("%s: %s is %+v, which converts back to %d, %v", c.ID, timeFromFixed(fixed).Format(dateFormat), d, back-fixed, err)
+			}
+		}
+	}
+}
+
+func TestCalendarRoundTripsAtRangeEnds(t *testing.T) {
+	for _, c := range CalendarSystems {
+		for _, g := range []time.Time{
+			time.Date(c.MinYear, time.January, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(c.MaxYear, time.December, 31, 0, 0, 0, 0, time.UTC),
+		} {
+			fixed := fixedFromTime(g)
+			d := c.FromFixed(fixed)
+			back, err := c.ToFixed(d.Year, d.Month, d.Day, d.LeapMonth)
+			if err != nil || back != fixed {
+				t.Errorf("%s: %s is %s, which converts back to %s, %v", c.ID, g.Format(dateFormat), c.Format(d), timeFromFixed(back).Format(dateFormat), err)
+			}
+		}
+	}
+}
+
+func TestCalendarToFixedRejectsInvalidDates(t *testing.T) {
+	testCases := []struct {
+		calendar         string
+		year, month, day int
+		leapMonth        bool
+	}{
+		{calendar: "gregorian", year: 2025, month: 2, day: 29},
+		{calendar: "julian", year: 2025, month: 13, day: 1},
+		{calendar: "hebrew", year: 5785, month: 13, day: 1},
+		{calendar: "islamic", year: 1446, month: 2, day: 30},
+		{calendar: "persian", year: 1404, month: 12, day: 30},
+		{calendar: "coptic", year: 1741, month: 13, day: 6},
+		{calendar: "chinese", year: 2024, month: 6, day: 1, leapMonth: true},
+		{calendar: "iso", year: 2025, month: 53, day: 1},
+		{calendar: "chinese", year: 1500, month: 1, day: 1},
+	}
+	for _, tc := range testCases {
+		c, err := LookupCalendarSystem(tc.calendar)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.ToFixed(tc.year, tc.month, tc.day, tc.leapMonth); err == nil {
+			t.Errorf("%s ToFixed(%d, %d, %d, %t) succeeded, want an error", tc.calendar, tc.year, tc.month, tc.day, tc.leapMonth)
+		}
+	}
+	if _, err := LookupCalendarSystem("mayan"); err == nil {
+		t.Error("LookupCalendarSystem(mayan) succeeded")
+	}
+}
+
+func TestChineseYearName(t *testing.T) {
+	for year, want := range map[int]string{2024: "Jia-Chen (Wood Dragon)", 2025: "Yi-Si (Wood Snake)", 1984: "Jia-Zi (Wood Rat)", 2043: "Gui-Hai (Water Pig)"} {
+		if got := chineseYearName(year); got != want {
+			t.Errorf("chineseYearName(%d) = %s, want %s", year, got, want)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package mcp
+
+import (
+	"fmt"
+	"math"
+)
+
+// The Chinese calendar is computed from the Sun and Moon as seen from
+// Beijing, by the rules in force since 1645: months start on the day of a
+// new moon, the winter solstice falls in the eleventh month, and in a year
+// of thirteen months the first month without a major solar term is a leap
+// month repeating the number of the month before it.
+
+// chineseEpoch is the fixed day number of the first day of the first year
+// of the first sexagenary cycle, 15 February 2637 BC (Gregorian).
+const chineseEpoch = -963099
+
+var (
+	chineseMonths   = []string{"Zhengyue", "Eryue", "Sanyue", "Siyue", "Wuyue", "Liuyue", "Qiyue", "Bayue", "Jiuyue", "Shiyue", "Dongyue", "Layue"}
+	chineseStems    = []string{"Jia", "Yi", "Bing", "Ding", "Wu", "Ji", "Geng", "Xin", "Ren", "Gui"}
+	chineseBranches = []string{"Zi", "Chou", "Yin", "Mao", "Chen", "Si", "Wu", "Wei", "Shen", "You", "Xu", "Hai"}
+	chineseAnimals  = []string{"Rat", "Ox", "Tiger", "Rabbit", "Dragon", "Snake", "Horse", "Goat", "Monkey", "Rooster", "Dog", "Pig"}
+	chineseElements = []string{"Wood", "Fire", "Earth", "Metal", "Water"}
+)
+
+// amod is floorMod with n in place of 0.
+func amod(x, n int) int {
+	if m := floorMod(x, n); m != 0 {
+		return m
+	}
+	return n
+}
+
+// chinaOffset is the offset of Chinese time from Universal Time, in days:
+// Beijing's mean solar time before 1929 and UTC+8 since.
+func chinaOffset(moment float64) float64 {
+	if timeFromFixed(int(math.Floor(moment))).Year() < 1929 {
+		return 1397.0 / 180 / 24
+	}
+	return 8.0 / 24
+}
+
+// midnightInChina returns the moment a day starts in China.
+func midnightInChina(fixed int) float64 {
+	return float64(fixed) - chinaOffset(float64(fixed))
+}
+
+// chineseDay returns the day in China of a moment.
+func chineseDay(moment float64) int {
+	return int(math.Floor(moment + chinaOffset(moment)))
+}
+
+// chineseWinterSolsticeOnOrBefore returns the day in China of the last
+// winter solstice on or before fixed.
+func chineseWinterSolsticeOnOrBefore(fixed int) int {
+	approx := estimatePriorSolarLongitude(270, midnightInChina(fixed+1))
+	day := int(math.Floor(approx)) - 1
+	for solarLongitude(midnightInChina(day+1)) <= 270 {
+		day++
+	}
+	return day
+}
+
+// chineseNewMoonOnOrAfter returns the first day in China on or after fixed
+// with a new moon.
+func chineseNewMoonOnOrAfter(fixed int) int {
+	return chineseDay(newMoonAtOrAfter(midnightInChina(fixed)))
+}
+
+// chineseNewMoonBefore returns the last day in China before fixed with a
+// new moon.
+func chineseNewMoonBefore(fixed int) int {
+	return chineseDay(newMoonBefore(midnightInChina(fixed)))
+}
+
+// currentMajorSolarTerm returns the last major solar term, from 1 to 12, to
+// start on or before fixed.  Major term 1 starts when the Sun reaches 330°.
+func currentMajorSolarTerm(fixed int) int {
+	return amod(2+int(math.Floor(solarLongitude(midnightInChina(fixed))/30)), 12)
+}
+
+// chineseNoMajorSolarTerm reports whether the month starting on fixed has
+// no major solar term.
+func chineseNoMajorSolarTerm(fixed int) bool {
+	return currentMajorSolarTerm(fixed) == currentMajorSolarTerm(chineseNewMoonOnOrAfter(fixed+1))
+}
+
+// chinesePriorLeapMonth reports whether there is a leap month from the
+// month starting on start to the one starting on month.
+func chinesePriorLeapMonth(start, month int) bool {
+	for ; month >= start; month = chineseNewMoonBefore(month) {
+		if chineseNoMajorSolarTerm(month) {
+			return true
+		}
+	}
+	return false
+}
+
+// chineseNewYearInSui returns the Chinese new year in the year from the
+// winter solstice on or before fixed to the next.
+func chineseNewYearInSui(fixed int) int {
+	s1 := chineseWinterSolsticeOnOrBefore(fixed)
+	s2 := chineseWinterSolsticeOnOrBefore(s1 + 370)
+	m12 := chineseNewMoonOnOrAfter(s1 + 1)
+	m13 := chineseNewMoonOnOrAfter(m12 + 1)
+	nextM11 := chineseNewMoonBefore(s2 + 1)
+	if math.Round(float64(nextM11-m12)/meanSynodicMonth) == 12 && (chineseNoMajorSolarTerm(m12) || chineseNoMajorSolarTerm(m13)) {
+		return chineseNewMoonOnOrAfter(m13 + 1)
+	}
+	return m13
+}
+
+// chineseNewYearOnOrBefore returns the last Chinese new year on or before
+// fixed.
+func chineseNewYearOnOrBefore(fixed int) int {
+	if newYear := chineseNewYearInSui(fixed); fixed >= newYear {
+		return newYear
+	}
+	return chineseNewYearInSui(fixed - 180)
+}
+
+// chineseFromFixed returns the Chinese date of fixed.  Its year is the
+// Gregorian year the Chinese year starts in.
+func chineseFromFixed(fixed int) CalendarDate {
+	s1 := chineseWinterSolsticeOnOrBefore(fixed)
+	s2 := chineseWinterSolsticeOnOrBefore(s1 + 370)
+	m12 := chineseNewMoonOnOrAfter(s1 + 1)
+	nextM11 := chineseNewMoonBefore(s2 + 1)
+	m := chineseNewMoonBefore(fixed + 1)
+	leapSui := math.Round(float64(nextM11-m12)/meanSynodicMonth) == 12
+
+	month := int(math.Round(float64(m-m12) / meanSynodicMonth))
+	if leapSui && chinesePriorLeapMonth(m12, m) {
+		month--
+	}
+	month = amod(month, 12)
+	elapsedYears := int(math.Floor(1.5 - float64(month)/12 + float64(fixed-chineseEpoch)/meanTropicalYear))
+	newYear := chineseNewYearOnOrBefore(fixed)
+	return CalendarDate{
+		Year:      elapsedYears - 2637,
+		Month:     month,
+		Day:       fixed - m + 1,
+		LeapMonth: leapSui && chineseNoMajorSolarTerm(m) && !chinesePriorLeapMonth(m12, chineseNewMoonBefore(m)),
+		LeapYear:  chineseNewYearOnOrBefore(newYear+400)-newYear > 370,
+	}
+}
+
+// fixedFromChinese returns the fixed day number of a Chinese date whose
+// year is given as the Gregorian year it starts in.
+func fixedFromChinese(year, month, day int, leapMonth bool) int {
+	midYear := int(math.Floor(chineseEpoch + (float64(year+2637)-0.5)*meanTropicalYear))
+	newYear := chineseNewYearOnOrBefore(midYear)
+	p := chineseNewMoonOnOrAfter(newYear + (month-1)*29)
+	if d := chineseFromFixed(p); d.Month != month || d.LeapMonth != leapMonth {
+		p = chineseNewMoonOnOrAfter(p + 1)
+	}
+	return p + day - 1
+}
+
+func chineseMonthName(d CalendarDate) string {
+	if d.LeapMonth {
+		return "Leap " + chineseMonths[d.Month-1]
+	}
+	return chineseMonths[d.Month-1]
+}
+
+// chineseYearName names the year of the sexagenary cycle, e.g. "Yi-Si (Wood
+// Snake)" for the year starting in 2025.
+func chineseYearName(year int) string {
+	n := amod(year+2637, 60) - 1
+	return fmt.Sprintf("%s-%s (%s %s)", chineseStems[n%10], chineseBranches[n%12], chineseElements[n%10/2], chineseAnimals[n%12])
+}
+
+func formatChineseDate(d CalendarDate, month string) string {
+	return fmt.Sprintf("%s %d, %s year", month, d.Day, chineseYearName(d.Year))
+}
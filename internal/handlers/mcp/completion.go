@@ -0,0 +1,118 @@
+package mcp
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	mcp_go "github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxCompletionValues is the most values a completion may return.
+const maxCompletionValues = 100
+
+// CompleteResourceArgument suggests values for the variables of the
+// resource templates.
+func (s *Server) CompleteResourceArgument(ctx context.Context, _ string, argument mcp_go.CompleteArgument, _ mcp_go.CompleteContext) (*mcp_go.Completion, error) {
+	return s.completeArgument(argument)
+}
+
+// CompletePromptArgument suggests values for the arguments of the prompts.
+func (s *Server) CompletePromptArgument(ctx context.Context, _ string, argument mcp_go.CompleteArgument, _ mcp_go.CompleteContext) (*mcp_go.Completion, error) {
+	return s.completeArgument(argument)
+}
+
+// completeArgument suggests values for an argument by its name.  Prompts,
+// resource templates and tools share argument names where they share
+// meaning, so a name completes the same way wherever it appears.
+func (s *Server) completeArgument(argument mcp_go.CompleteArgument) (*mcp_go.Completion, error) {
+	switch argument.Name {
+	case "timezone", "timeZone", "firstTimeZone", "secondTimeZone", "fromTimeZone", "toTimeZone":
+		return completeTimeZone(argument.Value), nil
+	case "dayOfWeek":
+		return completeWeekday(argument.Value), nil
+	case "year":
+		return completeValues(s.yearSuggestions(), argument.Value), nil
+	case "month":
+		return completeMonth(argument.Value), nil
+	case "region", "holidayCalendar":
+		return completeValues(s.Holidays.IDs(), argument.Value), nil
+	case "name":
+		events, err := s.Events.List()
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(events))
+		for _, e := range events {
+			names = append(names, e.Name)
+		}
+		return completeValues(names, argument.Value), nil
+	}
+	return &mcp_go.Completion{Values: []string{}}, nil
+}
+
+// completeValues returns the values that start with prefix, ignoring case.
+func completeValues(values []string, prefix string) *mcp_go.Completion {
+	prefix = strings.ToLower(prefix)
+	var matches []string
+	for _, v := range values {
+		if strings.HasPrefix(strings.ToLower(v), prefix) {
+			matches = append(matches, v)
+		}
+	}
+	return newCompletion(matches)
+}
+
+// newCompletion returns the first maxCompletionValues of matches.
+func newCompletion(matches []string) *mcp_go.Completion {
+	completion := &mcp_go.Completion{Values: []string{}, Total: len(matches)}
+	if len(matches) > maxCompletionValues {
+		matches = matches[:maxCompletionValues]
+		completion.HasMore = true
+	}
+	completion.Values = append(completion.Values, matches...)
+	return completion
+}
+
+// completeTimeZone returns the time zones whose name, or whose last part
+// such as "New_York", starts with prefix, allowing for spaces, old names and
+// typing mistakes.
+func completeTimeZone(prefix string) *mcp_go.Completion {
+	return newCompletion(timeZoneIndex().Complete(prefix))
+}
+
+// completeWeekday returns the days of the week, from Monday, that start with
+// prefix.
+func completeWeekday(prefix string) *mcp_go.Completion {
+	values := make([]string, 0, 7)
+	for i := 1; i <= 7; i++ {
+		values = append(values, time.Weekday(i%7).String())
+	}
+	return completeValues(values, strings.TrimSpace(prefix))
+}
+
+// completeMonth suggests month numbers, or month names once a letter has
+// been typed.
+func completeMonth(prefix string) *mcp_go.Completion {
+	values := make([]string, 0, 12)
+	for m := time.January; m <= time.December; m++ {
+		if prefix != "" && (prefix[0] < '0' || prefix[0] > '9') {
+			values = append(values, m.String())
+		} else {
+			values = append(values, strconv.Itoa(int(m)))
+		}
+	}
+	return completeValues(values, prefix)
+}
+
+// yearSuggestions are the years around the current one, most relevant
+// first.
+func (s *Server) yearSuggestions() []string {
+	year := s.TimeManager.Now().Year()
+	values := []string{strconv.Itoa(year)}
+	for i := 1; i <= 5; i++ {
+		values = append(values, strconv.Itoa(year+i), strconv.Itoa(year-i))
+	}
+	return values
+}
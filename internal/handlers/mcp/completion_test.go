@@ -0,0 +1,39 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestCompleteArgument(t *testing.T) {
+	s := NewServer()
+	s.TimeManager = &mockTmanager{}
+
+	testCases := []struct {
+		argument string
+		value    string
+		want     string
+	}{
+		{argument: "timeZone", value: "europe/lond", want: "Europe/London"},
+		{argument: "firstTimeZone", value: "kolk", want: "Asia/Kolkata"},
+		{argument: "secondTimeZone", value: "new york", want: "America/New_York"},
+		{argument: "dayOfWeek", value: "t", want: "Tuesday, Thursday"},
+		{argument: "dayOfWeek", value: "SA", want: "Saturday"},
+		{argument: "dayOfWeek", value: "", want: "Monday, Tuesday, Wednesday, Thursday, Friday, Saturday, Sunday"},
+		{argument: "holidayCalendar", value: "g", want: "GR"},
+		{argument: "color", value: "r", want: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.argument+" "+tc.value, func(t *testing.T) {
+			got, err := s.completeArgument(mcp.CompleteArgument{Name: tc.argument, Value: tc.value})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Join(got.Values, ", ") != tc.want {
+				t.Errorf("completion = %v, want %s", got.Values, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,794 @@
+package mcp
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronDialect selects how the fields of a cron expression are read.
+type CronDialect string
+
+const (
+	// CronDialectAuto picks the dialect from the number of fields: five are
+	// standard, seven are Quartz, and six are Quartz when a day field is "?"
+	// and otherwise standard with a leading seconds field.
+	CronDialectAuto CronDialect = "auto"
+	// CronDialectStandard is the five-field Unix format: minute, hour, day of
+	// month, month and day of week, with Sunday as 0 or 7.
+	CronDialectStandard CronDialect = "standard"
+	// CronDialectSeconds adds a leading seconds field to the standard format.
+	CronDialectSeconds CronDialect = "seconds"
+	// CronDialectQuartz is the Quartz scheduler format: seconds, minute, hour,
+	// day of month, month, day of week with Sunday as 1, and an optional year.
+	CronDialectQuartz CronDialect = "quartz"
+)
+
+// cronMacros maps the "@" shorthands to standard expressions.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	cronMonthNames   = []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+	cronWeekdayNames = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+)
+
+// cronItemKind distinguishes the forms a comma-separated cron item takes.
+type cronItemKind int
+
+const (
+	cronValues cronItemKind = iota // *, a, a-b, */n, a/n or a-b/n
+	// Day-of-month forms.
+	cronLastDay        // L or L-n
+	cronNearestWeekday // nW
+	cronLastWeekday    // LW
+	// Day-of-week forms.
+	cronLastOfWeekday // dL, the last such weekday of the month
+	cronNthWeekday    // d#n, the nth such weekday of the month
+)
+
+// cronItem is one comma-separated part of a cron field.
+type cronItem struct {
+	kind cronItemKind
+	// start, end and step describe a cronValues item; any is set for "*".
+	start, end, step int
+	any              bool
+	// n is the offset of L-n, the day of nW and the ordinal of d#n.
+	n int
+}
+
+// cronField is a parsed cron field.
+type cronField struct {
+	items []cronItem
+	// min and max bound the field's values and set holds those matched by
+	// its cronValues items, indexed from min.
+	min, max int
+	set      []bool
+	// star records a field written as "*" or "?", or starting with "*".
+	star bool
+}
+
+func (f *cronField) matches(v int) bool {
+	return v >= f.min && v <= f.max && f.set[v-f.min]
+}
+
+// values returns the matched values in ascending order.
+func (f *cronField) values() []int {
+	var out []int
+	for i, ok := range f.set {
+		if ok {
+			out = append(out, f.min+i)
+		}
+	}
+	return out
+}
+
+// isAny reports whether the field matches every value.
+func (f *cronField) isAny() bool {
+	for _, item := range f.items {
+		if item.kind == cronValues && item.any && item.step == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// singles returns the field's values when it is made only of single values.
+func (f *cronField) singles() ([]int, bool) {
+	var out []int
+	for _, item := range f.items {
+		if item.kind != cronValues || item.any || item.start != item.end {
+			return nil, false
+		}
+		out = append(out, item.start)
+	}
+	sort.Ints(out)
+	return out, true
+}
+
+// CronSchedule is a parsed cron expression.
+type CronSchedule struct {
+	Expression string
+	Dialect    CronDialect
+
+	second, minute, hour, dayOfMonth, month, dayOfWeek cronField
+	// year is nil unless a Quartz year field was given.
+	year *cronField
+}
+
+// ParseCron parses a cron expression in the given dialect.  Month and
+// weekday names (JAN, MON) and the macros @yearly, @annually, @monthly,
+// @weekly, @daily, @midnight and @hourly are accepted in every dialect, as
+// are the Quartz day forms L, L-n, nW, LW, dL and d#n.
+func ParseCron(expression string, dialect CronDialect) (*CronSchedule, error) {
+	expr := strings.TrimSpace(expression)
+	if expr == "" {
+		return nil, NewInvalidCronExpressionError(expression, "expression is empty")
+	}
+	if dialect == "" {
+		dialect = CronDialectAuto
+	}
+	if strings.HasPrefix(expr, "@") {
+		macro, ok := cronMacros[strings.ToLower(expr)]
+		if !ok {
+			return nil, NewInvalidCronExpressionError(expression, "unknown macro "+expr)
+		}
+		expr, dialect = macro, CronDialectStandard
+	}
+
+	fields := strings.Fields(expr)
+	if dialect == CronDialectAuto {
+		switch len(fields) {
+		case 5:
+			dialect = CronDialectStandard
+		case 6:
+			dialect = CronDialectSeconds
+			if fields[3] == "?" || fields[5] == "?" {
+				dialect = CronDialectQuartz
+			}
+		case 7:
+			dialect = CronDialectQuartz
+		}
+	}
+	switch {
+	case dialect == CronDialectStandard && len(fields) == 5:
+		// Standard cron fires on the minute.
+		fields = append([]string{"0"}, fields...)
+	case dialect == CronDialectSeconds && len(fields) == 6:
+	case dialect == CronDialectQuartz && (len(fields) == 6 || len(fields) == 7):
+	case dialect == CronDialectStandard || dialect == CronDialectSeconds || dialect == CronDialectQuartz || dialect == CronDialectAuto:
+		return nil, NewInvalidCronExpressionError(expression, fmt.Sprintf("expected %s but got %d fields", cronFieldCounts(dialect), len(fields)))
+	default:
+		return nil, NewInvalidCronExpressionError(expression, fmt.Sprintf("unknown dialect %q", dialect))
+	}
+
+	c := &CronSchedule{Expression: expression, Dialect: dialect}
+	weekdayMin, weekdayMax := 0, 7
+	if dialect == CronDialectQuartz {
+		weekdayMin, weekdayMax = 1, 7
+	}
+	specs := []struct {
+		name     string
+		field    *cronField
+		min, max int
+		names    []string
+		nameBase int
+	}{
+		{"second", &c.second, 0, 59, nil, 0},
+		{"minute", &c.minute, 0, 59, nil, 0},
+		{"hour", &c.hour, 0, 23, nil, 0},
+		{"day of month", &c.dayOfMonth, 1, 31, nil, 0},
+		{"month", &c.month, 1, 12, cronMonthNames, 1},
+		{"day of week", &c.dayOfWeek, weekdayMin, weekdayMax, cronWeekdayNames, weekdayMin},
+	}
+	for i, spec := range specs {
+		parsed, err := parseCronField(fields[i], spec.min, spec.max, spec.names, spec.nameBase, i)
+		if err != nil {
+			return nil, NewInvalidCronExpressionError(expression, spec.name+" field: "+err.Error())
+		}
+		*spec.field = parsed
+	}
+	if len(fields) == 7 {
+		year, err := parseCronField(fields[6], 1970, 2099, nil, 0, 6)
+		if err != nil {
+			return nil, NewInvalidCronExpressionError(expression, "year field: "+err.Error())
+		}
+		c.year = &year
+	}
+	if dialect != CronDialectQuartz {
+		// Standard cron accepts 7 as well as 0 for Sunday.
+		c.dayOfWeek.set[0] = c.dayOfWeek.set[0] || c.dayOfWeek.set[7]
+		c.dayOfWeek.set[7] = false
+		for i, item := range c.dayOfWeek.items {
+			if item.kind != cronValues && item.start == 7 {
+				c.dayOfWeek.items[i].start = 0
+			}
+		}
+	}
+	return c, nil
+}
+
+func cronFieldCounts(dialect CronDialect) string {
+	switch dialect {
+	case CronDialectStandard:
+		return "5 fields"
+	case CronDialectSeconds:
+		return "6 fields"
+	case CronDialectQuartz:
+		return "6 or 7 fields"
+	default:
+		return "5, 6 or 7 fields"
+	}
+}
+
+// parseCronField parses one field.  index is the field's position counting
+// seconds as 0; it decides which special forms are allowed.
+func parseCronField(s string, min, max int, names []string, nameBase, index int) (cronField, error) {
+	f := cronField{min: min, max: max, set: make([]bool, max-min+1), star: strings.HasPrefix(s, "*") || s == "?"}
+	isDayOfMonth, isDayOfWeek := index == 3, index == 5
+
+	value := func(v string) (int, error) {
+		upper := strings.ToUpper(v)
+		for i, name := range names {
+			if upper == name {
+				return nameBase + i, nil
+			}
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a number", v)
+		}
+		if n < min || n > max {
+			return 0, fmt.Errorf("%d is out of range %d-%d", n, min, max)
+		}
+		return n, nil
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		upper := strings.ToUpper(part)
+		var item cronItem
+		var err error
+		switch {
+		case part == "":
+			return f, fmt.Errorf("empty list item in %q", s)
+		case part == "?" && (isDayOfMonth || isDayOfWeek):
+			item = cronItem{start: min, end: max, step: 1, any: true}
+		case isDayOfMonth && upper == "LW":
+			item = cronItem{kind: cronLastWeekday}
+		case isDayOfMonth && strings.HasPrefix(upper, "L"):
+			item = cronItem{kind: cronLastDay}
+			if rest := upper[1:]; rest != "" {
+				if !strings.HasPrefix(rest, "-") {
+					return f, fmt.Errorf("invalid item %q", part)
+				}
+				if item.n, err = strconv.Atoi(rest[1:]); err != nil || item.n < 1 || item.n > 30 {
+					return f, fmt.Errorf("invalid offset in %q", part)
+				}
+			}
+		case isDayOfMonth && strings.HasSuffix(upper, "W"):
+			item = cronItem{kind: cronNearestWeekday}
+			if item.n, err = value(part[:len(part)-1]); err != nil {
+				return f, err
+			}
+		case isDayOfWeek && upper == "L":
+			// A lone L is the last day of the week, Saturday.
+			item = cronItem{start: max - 1, end: max - 1, step: 1}
+			if min == 1 {
+				item.start, item.end = max, max
+			}
+		case isDayOfWeek && strings.HasSuffix(upper, "L"):
+			item = cronItem{kind: cronLastOfWeekday}
+			if item.start, err = value(part[:len(part)-1]); err != nil {
+				return f, err
+			}
+		case isDayOfWeek && strings.Contains(part, "#"):
+			day, nth, _ := strings.Cut(part, "#")
+			item = cronItem{kind: cronNthWeekday}
+			if item.start, err = value(day); err != nil {
+				return f, err
+			}
+			if item.n, err = strconv.Atoi(nth); err != nil || item.n < 1 || item.n > 5 {
+				return f, fmt.Errorf("invalid ordinal in %q; expected 1 to 5", part)
+			}
+		default:
+			if item, err = parseCronRange(part, min, max, value); err != nil {
+				return f, err
+			}
+		}
+		if item.kind == cronValues {
+			for v := item.start; v <= item.end; v += item.step {
+				f.set[v-min] = true
+			}
+		}
+		f.items = append(f.items, item)
+	}
+	return f, nil
+}
+
+// parseCronRange parses "*", "a", "a-b" with an optional "/step".
+func parseCronRange(part string, min, max int, value func(string) (int, error)) (cronItem, error) {
+	rng, stepText, hasStep := strings.Cut(part, "/")
+	item := cronItem{start: min, end: max, step: 1}
+	if hasStep {
+		step, err := strconv.Atoi(stepText)
+		if err != nil || step < 1 {
+			return item, fmt.Errorf("invalid step in %q", part)
+		}
+		item.step = step
+	}
+
+	var err error
+	switch {
+	case rng == "*":
+		item.any = true
+	case strings.Contains(rng, "-"):
+		from, to, _ := strings.Cut(rng, "-")
+		if item.start, err = value(from); err != nil {
+			return item, err
+		}
+		if item.end, err = value(to); err != nil {
+			return item, err
+		}
+		if item.end < item.start {
+			return item, fmt.Errorf("range %q runs backwards", rng)
+		}
+	default:
+		if item.start, err = value(rng); err != nil {
+			return item, err
+		}
+		if !hasStep {
+			// "a/n" runs from a to the maximum; a plain "a" is one value.
+			item.end = item.start
+		}
+	}
+	return item, nil
+}
+
+// dayMatches reports whether the schedule fires on the calendar date of d.
+// As in Vixie cron, when both day fields are restricted a day matching
+// either fires; when either is written with "*" both must match.
+func (c *CronSchedule) dayMatches(d time.Time) bool {
+	if c.year != nil && !c.year.matches(d.Year()) {
+		return false
+	}
+	if !c.month.matches(int(d.Month())) {
+		return false
+	}
+	dom, dow := c.dayOfMonthMatches(d), c.dayOfWeekMatches(d)
+	if c.dayOfMonth.star || c.dayOfWeek.star {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func (c *CronSchedule) dayOfMonthMatches(d time.Time) bool {
+	last := daysIn(d.Month(), d.Year())
+	for _, item := range c.dayOfMonth.items {
+		switch item.kind {
+		case cronValues:
+			if c.dayOfMonth.matches(d.Day()) {
+				return true
+			}
+		case cronLastDay:
+			if d.Day() == last-item.n {
+				return true
+			}
+		case cronLastWeekday:
+			if d.Day() == nearestWeekday(d.Year(), d.Month(), last) {
+				return true
+			}
+		case cronNearestWeekday:
+			if item.n <= last && d.Day() == nearestWeekday(d.Year(), d.Month(), item.n) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nearestWeekday returns the Monday to Friday day of the month closest to
+// day without leaving the month, as Quartz's W does.
+func nearestWeekday(year int, month time.Month, day int) int {
+	last := daysIn(month, year)
+	switch time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return 3
+		}
+		return day - 1
+	case time.Sunday:
+		if day == last {
+			return day - 2
+		}
+		return day + 1
+	}
+	return day
+}
+
+func (c *CronSchedule) dayOfWeekMatches(d time.Time) bool {
+	weekday := int(d.Weekday())
+	if c.Dialect == CronDialectQuartz {
+		weekday++
+	}
+	for _, item := range c.dayOfWeek.items {
+		switch item.kind {
+		case cronValues:
+			if c.dayOfWeek.matches(weekday) {
+				return true
+			}
+		case cronLastOfWeekday:
+			if weekday == item.start && d.Day()+7 > daysIn(d.Month(), d.Year()) {
+				return true
+			}
+		case cronNthWeekday:
+			if weekday == item.start && (d.Day()-1)/7+1 == item.n {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// maxCronDays bounds how far Next and Previous search for a matching day.
+const maxCronDays = 366 * 100
+
+// Next returns up to n fire times strictly after from, in loc.
+//
+// Fire times follow loc's wall clock across daylight saving transitions the
+// way Vixie cron does: a job whose hour field is "*" keeps firing in real
+// time, so it fires in both passes of a repeated hour and not at all in a
+// skipped one, while any other job fires once: on the first pass through a
+// repeated hour, or when the clocks go forward for a time that was skipped.
+func (c *CronSchedule) Next(from time.Time, n int, loc *time.Location) []time.Time {
+	var out []time.Time
+	local := from.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day()-1, 0, 0, 0, 0, time.UTC)
+	hours := c.hour.values()
+	for i := 0; i < maxCronDays && len(out) < n; i, day = i+1, day.AddDate(0, 0, 1) {
+		if !c.dayMatches(day) {
+			continue
+		}
+		for _, h := range hours {
+			for _, t := range c.hourRuns(day, h, loc) {
+				if t.After(from) && (len(out) == 0 || t.After(out[len(out)-1])) {
+					out = append(out, t)
+					if len(out) == n {
+						return out
+					}
+				}
+			}
+		}
+	}
+	return out
+}
+
+// Previous returns up to n fire times strictly before from, latest first.
+func (c *CronSchedule) Previous(from time.Time, n int, loc *time.Location) []time.Time {
+	var out []time.Time
+	local := from.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, time.UTC)
+	hours := c.hour.values()
+	for i := 0; i < maxCronDays && len(out) < n; i, day = i+1, day.AddDate(0, 0, -1) {
+		if !c.dayMatches(day) {
+			continue
+		}
+		for h := len(hours) - 1; h >= 0; h-- {
+			runs := c.hourRuns(day, hours[h], loc)
+			for j := len(runs) - 1; j >= 0; j-- {
+				t := runs[j]
+				if t.Before(from) && (len(out) == 0 || t.Before(out[len(out)-1])) {
+					out = append(out, t)
+					if len(out) == n {
+						return out
+					}
+				}
+			}
+		}
+	}
+	return out
+}
+
+// hourRuns returns the sorted fire times within hour on the calendar date
+// day, which must match.
+func (c *CronSchedule) hourRuns(day time.Time, hour int, loc *time.Location) []time.Time {
+	var out []time.Time
+	everyHour := c.hour.isAny()
+	for _, m := range c.minute.values() {
+		for _, s := range c.second.values() {
+			wc, err := resolveWallClock(day.Year(), day.Month(), day.Day(), hour, m, s, 0, loc, DSTPolicyEarlier)
+			if err != nil {
+				continue
+			}
+			switch wc.Kind {
+			case WallClockGap:
+				if !everyHour {
+					// Fire as the clocks go forward.
+					start, _ := wc.Later.ZoneBounds()
+					out = append(out, start)
+				}
+			case WallClockFold:
+				out = append(out, wc.Earlier)
+				if everyHour {
+					out = append(out, wc.Later)
+				}
+			default:
+				out = append(out, wc.Time)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+	return out
+}
+
+// Describe explains the schedule in English, e.g. "At 09:30 on Monday
+// through Friday".
+func (c *CronSchedule) Describe() string {
+	var parts []string
+	for _, p := range []string{c.describeTime(), c.describeDays(), c.describeMonths(), c.describeYears()} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	s := strings.Join(parts, " ")
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func (c *CronSchedule) describeTime() string {
+	seconds, secondsOK := c.second.singles()
+	minutes, minutesOK := c.minute.singles()
+	hours, hoursOK := c.hour.singles()
+	if secondsOK && minutesOK && hoursOK && len(seconds)*len(minutes)*len(hours) <= 6 {
+		var times []string
+		for _, h := range hours {
+			for _, m := range minutes {
+				for _, s := range seconds {
+					if containsNonZero(seconds) {
+						times = append(times, fmt.Sprintf("%02d:%02d:%02d", h, m, s))
+					} else {
+						times = append(times, fmt.Sprintf("%02d:%02d", h, m))
+					}
+				}
+			}
+		}
+		return "at " + joinEnglish(times)
+	}
+
+	var parts []string
+	onTheSecond := secondsOK && len(seconds) == 1 && seconds[0] == 0
+	if !onTheSecond {
+		parts = append(parts, describeCronUnits(&c.second, "second"))
+	}
+	onTheHour := onTheSecond && minutesOK && len(minutes) == 1 && minutes[0] == 0
+	// "Every 20 seconds" already implies every minute.
+	if !onTheHour && (onTheSecond || !c.minute.isAny()) {
+		parts = append(parts, describeCronUnits(&c.minute, "minute"))
+	}
+
+	var hourParts []string
+	if hoursOK {
+		names := make([]string, len(hours))
+		for i, h := range hours {
+			names[i] = strconv.Itoa(h)
+		}
+		hourParts = append(hourParts, "past hour"+pluralSuffix(len(hours))+" "+joinEnglish(names))
+	} else {
+		for _, item := range c.hour.items {
+			switch {
+			case item.any && item.step == 1:
+				if onTheHour {
+					hourParts = append(hourParts, "every hour")
+				}
+			case item.start == item.end:
+				hourParts = append(hourParts, fmt.Sprintf("past hour %d", item.start))
+			case item.step == 1:
+				prefix := ""
+				if onTheHour {
+					prefix = "every hour "
+				}
+				hourParts = append(hourParts, fmt.Sprintf("%sbetween %02d:00 and %02d:59", prefix, item.start, item.end))
+			case item.any:
+				hourParts = append(hourParts, fmt.Sprintf("every %d hours", item.step))
+			case item.end == 23:
+				hourParts = append(hourParts, fmt.Sprintf("every %d hours starting at %02d:00", item.step, item.start))
+			default:
+				hourParts = append(hourParts, fmt.Sprintf("every %d hours from %02d:00 through %02d:00", item.step, item.start, item.end))
+			}
+		}
+	}
+	if len(hourParts) > 0 {
+		parts = append(parts, strings.Join(hourParts, " and "))
+	}
+	return strings.Join(parts, " ")
+}
+
+// describeCronUnits describes a seconds or minutes field.
+func describeCronUnits(f *cronField, unit string) string {
+	if values, ok := f.singles(); ok {
+		names := make([]string, len(values))
+		for i, v := range values {
+			names[i] = strconv.Itoa(v)
+		}
+		return "at " + unit + pluralSuffix(len(values)) + " " + joinEnglish(names)
+	}
+	var parts []string
+	for _, item := range f.items {
+		switch {
+		case item.any && item.step == 1:
+			parts = append(parts, "every "+unit)
+		case item.start == item.end:
+			parts = append(parts, fmt.Sprintf("at %s %d", unit, item.start))
+		case item.step == 1:
+			parts = append(parts, fmt.Sprintf("every %s from %d through %d", unit, item.start, item.end))
+		case item.any:
+			parts = append(parts, fmt.Sprintf("every %d %ss", item.step, unit))
+		case item.end == f.max:
+			parts = append(parts, fmt.Sprintf("every %d %ss starting at %s %d", item.step, unit, unit, item.start))
+		default:
+			parts = append(parts, fmt.Sprintf("every %d %ss from %d through %d", item.step, unit, item.start, item.end))
+		}
+	}
+	return strings.Join(parts, " and ")
+}
+
+func (c *CronSchedule) describeDays() string {
+	domAny := c.dayOfMonth.isAny()
+	dowAny := c.dayOfWeek.isAny()
+	var dom, dow string
+	if !domAny {
+		dom = c.describeDaysOfMonth()
+	}
+	if !dowAny {
+		dow = c.describeDaysOfWeek()
+	}
+	switch {
+	case dom != "" && dow != "" && !c.dayOfMonth.star && !c.dayOfWeek.star:
+		return dom + " or " + dow
+	case dom != "" && dow != "":
+		return dom + " if it is also " + strings.TrimPrefix(dow, "on ")
+	case dom != "":
+		return dom
+	default:
+		return dow
+	}
+}
+
+func (c *CronSchedule) describeDaysOfMonth() string {
+	if values, ok := c.dayOfMonth.singles(); ok {
+		names := make([]string, len(values))
+		for i, v := range values {
+			names[i] = strconv.Itoa(v)
+		}
+		return "on day" + pluralSuffix(len(values)) + " " + joinEnglish(names) + " of the month"
+	}
+	var parts []string
+	for _, item := range c.dayOfMonth.items {
+		switch item.kind {
+		case cronLastDay:
+			if item.n == 0 {
+				parts = append(parts, "on the last day of the month")
+			} else {
+				parts = append(parts, fmt.Sprintf("%s before the last day of the month", plural(item.n, "day")))
+			}
+		case cronLastWeekday:
+			parts = append(parts, "on the last weekday of the month")
+		case cronNearestWeekday:
+			parts = append(parts, fmt.Sprintf("on the weekday nearest day %d of the month", item.n))
+		default:
+			switch {
+			case item.any && item.step == 1:
+				parts = append(parts, "every day")
+			case item.start == item.end:
+				parts = append(parts, fmt.Sprintf("on day %d of the month", item.start))
+			case item.step == 1:
+				parts = append(parts, fmt.Sprintf("on days %d through %d of the month", item.start, item.end))
+			case item.end == 31:
+				parts = append(parts, fmt.Sprintf("every %d days of the month starting on day %d", item.step, item.start))
+			default:
+				parts = append(parts, fmt.Sprintf("every %d days from day %d through %d of the month", item.step, item.start, item.end))
+			}
+		}
+	}
+	return joinEnglish(parts)
+}
+
+func (c *CronSchedule) describeDaysOfWeek() string {
+	weekdayName := func(v int) string {
+		if c.Dialect == CronDialectQuartz {
+			v--
+		}
+		return time.Weekday(v % 7).String()
+	}
+	var parts, names []string
+	for _, item := range c.dayOfWeek.items {
+		switch item.kind {
+		case cronLastOfWeekday:
+			parts = append(parts, "on the last "+weekdayName(item.start)+" of the month")
+		case cronNthWeekday:
+			parts = append(parts, "on the "+ordinalPhrase(item.n)+" "+weekdayName(item.start)+" of the month")
+		default:
+			if item.step == 1 && item.start != item.end && !item.any {
+				names = append(names, weekdayName(item.start)+" through "+weekdayName(item.end))
+				continue
+			}
+			for v := item.start; v <= item.end; v += item.step {
+				if c.Dialect != CronDialectQuartz && v == 7 && containsWeekdayName(names, "Sunday") {
+					continue
+				}
+				names = append(names, weekdayName(v))
+			}
+		}
+	}
+	if len(names) > 0 {
+		parts = append([]string{"on " + joinEnglish(names)}, parts...)
+	}
+	return joinEnglish(parts)
+}
+
+func containsWeekdayName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CronSchedule) describeMonths() string {
+	if c.month.isAny() {
+		return ""
+	}
+	var names []string
+	for _, item := range c.month.items {
+		if item.step == 1 && item.start != item.end && !item.any {
+			names = append(names, time.Month(item.start).String()+" through "+time.Month(item.end).String())
+			continue
+		}
+		for v := item.start; v <= item.end; v += item.step {
+			names = append(names, time.Month(v).String())
+		}
+	}
+	return "in " + joinEnglish(names)
+}
+
+func (c *CronSchedule) describeYears() string {
+	if c.year == nil || c.year.isAny() {
+		return ""
+	}
+	var parts []string
+	for _, item := range c.year.items {
+		switch {
+		case item.start == item.end:
+			parts = append(parts, strconv.Itoa(item.start))
+		case item.step == 1:
+			parts = append(parts, fmt.Sprintf("%d through %d", item.start, item.end))
+		default:
+			parts = append(parts, fmt.Sprintf("every %d years from %d through %d", item.step, item.start, item.end))
+		}
+	}
+	return "in " + joinEnglish(parts)
+}
+
+func containsNonZero(values []int) bool {
+	for _, v := range values {
+		if v != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func pluralSuffix(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
@@ -0,0 +1,238 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		expression string
+		timeZone   string
+		from       string
+		n          int
+		want       []string
+	}{
+		{
+			desc:       "Every 15 minutes during working hours",
+			expression: "*/15 9-17 * * MON-FRI",
+			timeZone:   "UTC",
+			from:       "2024-01-05T17:50:00",
+			n:          3,
+			want:       []string{"2024-01-08 09:00:00 +0000", "2024-01-08 09:15:00 +0000", "2024-01-08 09:30:00 +0000"},
+		},
+		{
+			desc:       "Quartz last Friday of the month",
+			expression: "0 0 12 ? * 6L",
+			timeZone:   "UTC",
+			from:       "2024-01-01T00:00:00",
+			n:          3,
+			want:       []string{"2024-01-26 12:00:00 +0000", "2024-02-23 12:00:00 +0000", "2024-03-29 12:00:00 +0000"},
+		},
+		{
+			desc:       "Macro",
+			expression: "@daily",
+			timeZone:   "UTC",
+			from:       "2024-01-01T10:00:00",
+			n:          2,
+			want:       []string{"2024-01-02 00:00:00 +0000", "2024-01-03 00:00:00 +0000"},
+		},
+		{
+			desc:       "Day of month or day of week",
+			expression: "0 9 1,15 * MON",
+			timeZone:   "UTC",
+			from:       "2024-01-01T00:00:00",
+			n:          4,
+			want:       []string{"2024-01-01 09:00:00 +0000", "2024-01-08 09:00:00 +0000", "2024-01-15 09:00:00 +0000", "2024-01-22 09:00:00 +0000"},
+		},
+		{
+			desc:       "Last day of the month",
+			expression: "0 0 0 L * ?",
+			timeZone:   "UTC",
+			from:       "2024-01-15T00:00:00",
+			n:          3,
+			want:       []string{"2024-01-31 00:00:00 +0000", "2024-02-29 00:00:00 +0000", "2024-03-31 00:00:00 +0000"},
+		},
+		{
+			desc:       "Nearest weekday",
+			expression: "0 0 0 15W * ?",
+			timeZone:   "UTC",
+			from:       "2024-06-01T00:00:00",
+			n:          4,
+			want:       []string{"2024-06-14 00:00:00 +0000", "2024-07-15 00:00:00 +0000", "2024-08-15 00:00:00 +0000", "2024-09-16 00:00:00 +0000"},
+		},
+		{
+			desc:       "Nearest weekday does not leave the month",
+			expression: "0 0 0 1W * ?",
+			timeZone:   "UTC",
+			from:       "2024-05-31T12:00:00",
+			n:          1,
+			want:       []string{"2024-06-03 00:00:00 +0000"},
+		},
+		{
+			desc:       "Second Tuesday",
+			expression: "0 10 * * 2#2",
+			timeZone:   "UTC",
+			from:       "2024-01-01T00:00:00",
+			n:          2,
+			want:       []string{"2024-01-09 10:00:00 +0000", "2024-02-13 10:00:00 +0000"},
+		},
+		{
+			desc:       "Fixed time skipped by daylight saving fires as the clocks go forward",
+			expression: "30 2 * * *",
+			timeZone:   "America/New_York",
+			from:       "2024-03-09T12:00:00",
+			n:          2,
+			want:       []string{"2024-03-10 03:00:00 -0400", "2024-03-11 02:30:00 -0400"},
+		},
+		{
+			desc:       "Fixed time repeated by daylight saving fires once",
+			expression: "30 1 * * *",
+			timeZone:   "America/New_York",
+			from:       "2024-11-02T12:00:00",
+			n:          2,
+			want:       []string{"2024-11-03 01:30:00 -0400", "2024-11-04 01:30:00 -0500"},
+		},
+		{
+			desc:       "Hourly job runs in both passes of a repeated hour",
+			expression: "*/30 * * * *",
+			timeZone:   "America/New_York",
+			from:       "2024-11-03T00:45:00",
+			n:          4,
+			want:       []string{"2024-11-03 01:00:00 -0400", "2024-11-03 01:30:00 -0400", "2024-11-03 01:00:00 -0500", "2024-11-03 01:30:00 -0500"},
+		},
+		{
+			desc:       "Hourly job skips a skipped hour",
+			expression: "*/30 * * * *",
+			timeZone:   "America/New_York",
+			from:       "2024-03-10T01:15:00",
+			n:          3,
+			want:       []string{"2024-03-10 01:30:00 -0500", "2024-03-10 03:00:00 -0400", "2024-03-10 03:30:00 -0400"},
+		},
+		{
+			desc:       "Seconds field",
+			expression: "*/20 * * * * *",
+			timeZone:   "UTC",
+			from:       "2024-01-01T00:00:00",
+			n:          3,
+			want:       []string{"2024-01-01 00:00:20 +0000", "2024-01-01 00:00:40 +0000", "2024-01-01 00:01:00 +0000"},
+		},
+		{
+			desc:       "Quartz year field",
+			expression: "0 0 0 1 1 ? 2030",
+			timeZone:   "UTC",
+			from:       "2024-01-01T00:00:00",
+			n:          3,
+			want:       []string{"2030-01-01 00:00:00 +0000"},
+		},
+		{
+			desc:       "Never fires",
+			expression: "0 0 30 2 *",
+			timeZone:   "UTC",
+			from:       "2024-01-01T00:00:00",
+			n:          1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			loc, err := time.LoadLocation(tc.timeZone)
+			if err != nil {
+				t.Fatal(err)
+			}
+			from, err := time.ParseInLocation("2006-01-02T15:04:05", tc.from, loc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c, err := ParseCron(tc.expression, CronDialectAuto)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) returned error: %v", tc.expression, err)
+			}
+			var got []string
+			for _, run := range c.Next(from, tc.n, loc) {
+				got = append(got, run.Format(dateTimeFormatTimeZone))
+			}
+			if strings.Join(got, ", ") != strings.Join(tc.want, ", ") {
+				t.Errorf("Next() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCronPrevious(t *testing.T) {
+	c, err := ParseCron("0 9 * * MON-FRI", CronDialectAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := time.Date(2024, time.January, 8, 8, 0, 0, 0, time.UTC)
+	var got []string
+	for _, run := range c.Previous(from, 2, time.UTC) {
+		got = append(got, run.Format(dateTimeFormat))
+	}
+	if want := "2024-01-05 09:00:00, 2024-01-04 09:00:00"; strings.Join(got, ", ") != want {
+		t.Errorf("Previous() = %v, want %s", got, want)
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		expression string
+		dialect    CronDialect
+		want       string
+	}{
+		{desc: "Empty", expression: " ", want: "expression is empty"},
+		{desc: "Too few fields", expression: "* * *", want: "expected 5, 6 or 7 fields but got 3"},
+		{desc: "Wrong count for dialect", expression: "0 * * * * *", dialect: CronDialectStandard, want: "expected 5 fields but got 6"},
+		{desc: "Out of range", expression: "60 * * * *", want: "minute field: 60 is out of range 0-59"},
+		{desc: "Backwards range", expression: "0 17-9 * * *", want: "range \"17-9\" runs backwards"},
+		{desc: "Bad step", expression: "*/0 * * * *", want: "invalid step"},
+		{desc: "Unknown name", expression: "0 0 * FOO *", want: "\"FOO\" is not a number"},
+		{desc: "Unknown macro", expression: "@reboot", want: "unknown macro @reboot"},
+		{desc: "Bad ordinal", expression: "0 0 * * MON#6", want: "invalid ordinal"},
+		{desc: "Unknown dialect", expression: "* * * * *", dialect: "systemd", want: "unknown dialect"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := ParseCron(tc.expression, tc.dialect)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("ParseCron(%q) error = %v, want it to contain %q", tc.expression, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestCronDescribe(t *testing.T) {
+	testCases := []struct {
+		expression string
+		want       string
+	}{
+		{"*/15 9-17 * * MON-FRI", "Every 15 minutes between 09:00 and 17:59 on Monday through Friday"},
+		{"30 9 * * 1-5", "At 09:30 on Monday through Friday"},
+		{"5 4 * * sun", "At 04:05 on Sunday"},
+		{"0 0 12 ? * 6L", "At 12:00 on the last Friday of the month"},
+		{"0 15 10 ? * 2#3", "At 10:15 on the 3rd Monday of the month"},
+		{"@hourly", "Every hour"},
+		{"0 */2 * * *", "Every 2 hours"},
+		{"0 9-17 * * *", "Every hour between 09:00 and 17:59"},
+		{"0,30 * * * *", "At minutes 0 and 30"},
+		{"15 9,17 * * *", "At 09:15 and 17:15"},
+		{"0 0 1,15 * *", "At 00:00 on days 1 and 15 of the month"},
+		{"0 9 1 * MON", "At 09:00 on day 1 of the month or on Monday"},
+		{"0 0 0 L * ?", "At 00:00 on the last day of the month"},
+		{"0 0 0 LW * ?", "At 00:00 on the last weekday of the month"},
+		{"0 0 1 */3 *", "At 00:00 on day 1 of the month in January, April, July and October"},
+		{"*/20 * * * * *", "Every 20 seconds"},
+		{"0 0 0 1 1 ? 2030", "At 00:00 on day 1 of the month in January in 2030"},
+	}
+	for _, tc := range testCases {
+		c, err := ParseCron(tc.expression, CronDialectAuto)
+		if err != nil {
+			t.Fatalf("ParseCron(%q) returned error: %v", tc.expression, err)
+		}
+		if got := c.Describe(); got != tc.want {
+			t.Errorf("Describe(%q) = %q, want %q", tc.expression, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// datePeriod is a run of whole days, such as the week or quarter that
+// contains a date.
+type datePeriod struct {
+	first, last time.Time
+}
+
+// weekOf returns the week containing d that starts on weekStart.
+func weekOf(d time.Time, weekStart time.Weekday) datePeriod {
+	first := d.AddDate(0, 0, -((int(d.Weekday()) - int(weekStart) + 7) % 7))
+	return datePeriod{first, first.AddDate(0, 0, 6)}
+}
+
+// monthOf returns the month containing d.
+func monthOf(d time.Time) datePeriod {
+	first := time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, d.Location())
+	return datePeriod{first, first.AddDate(0, 1, -1)}
+}
+
+// quarterOf returns the calendar quarter containing d.
+func quarterOf(d time.Time) datePeriod {
+	first := time.Date(d.Year(), time.Month(3*(quarter(d)-1)+1), 1, 0, 0, 0, 0, d.Location())
+	return datePeriod{first, first.AddDate(0, 3, -1)}
+}
+
+// yearOf returns the year containing d.
+func yearOf(d time.Time) datePeriod {
+	first := time.Date(d.Year(), time.January, 1, 0, 0, 0, 0, d.Location())
+	return datePeriod{first, first.AddDate(1, 0, -1)}
+}
+
+// quarter returns the calendar quarter of d, from 1 to 4.
+func quarter(d time.Time) int {
+	return (int(d.Month())-1)/3 + 1
+}
+
+// days counts the days of p.
+func (p datePeriod) days() int {
+	return daysFrom(p.first, p.last) + 1
+}
+
+// newPeriodOutput describes p and the position of d in it.
+func newPeriodOutput(p datePeriod, d time.Time) PeriodOutput {
+	return PeriodOutput{
+		First:         p.first.Format(dateFormat),
+		Last:          p.last.Format(dateFormat),
+		Days:          p.days(),
+		DayOfPeriod:   daysFrom(p.first, d) + 1,
+		DaysRemaining: daysFrom(d, p.last),
+	}
+}
+
+// newDateInfoOutput describes the calendar date of d, with weeks starting
+// on weekStart.
+func newDateInfoOutput(d time.Time, weekStart time.Weekday) DateInfoOutput {
+	d = time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+	isoYear, isoWeek := d.ISOWeek()
+	isoWeekday := (int(d.Weekday())+6)%7 + 1
+	year := yearOf(d)
+	return DateInfoOutput{
+		Date:        d.Format(dateFormat),
+		DayOfWeek:   d.Weekday().String(),
+		ISOWeekday:  isoWeekday,
+		ISOWeekYear: isoYear,
+		ISOWeek:     isoWeek,
+		ISOWeekDate: fmt.Sprintf("%04d-W%02d-%d", isoYear, isoWeek, isoWeekday),
+		DayOfYear:   d.YearDay(),
+		OrdinalDate: fmt.Sprintf("%04d-%03d", d.Year(), d.YearDay()),
+		Quarter:     quarter(d),
+		IsLeapYear:  year.days() == 366,
+		WeekStart:   weekStart.String(),
+		Periods: DatePeriodsOutput{
+			Week:    newPeriodOutput(weekOf(d, weekStart), d),
+			Month:   newPeriodOutput(monthOf(d), d),
+			Quarter: newPeriodOutput(quarterOf(d), d),
+			Year:    newPeriodOutput(year, d),
+		},
+	}
+}
+
+// describePeriod summarizes the position of a date in a period, e.g.
+// "2025-01-27 to 2025-02-02, day 6 of 7, 1 day left".
+func describePeriod(p PeriodOutput) string {
+	return fmt.Sprintf("%s to %s, day %d of %d, %s left", p.First, p.Last, p.DayOfPeriod, p.Days, plural(p.DaysRemaining, "day"))
+}
+
+// weekdayColumnHeadings head the columns of a month grid.
+var weekdayColumnHeadings = [7]string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"}
+
+// monthWeeks lays a month out in weeks starting on weekStart, with the day
+// of the month in each column or 0 for days outside it.
+func monthWeeks(year int, month time.Month, weekStart time.Weekday) [][]int {
+	column := (int(time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Weekday()) - int(weekStart) + 7) % 7
+	weeks := [][]int{make([]int, 7)}
+	for d := 1; d <= daysIn(month, year); d++ {
+		if column == 7 {
+			weeks = append(weeks, make([]int, 7))
+			column = 0
+		}
+		weeks[len(weeks)-1][column] = d
+		column++
+	}
+	return weeks
+}
+
+// monthGrid lays a month out like cal(1), with weeks starting on weekStart.
+func monthGrid(year int, month time.Month, weekStart time.Weekday) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %d\n", month, year)
+	for i := range 7 {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString(weekdayColumnHeadings[(int(weekStart)+i)%7])
+	}
+	for _, week := range monthWeeks(year, month, weekStart) {
+		b.WriteString("\n")
+		line := ""
+		for i, d := range week {
+			if i > 0 {
+				line += " "
+			}
+			if d == 0 {
+				line += "  "
+			} else {
+				line += fmt.Sprintf("%2d", d)
+			}
+		}
+		b.WriteString(strings.TrimRight(line, " "))
+	}
+	return b.String()
+}
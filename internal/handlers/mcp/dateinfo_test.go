@@ -0,0 +1,28 @@
+package mcp
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMonthWeeks(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		year      int
+		month     time.Month
+		weekStart time.Weekday
+		want      string
+	}{
+		{desc: "Month starting on the week start", year: 2021, month: time.February, weekStart: time.Monday, want: "[[1 2 3 4 5 6 7] [8 9 10 11 12 13 14] [15 16 17 18 19 20 21] [22 23 24 25 26 27 28]]"},
+		{desc: "Month ending on the week start", year: 2025, month: time.March, weekStart: time.Monday, want: "[[0 0 0 0 0 1 2] [3 4 5 6 7 8 9] [10 11 12 13 14 15 16] [17 18 19 20 21 22 23] [24 25 26 27 28 29 30] [31 0 0 0 0 0 0]]"},
+		{desc: "Weeks starting on Sunday", year: 2025, month: time.March, weekStart: time.Sunday, want: "[[0 0 0 0 0 0 1] [2 3 4 5 6 7 8] [9 10 11 12 13 14 15] [16 17 18 19 20 21 22] [23 24 25 26 27 28 29] [30 31 0 0 0 0 0]]"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := fmt.Sprint(monthWeeks(tc.year, tc.month, tc.weekStart)); got != tc.want {
+				t.Errorf("monthWeeks() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package mcp
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MonthEndPolicy selects what happens when adding months or years lands on a
+// day that does not exist in the target month, e.g. January 31st + 1 month.
+type MonthEndPolicy string
+
+const (
+	// MonthEndClamp moves the result back to the last day of the month
+	// (January 31st + 1 month = February 28th/29th).
+	MonthEndClamp MonthEndPolicy = "clamp"
+	// MonthEndOverflow carries the excess days into the following month
+	// (January 31st + 1 month = March 2nd/3rd).
+	MonthEndOverflow MonthEndPolicy = "overflow"
+)
+
+// DefaultMonthEndPolicy is used when no policy is requested.
+const DefaultMonthEndPolicy = MonthEndClamp
+
+// ParseMonthEndPolicy maps a string to a MonthEndPolicy.  An empty string
+// yields the default policy.
+func ParseMonthEndPolicy(s string) (MonthEndPolicy, error) {
+	switch p := MonthEndPolicy(strings.ToLower(strings.TrimSpace(s))); p {
+	case "":
+		return DefaultMonthEndPolicy, nil
+	case MonthEndClamp, MonthEndOverflow:
+		return p, nil
+	default:
+		return "", NewInvalidMonthEndPolicyError(s)
+	}
+}
+
+// CalendarDuration is an amount of time made of calendar components, which
+// are applied to the wall-clock date, and an exact clock component.
+type CalendarDuration struct {
+	Years  int
+	Months int
+	Days   int
+	Clock  time.Duration
+}
+
+// IsZero reports whether d has no components.
+func (d CalendarDuration) IsZero() bool {
+	return d == CalendarDuration{}
+}
+
+// Negate returns d with every component negated.
+func (d CalendarDuration) Negate() CalendarDuration {
+	return CalendarDuration{Years: -d.Years, Months: -d.Months, Days: -d.Days, Clock: -d.Clock}
+}
+
+// AddTo adds d to t.  Years, months and days move the wall-clock date in t's
+// location, keeping the time of day, and months that run past the end of the
+// target month are handled by monthEnd.  The clock component is then added
+// as an exact duration.
+func (d CalendarDuration) AddTo(t time.Time, monthEnd MonthEndPolicy, dst DSTPolicy) (time.Time, error) {
+	if d.Years != 0 || d.Months != 0 || d.Days != 0 {
+		year, month, day := t.Date()
+		hour, min, sec := t.Clock()
+
+		if d.Years != 0 || d.Months != 0 {
+			total := year*12 + int(month) - 1 + d.Years*12 + d.Months
+			year, month = floorDiv(total, 12), time.Month(floorMod(total, 12)+1)
+			if monthEnd != MonthEndOverflow && day > daysIn(month, year) {
+				day = daysIn(month, year)
+			}
+		}
+
+		wc, err := resolveWallClock(year, month, day+d.Days, hour, min, sec, t.Nanosecond(), t.Location(), dst)
+		if err != nil {
+			return time.Time{}, err
+		}
+		t = wc.Time
+	}
+	return t.Add(d.Clock), nil
+}
+
+// String formats d as an ISO 8601 duration such as "P1Y2M10DT2H30M".
+func (d CalendarDuration) String() string {
+	if d.IsZero() {
+		return "PT0S"
+	}
+	var b strings.Builder
+	// ISO 8601 has no per-component sign, so a wholly negative duration is
+	// written with a leading minus.
+	if d.Years <= 0 && d.Months <= 0 && d.Days <= 0 && d.Clock <= 0 {
+		b.WriteString("-")
+		d = d.Negate()
+	}
+	b.WriteString("P")
+	for _, c := range []struct {
+		v    int
+		unit string
+	}{{d.Years, "Y"}, {d.Months, "M"}, {d.Days, "D"}} {
+		if c.v != 0 {
+			fmt.Fprintf(&b, "%d%s", c.v, c.unit)
+		}
+	}
+	if d.Clock != 0 {
+		b.WriteString("T")
+		clock := d.Clock
+		if h := clock / time.Hour; h != 0 {
+			fmt.Fprintf(&b, "%dH", h)
+			clock -= h * time.Hour
+		}
+		if m := clock / time.Minute; m != 0 {
+			fmt.Fprintf(&b, "%dM", m)
+			clock -= m * time.Minute
+		}
+		if clock != 0 {
+			b.WriteString(strconv.FormatFloat(clock.Seconds(), 'f', -1, 64) + "S")
+		}
+	}
+	return b.String()
+}
+
+// ParseCalendarDuration parses an ISO 8601 duration ("P1Y2M10DT2H30M",
+// "P3W", "-PT90M") or a list of human units ("3 weeks 2 days",
+// "2y3mo", "1h30m").  A leading sign applies to the whole duration.
+func ParseCalendarDuration(input string) (CalendarDuration, error) {
+	s := strings.ToLower(strings.TrimSpace(input))
+	if s == "" {
+		return CalendarDuration{}, NewInvalidDurationError(input, "duration is empty")
+	}
+
+	sign := 1
+	switch s[0] {
+	case '-':
+		sign = -1
+		s = strings.TrimSpace(s[1:])
+	case '+':
+		s = strings.TrimSpace(s[1:])
+	}
+
+	var d CalendarDuration
+	var err error
+	if strings.HasPrefix(s, "p") {
+		d, err = parseISODuration(s)
+	} else {
+		d, err = parseHumanDuration(s)
+	}
+	if err == nil {
+		err = checkDurationRange(d)
+	}
+	if err != nil {
+		return CalendarDuration{}, NewInvalidDurationError(input, err.Error())
+	}
+	if sign < 0 {
+		d = d.Negate()
+	}
+	return d, nil
+}
+
+// The largest calendar components a duration may have, so that adding one
+// to a time cannot overflow: ten thousand years, however they are written.
+const (
+	maxDurationYears  = 10000
+	maxDurationMonths = 12 * maxDurationYears
+	maxDurationDays   = 146097 * maxDurationYears / 400
+)
+
+// checkDurationRange rejects a parsed duration whose components are too
+// large to add to a time.
+func checkDurationRange(d CalendarDuration) error {
+	switch {
+	case d.Years > maxDurationYears:
+		return fmt.Errorf("years must be at most %d", maxDurationYears)
+	case d.Months > maxDurationMonths:
+		return fmt.Errorf("months must be at most %d", maxDurationMonths)
+	case d.Days > maxDurationDays:
+		return fmt.Errorf("days must be at most %d", maxDurationDays)
+	}
+	return nil
+}
+
+var isoDurationPattern = regexp.MustCompile(`^p(?:(\d+)y)?(?:(\d+)m)?(?:(\d+)w)?(?:(\d+)d)?(?:t(?:(\d+(?:[.,]\d+)?)h)?(?:(\d+(?:[.,]\d+)?)m)?(?:(\d+(?:[.,]\d+)?)s)?)?$`)
+
+func parseISODuration(s string) (CalendarDuration, error) {
+	m := isoDurationPattern.FindStringSubmatch(s)
+	if m == nil || s == "p" || strings.HasSuffix(s, "t") {
+		return CalendarDuration{}, fmt.Errorf("expected an ISO 8601 duration such as P1Y2M10DT2H30M")
+	}
+	var calendar [4]int
+	for i, v := range m[1:5] {
+		n, err := parseDurationComponent(v)
+		if err != nil {
+			return CalendarDuration{}, err
+		}
+		calendar[i] = n
+	}
+	d := CalendarDuration{Years: calendar[0], Months: calendar[1], Days: 7*calendar[2] + calendar[3]}
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		v := m[5+i]
+		if v == "" {
+			continue
+		}
+		f, err := strconv.ParseFloat(strings.Replace(v, ",", ".", 1), 64)
+		if err != nil {
+			return CalendarDuration{}, err
+		}
+		if err := d.addClock(f, unit); err != nil {
+			return CalendarDuration{}, err
+		}
+	}
+	return d, nil
+}
+
+var humanDurationPattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([a-zµ]+)`)
+
+// applyHumanUnit adds v of the named unit to d.  Single letters follow
+// time.ParseDuration, so "m" is minutes and months are written "mo".
+func applyHumanUnit(d *CalendarDuration, unit string, v float64) error {
+	var clock time.Duration
+	switch unit {
+	case "h", "hr", "hrs", "hour", "hours":
+		clock = time.Hour
+	case "m", "min", "mins", "minute", "minutes":
+		clock = time.Minute
+	case "s", "sec", "secs", "second", "seconds":
+		clock = time.Second
+	case "ms", "millisecond", "milliseconds":
+		clock = time.Millisecond
+	case "us", "µs", "microsecond", "microseconds":
+		clock = time.Microsecond
+	case "ns", "nanosecond", "nanoseconds":
+		clock = time.Nanosecond
+	}
+	if clock != 0 {
+		return d.addClock(v, clock)
+	}
+
+	if v != math.Trunc(v) {
+		return fmt.Errorf("years, months, weeks and days must be whole numbers")
+	}
+	if v > maxDurationDays {
+		return fmt.Errorf("%s %s is too long", strconv.FormatFloat(v, 'f', -1, 64), unit)
+	}
+	n := int(v)
+	switch unit {
+	case "y", "yr", "yrs", "year", "years":
+		d.Years += n
+	case "mo", "mos", "month", "months":
+		d.Months += n
+	case "w", "wk", "wks", "week", "weeks":
+		d.Days += 7 * n
+	case "d", "day", "days":
+		d.Days += n
+	default:
+		return fmt.Errorf("unknown unit %q", unit)
+	}
+	return nil
+}
+
+func parseHumanDuration(s string) (CalendarDuration, error) {
+	var d CalendarDuration
+	for rest := s; rest != ""; {
+		rest = strings.TrimLeft(rest, " ,")
+		rest = strings.TrimPrefix(rest, "and ")
+		if rest == "" {
+			break
+		}
+		m := humanDurationPattern.FindStringSubmatch(rest)
+		if m == nil {
+			return CalendarDuration{}, fmt.Errorf("expected a number followed by a unit at %q", rest)
+		}
+		v, err := strconv.ParseFloat(m[1], 64)
+		if err != nil {
+			return CalendarDuration{}, err
+		}
+		if err := applyHumanUnit(&d, m[2], v); err != nil {
+			return CalendarDuration{}, err
+		}
+		rest = rest[len(m[0]):]
+	}
+	return d, nil
+}
+
+// parseDurationComponent parses the digits of one calendar component of an
+// ISO 8601 duration, which may be absent.
+func parseDurationComponent(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n > maxDurationDays {
+		return 0, fmt.Errorf("%s is too large", s)
+	}
+	return n, nil
+}
+
+// addClock adds v of unit to the clock component of d, which parsing keeps
+// positive, failing if the sum does not fit in a time.Duration.
+func (d *CalendarDuration) addClock(v float64, unit time.Duration) error {
+	ns := math.Round(v * float64(unit))
+	if ns >= float64(math.MaxInt64-d.Clock) {
+		return fmt.Errorf("hours, minutes and seconds must add up to less than %d hours", math.MaxInt64/time.Hour)
+	}
+	d.Clock += time.Duration(ns)
+	return nil
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+func floorMod(a, b int) int {
+	return a - floorDiv(a, b)*b
+}
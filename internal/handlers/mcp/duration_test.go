@@ -0,0 +1,142 @@
+package mcp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCalendarDuration(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		input   string
+		want    CalendarDuration
+		wantErr bool
+	}{
+		{desc: "ISO 8601 full", input: "P1Y2M10DT2H30M", want: CalendarDuration{Years: 1, Months: 2, Days: 10, Clock: 2*time.Hour + 30*time.Minute}},
+		{desc: "ISO 8601 weeks", input: "P3W", want: CalendarDuration{Days: 21}},
+		{desc: "ISO 8601 fractional hours", input: "PT1.5H", want: CalendarDuration{Clock: 90 * time.Minute}},
+		{desc: "ISO 8601 negative", input: "-P1D", want: CalendarDuration{Days: -1}},
+		{desc: "Human units", input: "3 weeks 2 days", want: CalendarDuration{Days: 23}},
+		{desc: "Compact units", input: "2y3mo", want: CalendarDuration{Years: 2, Months: 3}},
+		{desc: "Go duration", input: "1h30m", want: CalendarDuration{Clock: 90 * time.Minute}},
+		{desc: "Negative Go duration", input: "-1h", want: CalendarDuration{Clock: -time.Hour}},
+		{desc: "Conjunctions", input: "1 year, 2 months and 3 days", want: CalendarDuration{Years: 1, Months: 2, Days: 3}},
+		{desc: "Empty", input: "", wantErr: true},
+		{desc: "Unknown unit", input: "3 fortnights", wantErr: true},
+		{desc: "Fractional months", input: "1.5 months", wantErr: true},
+		{desc: "Bare P", input: "P", wantErr: true},
+		{desc: "Dangling T", input: "P1DT", wantErr: true},
+		{desc: "Gibberish", input: "abc", wantErr: true},
+		{desc: "Ten thousand years", input: "P10000Y", want: CalendarDuration{Years: 10000}},
+		{desc: "Years past the int range", input: "P99999999999999999999Y", wantErr: true},
+		{desc: "Months that would overflow", input: "P9223372036854775807M", wantErr: true},
+		{desc: "Too many years", input: "P10001Y", wantErr: true},
+		{desc: "Too many months", input: "120001 months", wantErr: true},
+		{desc: "Weeks adding up to too many days", input: "P1000000W", wantErr: true},
+		{desc: "Days past the int range", input: "99999999999999999999 days", wantErr: true},
+		{desc: "Hours past the time.Duration range", input: "PT9999999999H", wantErr: true},
+		{desc: "Clock parts adding up past the time.Duration range", input: "2000000h 2000000h", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := ParseCalendarDuration(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseCalendarDuration() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ParseCalendarDuration() got = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCalendarDurationAddTo(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		desc     string
+		start    time.Time
+		duration CalendarDuration
+		monthEnd MonthEndPolicy
+		want     time.Time
+	}{
+		{
+			desc:     "Clamp to end of February in a leap year",
+			start:    time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			duration: CalendarDuration{Months: 1},
+			monthEnd: MonthEndClamp,
+			want:     time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:     "Overflow into March",
+			start:    time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC),
+			duration: CalendarDuration{Months: 1},
+			monthEnd: MonthEndOverflow,
+			want:     time.Date(2023, 3, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:     "Leap day plus one year",
+			start:    time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			duration: CalendarDuration{Years: 1},
+			monthEnd: MonthEndClamp,
+			want:     time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:     "Months across a year boundary backwards",
+			start:    time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+			duration: CalendarDuration{Months: -3},
+			monthEnd: MonthEndClamp,
+			want:     time.Date(2023, 10, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:     "Days keep local time across DST",
+			start:    time.Date(2024, 3, 9, 9, 0, 0, 0, newYork),
+			duration: CalendarDuration{Days: 30},
+			monthEnd: MonthEndClamp,
+			want:     time.Date(2024, 4, 8, 9, 0, 0, 0, newYork),
+		},
+		{
+			desc:     "Hours are exact across DST",
+			start:    time.Date(2024, 3, 9, 9, 0, 0, 0, newYork),
+			duration: CalendarDuration{Clock: 24 * time.Hour},
+			monthEnd: MonthEndClamp,
+			want:     time.Date(2024, 3, 10, 10, 0, 0, 0, newYork),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := tc.duration.AddTo(tc.start, tc.monthEnd, DefaultDSTPolicy)
+			if err != nil {
+				t.Fatalf("AddTo() error = %v", err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("AddTo() got = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCalendarDurationString(t *testing.T) {
+	testCases := []struct {
+		duration CalendarDuration
+		want     string
+	}{
+		{CalendarDuration{}, "PT0S"},
+		{CalendarDuration{Years: 1, Months: 2, Days: 10, Clock: 2*time.Hour + 30*time.Minute}, "P1Y2M10DT2H30M"},
+		{CalendarDuration{Clock: 1500 * time.Millisecond}, "PT1.5S"},
+		{CalendarDuration{Days: -3}, "-P3D"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.want, func(t *testing.T) {
+			if got := tc.duration.String(); got != tc.want {
+				t.Errorf("String() got = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -1,5 +1,12 @@
 package mcp
 
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
 type NilTimeOptsError struct{}
 
 func (e *NilTimeOptsError) Error() string {
@@ -22,10 +29,22 @@ func NewNilInputTime() *NilInputTime {
 
 type InvalidTimeFormatError struct {
 	Input string
+	// Closest names the ISO 8601 grammar alternative that matched the most
+	// input, Position is where it stopped and Reason why.
+	Closest  string
+	Position int
+	Reason   string
 }
 
 func (e *InvalidTimeFormatError) Error() string {
-	return "failed to parse time: \"" + e.Input + "\". Format must be YYYY-MM-DD HH:MM:SS for date/time or YYYY-MM-DD for date only"
+	if e.Closest == "" {
+		return "failed to parse time: \"" + e.Input + "\". Format must be YYYY-MM-DD HH:MM:SS for date/time or YYYY-MM-DD for date only"
+	}
+	msg := "failed to parse time: \"" + e.Input + "\". Expected an ISO 8601 date or date/time such as YYYY-MM-DD, YYYY-MM-DDTHH:MM:SS[.fff][Z|+HH:MM], YYYY-Www-D or YYYY-DDD; closest match was " + e.Closest + ", which stopped at position " + strconv.Itoa(e.Position)
+	if e.Reason != "" {
+		msg += " (" + e.Reason + ")"
+	}
+	return msg
 }
 
 func NewInvalidTimeFormatError(input string) *InvalidTimeFormatError {
@@ -34,18 +53,533 @@ func NewInvalidTimeFormatError(input string) *InvalidTimeFormatError {
 	}
 }
 
+func NewISO8601FormatError(input, closest string, position int, reason string) *InvalidTimeFormatError {
+	return &InvalidTimeFormatError{
+		Input:    input,
+		Closest:  closest,
+		Position: position,
+		Reason:   reason,
+	}
+}
+
 type TimeZoneLoadError struct {
 	TimeZone string
 	Err      error
+	// Suggestions lists known zones the name may have been meant as.
+	Suggestions []string
 }
 
 func (e *TimeZoneLoadError) Error() string {
-	return "failed to load time zone \"" + e.TimeZone + "\": " + e.Err.Error()
+	msg := "failed to load time zone \"" + e.TimeZone + "\": " + e.Err.Error()
+	if len(e.Suggestions) > 0 {
+		msg += "; did you mean " + strings.Join(e.Suggestions, ", ") + "?"
+	}
+	return msg
 }
 
+func (e *TimeZoneLoadError) Unwrap() error {
+	return e.Err
+}
+
+// maxTimeZoneSuggestions bounds the suggestions in a TimeZoneLoadError.
+const maxTimeZoneSuggestions = 3
+
+// NewTimeZoneLoadError returns an error for a time zone that failed to load,
+// suggesting known zones with a similar name.  An error that is already a
+// TimeZoneLoadError for the same zone is returned as it is.
 func NewTimeZoneLoadError(timeZone string, err error) *TimeZoneLoadError {
+	var loadErr *TimeZoneLoadError
+	if errors.As(err, &loadErr) && loadErr.TimeZone == timeZone {
+		return loadErr
+	}
 	return &TimeZoneLoadError{
+		TimeZone:    timeZone,
+		Err:         err,
+		Suggestions: timeZoneIndex().Suggest(timeZone, maxTimeZoneSuggestions),
+	}
+}
+
+type AmbiguousTimeZoneError struct {
+	TimeZone string
+	// Meanings describes the zones the name may refer to.
+	Meanings []string
+}
+
+func (e *AmbiguousTimeZoneError) Error() string {
+	return "time zone \"" + e.TimeZone + "\" is ambiguous; it may mean " + strings.Join(e.Meanings, ", ") + ".  Use an IANA time zone name instead"
+}
+
+func NewAmbiguousTimeZoneError(timeZone string, meanings []string) *AmbiguousTimeZoneError {
+	return &AmbiguousTimeZoneError{
+		TimeZone: timeZone,
+		Meanings: meanings,
+	}
+}
+
+type TimeZoneDatabaseError struct {
+	Path string
+	Err  error
+}
+
+func (e *TimeZoneDatabaseError) Error() string {
+	return "failed to open time zone database " + e.Path + ": " + e.Err.Error()
+}
+
+func (e *TimeZoneDatabaseError) Unwrap() error {
+	return e.Err
+}
+
+func NewTimeZoneDatabaseError(path string, err error) *TimeZoneDatabaseError {
+	return &TimeZoneDatabaseError{
+		Path: path,
+		Err:  err,
+	}
+}
+
+type InvalidDSTPolicyError struct {
+	Policy string
+}
+
+func (e *InvalidDSTPolicyError) Error() string {
+	return "invalid DST policy \"" + e.Policy + "\". Policy must be one of earlier, later or error"
+}
+
+func NewInvalidDSTPolicyError(policy string) *InvalidDSTPolicyError {
+	return &InvalidDSTPolicyError{
+		Policy: policy,
+	}
+}
+
+type NonexistentLocalTimeError struct {
+	Input    string
+	TimeZone string
+}
+
+func (e *NonexistentLocalTimeError) Error() string {
+	return "local time \"" + e.Input + "\" does not exist in time zone \"" + e.TimeZone + "\" because it falls in a daylight saving gap"
+}
+
+func NewNonexistentLocalTimeError(input, timeZone string) *NonexistentLocalTimeError {
+	return &NonexistentLocalTimeError{
+		Input:    input,
+		TimeZone: timeZone,
+	}
+}
+
+type AmbiguousLocalTimeError struct {
+	Input    string
+	TimeZone string
+	Earlier  time.Time
+	Later    time.Time
+}
+
+func (e *AmbiguousLocalTimeError) Error() string {
+	return "local time \"" + e.Input + "\" is ambiguous in time zone \"" + e.TimeZone + "\": it occurs at both " + e.Earlier.Format(dateTimeFormatTimeZone) + " and " + e.Later.Format(dateTimeFormatTimeZone)
+}
+
+func NewAmbiguousLocalTimeError(input, timeZone string, earlier, later time.Time) *AmbiguousLocalTimeError {
+	return &AmbiguousLocalTimeError{
+		Input:    input,
 		TimeZone: timeZone,
-		Err:      err,
+		Earlier:  earlier,
+		Later:    later,
+	}
+}
+
+type InvalidDateExpressionError struct {
+	Input  string
+	Reason string
+}
+
+func (e *InvalidDateExpressionError) Error() string {
+	return "failed to interpret date expression \"" + e.Input + "\": " + e.Reason + ". Use an ISO 8601 date/time or a phrase such as \"tomorrow at 3pm\", \"in 2 weeks\", \"3 days ago\", \"next Tuesday\", \"end of next month\" or \"the first Monday of June\""
+}
+
+func NewInvalidDateExpressionError(input, reason string) *InvalidDateExpressionError {
+	return &InvalidDateExpressionError{
+		Input:  input,
+		Reason: reason,
+	}
+}
+
+type InvalidDurationError struct {
+	Input  string
+	Reason string
+}
+
+func (e *InvalidDurationError) Error() string {
+	return "invalid duration \"" + e.Input + "\": " + e.Reason + ". Use an ISO 8601 duration (e.g. P1Y2M10DT2H30M or P3W) or units such as '3 weeks 2 days', '2y3mo' or '1h30m'"
+}
+
+func NewInvalidDurationError(input, reason string) *InvalidDurationError {
+	return &InvalidDurationError{
+		Input:  input,
+		Reason: reason,
+	}
+}
+
+type InvalidMonthEndPolicyError struct {
+	Policy string
+}
+
+func (e *InvalidMonthEndPolicyError) Error() string {
+	return "invalid month end policy \"" + e.Policy + "\". Policy must be one of clamp or overflow"
+}
+
+func NewInvalidMonthEndPolicyError(policy string) *InvalidMonthEndPolicyError {
+	return &InvalidMonthEndPolicyError{
+		Policy: policy,
+	}
+}
+
+type InvalidOutputStyleError struct {
+	Style string
+}
+
+func (e *InvalidOutputStyleError) Error() string {
+	return "invalid output style \"" + e.Style + "\". Style must be one of raw, calendar, totals, approximate or all"
+}
+
+func NewInvalidOutputStyleError(style string) *InvalidOutputStyleError {
+	return &InvalidOutputStyleError{
+		Style: style,
+	}
+}
+
+type UnknownHolidayCalendarError struct {
+	Calendar string
+	Known    []string
+}
+
+func (e *UnknownHolidayCalendarError) Error() string {
+	return "unknown holiday calendar \"" + e.Calendar + "\". Calendar must be one of " + strings.Join(e.Known, ", ")
+}
+
+func NewUnknownHolidayCalendarError(calendar string, known []string) *UnknownHolidayCalendarError {
+	return &UnknownHolidayCalendarError{
+		Calendar: calendar,
+		Known:    known,
+	}
+}
+
+type InvalidWeekendError struct {
+	Weekend string
+}
+
+func (e *InvalidWeekendError) Error() string {
+	return "invalid weekend \"" + e.Weekend + "\". Weekend must be a comma-separated list of day names such as \"Friday,Saturday\", leaving at least one working day, or \"none\""
+}
+
+func NewInvalidWeekendError(weekend string) *InvalidWeekendError {
+	return &InvalidWeekendError{
+		Weekend: weekend,
+	}
+}
+
+type BusinessDaySpanError struct {
+	Days int
+}
+
+func (e *BusinessDaySpanError) Error() string {
+	return "a span of " + strconv.Itoa(e.Days) + " days is too long; business days are counted over at most " + strconv.Itoa(maxBusinessDaySpan) + " days (400 years)"
+}
+
+func NewBusinessDaySpanError(days int) *BusinessDaySpanError {
+	return &BusinessDaySpanError{
+		Days: days,
+	}
+}
+
+type InvalidHolidayRuleError struct {
+	Calendar string
+	Rule     string
+	Reason   string
+}
+
+func (e *InvalidHolidayRuleError) Error() string {
+	return "invalid holiday rule \"" + e.Rule + "\" in calendar \"" + e.Calendar + "\": " + e.Reason
+}
+
+func NewInvalidHolidayRuleError(calendar, rule, reason string) *InvalidHolidayRuleError {
+	return &InvalidHolidayRuleError{
+		Calendar: calendar,
+		Rule:     rule,
+		Reason:   reason,
+	}
+}
+
+type HolidayCalendarLoadError struct {
+	Path string
+	Err  error
+}
+
+func (e *HolidayCalendarLoadError) Error() string {
+	return "failed to load holiday calendars from \"" + e.Path + "\": " + e.Err.Error()
+}
+
+func (e *HolidayCalendarLoadError) Unwrap() error {
+	return e.Err
+}
+
+func NewHolidayCalendarLoadError(path string, err error) *HolidayCalendarLoadError {
+	return &HolidayCalendarLoadError{
+		Path: path,
+		Err:  err,
+	}
+}
+
+type UnknownFiscalCalendarError struct {
+	Calendar string
+	Known    []string
+}
+
+func (e *UnknownFiscalCalendarError) Error() string {
+	return "unknown fiscal calendar \"" + e.Calendar + "\". Calendar must be one of " + strings.Join(e.Known, ", ")
+}
+
+func NewUnknownFiscalCalendarError(calendar string, known []string) *UnknownFiscalCalendarError {
+	return &UnknownFiscalCalendarError{
+		Calendar: calendar,
+		Known:    known,
+	}
+}
+
+type InvalidFiscalCalendarError struct {
+	Calendar string
+	Reason   string
+}
+
+func (e *InvalidFiscalCalendarError) Error() string {
+	return "invalid fiscal calendar \"" + e.Calendar + "\": " + e.Reason
+}
+
+func NewInvalidFiscalCalendarError(calendar, reason string) *InvalidFiscalCalendarError {
+	return &InvalidFiscalCalendarError{
+		Calendar: calendar,
+		Reason:   reason,
+	}
+}
+
+type FiscalCalendarLoadError struct {
+	Path string
+	Err  error
+}
+
+func (e *FiscalCalendarLoadError) Error() string {
+	return "failed to load fiscal calendars from \"" + e.Path + "\": " + e.Err.Error()
+}
+
+func (e *FiscalCalendarLoadError) Unwrap() error {
+	return e.Err
+}
+
+func NewFiscalCalendarLoadError(path string, err error) *FiscalCalendarLoadError {
+	return &FiscalCalendarLoadError{
+		Path: path,
+		Err:  err,
+	}
+}
+
+type InvalidRecurrenceRuleError struct {
+	Rule   string
+	Reason string
+}
+
+func (e *InvalidRecurrenceRuleError) Error() string {
+	return "invalid recurrence rule \"" + e.Rule + "\": " + e.Reason
+}
+
+func NewInvalidRecurrenceRuleError(rule, reason string) *InvalidRecurrenceRuleError {
+	return &InvalidRecurrenceRuleError{
+		Rule:   rule,
+		Reason: reason,
+	}
+}
+
+type InvalidCronExpressionError struct {
+	Expression string
+	Reason     string
+}
+
+func (e *InvalidCronExpressionError) Error() string {
+	return "invalid cron expression \"" + e.Expression + "\": " + e.Reason
+}
+
+func NewInvalidCronExpressionError(expression, reason string) *InvalidCronExpressionError {
+	return &InvalidCronExpressionError{
+		Expression: expression,
+		Reason:     reason,
+	}
+}
+
+type InvalidTimerScopeError struct {
+	Scope string
+}
+
+func (e *InvalidTimerScopeError) Error() string {
+	return "invalid timer scope \"" + e.Scope + "\"; expected session or global"
+}
+
+func NewInvalidTimerScopeError(scope string) *InvalidTimerScopeError {
+	return &InvalidTimerScopeError{
+		Scope: scope,
+	}
+}
+
+type TimerNotFoundError struct {
+	Name string
+}
+
+func (e *TimerNotFoundError) Error() string {
+	return "no timer named \"" + e.Name + "\""
+}
+
+func NewTimerNotFoundError(name string) *TimerNotFoundError {
+	return &TimerNotFoundError{
+		Name: name,
+	}
+}
+
+type TimerRunningError struct {
+	Name    string
+	Started time.Time
+}
+
+func (e *TimerRunningError) Error() string {
+	return "timer \"" + e.Name + "\" is already running since " + e.Started.Format(dateTimeFormatTimeZone)
+}
+
+func NewTimerRunningError(name string, started time.Time) *TimerRunningError {
+	return &TimerRunningError{
+		Name:    name,
+		Started: started,
+	}
+}
+
+type TimerStoppedError struct {
+	Name    string
+	Stopped time.Time
+}
+
+func (e *TimerStoppedError) Error() string {
+	return "timer \"" + e.Name + "\" was stopped at " + e.Stopped.Format(dateTimeFormatTimeZone)
+}
+
+func NewTimerStoppedError(name string, stopped time.Time) *TimerStoppedError {
+	return &TimerStoppedError{
+		Name:    name,
+		Stopped: stopped,
+	}
+}
+
+type TimerStoreError struct {
+	Path string
+	Err  error
+}
+
+func (e *TimerStoreError) Error() string {
+	return "failed to save or load timers in \"" + e.Path + "\": " + e.Err.Error()
+}
+
+func (e *TimerStoreError) Unwrap() error {
+	return e.Err
+}
+
+func NewTimerStoreError(path string, err error) *TimerStoreError {
+	return &TimerStoreError{
+		Path: path,
+		Err:  err,
+	}
+}
+
+type EventNotFoundError struct {
+	Name string
+}
+
+func (e *EventNotFoundError) Error() string {
+	return "no event named \"" + e.Name + "\""
+}
+
+func NewEventNotFoundError(name string) *EventNotFoundError {
+	return &EventNotFoundError{
+		Name: name,
+	}
+}
+
+type EventStoreError struct {
+	Path string
+	Err  error
+}
+
+func (e *EventStoreError) Error() string {
+	return "failed to save or load events in \"" + e.Path + "\": " + e.Err.Error()
+}
+
+func (e *EventStoreError) Unwrap() error {
+	return e.Err
+}
+
+func NewEventStoreError(path string, err error) *EventStoreError {
+	return &EventStoreError{
+		Path: path,
+		Err:  err,
+	}
+}
+
+type UnknownCalendarSystemError struct {
+	Calendar string
+	Known    []string
+}
+
+func (e *UnknownCalendarSystemError) Error() string {
+	return "unknown calendar \"" + e.Calendar + "\". Calendar must be one of " + strings.Join(e.Known, ", ")
+}
+
+func NewUnknownCalendarSystemError(calendar string, known []string) *UnknownCalendarSystemError {
+	return &UnknownCalendarSystemError{
+		Calendar: calendar,
+		Known:    known,
+	}
+}
+
+type InvalidCalendarDateError struct {
+	Calendar  string
+	Year      int
+	Month     int
+	Day       int
+	LeapMonth bool
+}
+
+func (e *InvalidCalendarDateError) Error() string {
+	month := strconv.Itoa(e.Month)
+	if e.LeapMonth {
+		month = "leap " + month
+	}
+	return "the " + e.Calendar + " calendar has no day " + strconv.Itoa(e.Day) + " of month " + month + " in year " + strconv.Itoa(e.Year) + ", or it is outside the years the conversion supports"
+}
+
+func NewInvalidCalendarDateError(calendar string, year, month, day int, leapMonth bool) *InvalidCalendarDateError {
+	return &InvalidCalendarDateError{
+		Calendar:  calendar,
+		Year:      year,
+		Month:     month,
+		Day:       day,
+		LeapMonth: leapMonth,
+	}
+}
+
+type InvalidCoordinatesError struct {
+	Latitude  float64
+	Longitude float64
+}
+
+func (e *InvalidCoordinatesError) Error() string {
+	return "invalid coordinates " + strconv.FormatFloat(e.Latitude, 'f', -1, 64) + ", " + strconv.FormatFloat(e.Longitude, 'f', -1, 64) + ". Latitude must be from -90 to 90 and longitude from -180 to 180 degrees"
+}
+
+func NewInvalidCoordinatesError(latitude, longitude float64) *InvalidCoordinatesError {
+	return &InvalidCoordinatesError{
+		Latitude:  latitude,
+		Longitude: longitude,
 	}
 }
@@ -0,0 +1,238 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event is a named deadline or appointment.
+type Event struct {
+	Name        string    `json:"name"`
+	Time        time.Time `json:"time"`
+	TimeZone    string    `json:"timeZone"`
+	Description string    `json:"description,omitempty"`
+	// Created is when the event was registered or last replaced.
+	Created time.Time `json:"created"`
+}
+
+// EventStore keeps named events.  Names are matched without regard to case.
+// Implementations must be safe for concurrent use.
+type EventStore interface {
+	// Put adds e, replacing any event of the same name, and reports whether
+	// one was replaced.
+	Put(e Event) (bool, error)
+	// Get returns the named event or an EventNotFoundError.
+	Get(name string) (Event, error)
+	// Delete removes the named event and returns it.
+	Delete(name string) (Event, error)
+	// List returns every event, soonest first.
+	List() ([]Event, error)
+}
+
+// eventKey is the key under which an event name is stored.
+func eventKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// sortEvents orders events by time, then by name.
+func sortEvents(events []Event) {
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Time.Equal(events[j].Time) {
+			return events[i].Name < events[j].Name
+		}
+		return events[i].Time.Before(events[j].Time)
+	})
+}
+
+// MemoryEventStore keeps events for the life of the process.
+type MemoryEventStore struct {
+	mu     sync.Mutex
+	events map[string]Event
+}
+
+func NewMemoryEventStore() *MemoryEventStore {
+	return &MemoryEventStore{events: map[string]Event{}}
+}
+
+func (m *MemoryEventStore) Put(e Event) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, replaced := m.events[eventKey(e.Name)]
+	m.events[eventKey(e.Name)] = e
+	return replaced, nil
+}
+
+func (m *MemoryEventStore) Get(name string) (Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.events[eventKey(name)]
+	if !ok {
+		return Event{}, NewEventNotFoundError(name)
+	}
+	return e, nil
+}
+
+func (m *MemoryEventStore) Delete(name string) (Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.events[eventKey(name)]
+	if !ok {
+		return Event{}, NewEventNotFoundError(name)
+	}
+	delete(m.events, eventKey(name))
+	return e, nil
+}
+
+func (m *MemoryEventStore) List() ([]Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	events := make([]Event, 0, len(m.events))
+	for _, e := range m.events {
+		events = append(events, e)
+	}
+	sortEvents(events)
+	return events, nil
+}
+
+// FileEventStore keeps events in a JSON file.  The file is read on every
+// call, so that servers sharing it, such as one stdio server per client, see
+// each other's changes.  The file is not locked, though: if two servers
+// change events at the same moment, one of the changes can be lost.
+type FileEventStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileEventStore returns a store backed by path, which is created on the
+// first change if it does not exist.
+func NewFileEventStore(path string) (*FileEventStore, error) {
+	f := &FileEventStore{path: path}
+	if _, err := f.load(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// load reads the events in the file.  The caller must hold f.mu.
+func (f *FileEventStore) load() (map[string]Event, error) {
+	events := map[string]Event{}
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return events, nil
+	}
+	if err != nil {
+		return nil, NewEventStoreError(f.path, err)
+	}
+	var list []Event
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, NewEventStoreError(f.path, err)
+	}
+	for _, e := range list {
+		events[eventKey(e.Name)] = e
+	}
+	return events, nil
+}
+
+// save replaces the file with events.  The caller must hold f.mu.
+func (f *FileEventStore) save(events map[string]Event) error {
+	list := make([]Event, 0, len(events))
+	for _, e := range events {
+		list = append(list, e)
+	}
+	sortEvents(list)
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return NewEventStoreError(f.path, err)
+	}
+	if err := writeFileAtomic(f.path, data); err != nil {
+		return NewEventStoreError(f.path, err)
+	}
+	return nil
+}
+
+func (f *FileEventStore) Put(e Event) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	events, err := f.load()
+	if err != nil {
+		return false, err
+	}
+	_, replaced := events[eventKey(e.Name)]
+	events[eventKey(e.Name)] = e
+	return replaced, f.save(events)
+}
+
+func (f *FileEventStore) Get(name string) (Event, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	events, err := f.load()
+	if err != nil {
+		return Event{}, err
+	}
+	e, ok := events[eventKey(name)]
+	if !ok {
+		return Event{}, NewEventNotFoundError(name)
+	}
+	return e, nil
+}
+
+func (f *FileEventStore) Delete(name string) (Event, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	events, err := f.load()
+	if err != nil {
+		return Event{}, err
+	}
+	e, ok := events[eventKey(name)]
+	if !ok {
+		return Event{}, NewEventNotFoundError(name)
+	}
+	delete(events, eventKey(name))
+	return e, f.save(events)
+}
+
+func (f *FileEventStore) List() ([]Event, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	events, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]Event, 0, len(events))
+	for _, e := range events {
+		list = append(list, e)
+	}
+	sortEvents(list)
+	return list, nil
+}
+
+// EventStatus is where an event stands relative to the current time.
+type EventStatus string
+
+const (
+	EventUpcoming EventStatus = "upcoming"
+	EventDue      EventStatus = "due"
+	EventOverdue  EventStatus = "overdue"
+)
+
+// eventDueWindow is how long an event is due, from its time, before it is
+// overdue.  Event times are rarely given more finely than to the minute.
+const eventDueWindow = time.Minute
+
+// eventStatus returns the status of an event at t as seen at now.
+func eventStatus(t, now time.Time) EventStatus {
+	switch {
+	case t.After(now):
+		return EventUpcoming
+	case now.Before(t.Add(eventDueWindow)):
+		return EventDue
+	default:
+		return EventOverdue
+	}
+}
@@ -0,0 +1,126 @@
+package mcp
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testEventStore exercises an empty EventStore.
+func testEventStore(t *testing.T, store EventStore) {
+	t.Helper()
+	due := time.Date(2025, time.September, 30, 21, 0, 0, 0, time.UTC)
+
+	if replaced, err := store.Put(Event{Name: "Q3 report", Time: due, TimeZone: "America/New_York"}); err != nil || replaced {
+		t.Fatalf("Put() = %v, %v, want false, nil", replaced, err)
+	}
+	if _, err := store.Put(Event{Name: "Launch", Time: due.Add(-time.Hour), TimeZone: "UTC"}); err != nil {
+		t.Fatal(err)
+	}
+	if replaced, err := store.Put(Event{Name: "q3 REPORT", Time: due.Add(time.Hour), TimeZone: "America/New_York"}); err != nil || !replaced {
+		t.Errorf("Put() of a name in another case = %v, %v, want true, nil", replaced, err)
+	}
+
+	e, err := store.Get("Q3 Report")
+	if err != nil || !e.Time.Equal(due.Add(time.Hour)) || e.TimeZone != "America/New_York" {
+		t.Errorf("Get() = %+v, %v", e, err)
+	}
+	list, err := store.List()
+	if err != nil || len(list) != 2 || list[0].Name != "Launch" {
+		t.Errorf("List() = %+v, %v, want Launch first", list, err)
+	}
+
+	if _, err := store.Delete("launch"); err != nil {
+		t.Errorf("Delete() returned error: %v", err)
+	}
+	var notFound *EventNotFoundError
+	if _, err := store.Get("Launch"); !errors.As(err, &notFound) {
+		t.Errorf("Get() of a deleted event: error = %v, want EventNotFoundError", err)
+	}
+	if _, err := store.Delete("Launch"); !errors.As(err, &notFound) {
+		t.Errorf("Delete() of a deleted event: error = %v, want EventNotFoundError", err)
+	}
+}
+
+func TestMemoryEventStore(t *testing.T) {
+	testEventStore(t, NewMemoryEventStore())
+}
+
+func TestFileEventStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.json")
+	store, err := NewFileEventStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testEventStore(t, store)
+
+	// A second store on the same file sees the first one's events.
+	other, err := NewFileEventStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.Get("Q3 report"); err != nil {
+		t.Errorf("Get() from a second store returned error: %v", err)
+	}
+	if _, err := other.Put(Event{Name: "Retro", Time: time.Date(2025, time.October, 1, 9, 0, 0, 0, time.UTC), TimeZone: "UTC"}); err != nil {
+		t.Fatal(err)
+	}
+	if list, _ := store.List(); len(list) != 2 {
+		t.Errorf("List() after a second store added an event = %+v", list)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var storeErr *EventStoreError
+	if _, err := NewFileEventStore(path); !errors.As(err, &storeErr) {
+		t.Errorf("NewFileEventStore() on a corrupt file: error = %v, want EventStoreError", err)
+	}
+}
+
+func TestEventStatus(t *testing.T) {
+	at := time.Date(2025, time.September, 30, 17, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		now  time.Time
+		want EventStatus
+	}{
+		{now: at.Add(-time.Second), want: EventUpcoming},
+		{now: at, want: EventDue},
+		{now: at.Add(59 * time.Second), want: EventDue},
+		{now: at.Add(time.Minute), want: EventOverdue},
+	}
+	for _, tc := range testCases {
+		if got := eventStatus(at, tc.now); got != tc.want {
+			t.Errorf("eventStatus(%s, %s) = %s, want %s", at.Format(time.TimeOnly), tc.now.Format(time.TimeOnly), got, tc.want)
+		}
+	}
+}
+
+func TestEventResources(t *testing.T) {
+	s := NewServer()
+	s.TimeManager = newFakeClock(time.Date(2025, time.September, 29, 21, 0, 0, 0, time.UTC))
+	s.Events.Put(Event{Name: "Q3 report", Time: time.Date(2025, time.September, 30, 21, 0, 0, 0, time.UTC), TimeZone: "America/New_York"})
+	s.Events.Put(Event{Name: "Kickoff", Time: time.Date(2025, time.September, 1, 9, 0, 0, 0, time.UTC), TimeZone: "UTC"})
+
+	all, ok := readResource(t, s, "time://events")
+	if !ok {
+		t.Fatal("reading time://events failed")
+	}
+	events := all["events"].([]any)
+	if len(events) != 2 || events[0].(map[string]any)["status"] != "overdue" {
+		t.Errorf("time://events = %v, want both events with the past one first", all)
+	}
+
+	one, ok := readResource(t, s, "time://events/Q3%20report")
+	if !ok {
+		t.Fatal("reading time://events/Q3%20report failed")
+	}
+	if one["name"] != "Q3 report" || one["status"] != "upcoming" || one["secondsRemaining"] != float64(86400) {
+		t.Errorf("time://events/Q3%%20report = %v", one)
+	}
+	if _, ok := readResource(t, s, "time://events/missing"); ok {
+		t.Error("reading an unknown event succeeded")
+	}
+}
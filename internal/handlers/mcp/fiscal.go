@@ -0,0 +1,435 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FiscalYearEnd is how a 52/53-week fiscal year picks its last day.
+type FiscalYearEnd string
+
+const (
+	// FiscalYearEndLast ends the year on the last given weekday of the
+	// month before the start month.
+	FiscalYearEndLast FiscalYearEnd = "last"
+	// FiscalYearEndNearest ends the year on the given weekday nearest the
+	// last day of the month before the start month.
+	FiscalYearEndNearest FiscalYearEnd = "nearest"
+)
+
+// FiscalCalendarSpec describes a fiscal calendar, as written in a file
+// read by FiscalCalendarRegistry.LoadFile.
+type FiscalCalendarSpec struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+	// StartMonth is the month, 1 to 12, that fiscal years start in.
+	StartMonth int `json:"startMonth"`
+	// Pattern is the weeks of the three periods of each quarter of a
+	// 52/53-week calendar: "4-4-5", "4-5-4" or "5-4-4".  Without one,
+	// periods are calendar months.
+	Pattern string `json:"pattern,omitempty"`
+	// YearEnd and Weekday pick the last day of a 52/53-week year, e.g. the
+	// Saturday nearest the end of the month before StartMonth.
+	YearEnd FiscalYearEnd `json:"yearEnd,omitempty"`
+	Weekday string        `json:"weekday,omitempty"`
+	// NamedFor is "end" (the default) when a fiscal year is named for the
+	// calendar year it ends in and "start" when it is named for the year it
+	// starts in.
+	NamedFor string `json:"namedFor,omitempty"`
+}
+
+// FiscalCalendar divides time into fiscal years of four quarters of three
+// periods each.  A month-based calendar's periods are calendar months; a
+// 52/53-week calendar's are whole weeks, with the extra week of a 53-week
+// year added to the last period.
+type FiscalCalendar struct {
+	ID         string
+	Name       string
+	StartMonth time.Month
+	// Pattern is the weeks of the periods of a quarter, e.g. [4 4 5], or
+	// nil for a month-based calendar.
+	Pattern    []int
+	YearEnd    FiscalYearEnd
+	EndWeekday time.Weekday
+	// NamedForStart is whether fiscal years are named for the calendar year
+	// they start in rather than the one they end in.
+	NamedForStart bool
+}
+
+// NewFiscalCalendar validates spec and returns the calendar it describes.
+func NewFiscalCalendar(spec FiscalCalendarSpec) (*FiscalCalendar, error) {
+	if spec.ID == "" {
+		return nil, NewInvalidFiscalCalendarError(spec.ID, "every calendar needs an id")
+	}
+	if spec.StartMonth < 1 || spec.StartMonth > 12 {
+		return nil, NewInvalidFiscalCalendarError(spec.ID, fmt.Sprintf("invalid startMonth %d; expected 1 to 12", spec.StartMonth))
+	}
+	c := &FiscalCalendar{ID: spec.ID, Name: spec.Name, StartMonth: time.Month(spec.StartMonth)}
+	if c.Name == "" {
+		c.Name = spec.ID
+	}
+	switch spec.NamedFor {
+	case "", "end":
+	case "start":
+		c.NamedForStart = true
+	default:
+		return nil, NewInvalidFiscalCalendarError(spec.ID, fmt.Sprintf("invalid namedFor %q; expected start or end", spec.NamedFor))
+	}
+
+	if spec.Pattern == "" {
+		if spec.YearEnd != "" || spec.Weekday != "" {
+			return nil, NewInvalidFiscalCalendarError(spec.ID, "yearEnd and weekday need a week pattern such as 4-4-5")
+		}
+		return c, nil
+	}
+	pattern, err := parseFiscalPattern(spec.Pattern)
+	if err != nil {
+		return nil, NewInvalidFiscalCalendarError(spec.ID, err.Error())
+	}
+	c.Pattern = pattern
+	switch spec.YearEnd {
+	case FiscalYearEndLast, FiscalYearEndNearest:
+		c.YearEnd = spec.YearEnd
+	default:
+		return nil, NewInvalidFiscalCalendarError(spec.ID, fmt.Sprintf("invalid yearEnd %q; expected last or nearest", spec.YearEnd))
+	}
+	if c.EndWeekday, err = parseWeekday(spec.Weekday); err != nil {
+		return nil, NewInvalidFiscalCalendarError(spec.ID, err.Error())
+	}
+	return c, nil
+}
+
+// parseFiscalPattern parses the weeks of the periods of a quarter, which
+// must be one of 4-4-5, 4-5-4 and 5-4-4.
+func parseFiscalPattern(s string) ([]int, error) {
+	var weeks []int
+	total := 0
+	for _, field := range strings.Split(s, "-") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || (n != 4 && n != 5) {
+			weeks = nil
+			break
+		}
+		weeks = append(weeks, n)
+		total += n
+	}
+	if len(weeks) != 3 || total != 13 {
+		return nil, fmt.Errorf("invalid pattern %q; expected 4-4-5, 4-5-4 or 5-4-4", s)
+	}
+	return weeks, nil
+}
+
+// WeekBased reports whether c is a 52/53-week calendar.
+func (c *FiscalCalendar) WeekBased() bool {
+	return c.Pattern != nil
+}
+
+// Describe summarizes the rules of c, e.g. "calendar months, years
+// starting in October and named for the year they end in".
+func (c *FiscalCalendar) Describe() string {
+	named := "named for the year they end in"
+	if c.NamedForStart {
+		named = "named for the year they start in"
+	}
+	if !c.WeekBased() {
+		return fmt.Sprintf("calendar months, years starting in %s and %s", c.StartMonth, named)
+	}
+	weeks := fmt.Sprintf("%d-%d-%d", c.Pattern[0], c.Pattern[1], c.Pattern[2])
+	if c.YearEnd == FiscalYearEndNearest {
+		return fmt.Sprintf("%s weeks, years ending on the %s nearest the end of %s and %s", weeks, c.EndWeekday, c.endMonth(), named)
+	}
+	return fmt.Sprintf("%s weeks, years ending on the last %s of %s and %s", weeks, c.EndWeekday, c.endMonth(), named)
+}
+
+// endMonth is the month fiscal years end in, or around for a 52/53-week
+// calendar.
+func (c *FiscalCalendar) endMonth() time.Month {
+	return (c.StartMonth+10)%12 + 1
+}
+
+// FiscalYear is one year of a fiscal calendar.
+type FiscalYear struct {
+	// Year is the number the year is named for.
+	Year int
+	datePeriod
+	// periods holds the twelve periods of the year.
+	periods []datePeriod
+}
+
+// Label names y, e.g. "FY2025".
+func (y FiscalYear) Label() string {
+	return fmt.Sprintf("FY%d", y.Year)
+}
+
+// quarter returns quarter q of y, from 1 to 4.
+func (y FiscalYear) quarter(q int) datePeriod {
+	return datePeriod{y.periods[3*q-3].first, y.periods[3*q-1].last}
+}
+
+// Year returns the fiscal year of c named year.
+func (c *FiscalCalendar) Year(year int) FiscalYear {
+	y := FiscalYear{Year: year}
+	if !c.WeekBased() {
+		startYear := year
+		if !c.NamedForStart && c.StartMonth != time.January {
+			startYear--
+		}
+		first := time.Date(startYear, c.StartMonth, 1, 0, 0, 0, 0, time.UTC)
+		y.datePeriod = datePeriod{first, first.AddDate(1, 0, -1)}
+		for i := range 12 {
+			y.periods = append(y.periods, datePeriod{first.AddDate(0, i, 0), first.AddDate(0, i+1, -1)})
+		}
+		return y
+	}
+
+	endYear := year
+	if c.NamedForStart && c.StartMonth != time.January {
+		endYear++
+	}
+	y.datePeriod = datePeriod{c.yearEnd(endYear-1).AddDate(0, 0, 1), c.yearEnd(endYear)}
+	first := y.first
+	for i := range 12 {
+		weeks := c.Pattern[i%3]
+		if i == 11 && y.days() == 53*7 {
+			weeks++
+		}
+		last := first.AddDate(0, 0, 7*weeks-1)
+		y.periods = append(y.periods, datePeriod{first, last})
+		first = last.AddDate(0, 0, 1)
+	}
+	return y
+}
+
+// yearEnd returns the last day of the 52/53-week year ending around the end
+// of c's end month in the calendar year year.
+func (c *FiscalCalendar) yearEnd(year int) time.Time {
+	monthEnd := time.Date(year, c.endMonth()+1, 0, 0, 0, 0, 0, time.UTC)
+	if c.YearEnd == FiscalYearEndNearest {
+		ahead := (int(c.EndWeekday) - int(monthEnd.Weekday()) + 7) % 7
+		if ahead > 3 {
+			ahead -= 7
+		}
+		return monthEnd.AddDate(0, 0, ahead)
+	}
+	return monthEnd.AddDate(0, 0, -((int(monthEnd.Weekday()) - int(c.EndWeekday) + 7) % 7))
+}
+
+// YearOf returns the fiscal year of c containing the calendar date of d.
+func (c *FiscalCalendar) YearOf(d time.Time) FiscalYear {
+	d = civilDate(d)
+	for _, year := range []int{d.Year() - 1, d.Year(), d.Year() + 1} {
+		if y := c.Year(year); !d.Before(y.first) && !d.After(y.last) {
+			return y
+		}
+	}
+	// Every date lies within a year of the fiscal year named for its
+	// calendar year.
+	panic(fmt.Sprintf("no fiscal year of %s contains %s", c.ID, d.Format(dateFormat)))
+}
+
+// FiscalDate is the position of a date in a fiscal year.
+type FiscalDate struct {
+	Date time.Time
+	FiscalYear
+	// Quarter is from 1 to 4 and Period from 1 to 12.  Week counts the
+	// seven-day weeks from the start of the year, from 1.
+	Quarter int
+	Period  int
+	Week    int
+}
+
+// Date returns the position of the calendar date of d in c.
+func (c *FiscalCalendar) Date(d time.Time) FiscalDate {
+	d = civilDate(d)
+	y := c.YearOf(d)
+	fd := FiscalDate{Date: d, FiscalYear: y, Week: daysFrom(y.first, d)/7 + 1}
+	for i, p := range y.periods {
+		if !d.After(p.last) {
+			fd.Period = i + 1
+			break
+		}
+	}
+	fd.Quarter = (fd.Period-1)/3 + 1
+	return fd
+}
+
+// week returns the seven-day fiscal week containing fd.  The last week of a
+// month-based year may be cut short by the end of the year.
+func (fd FiscalDate) week() datePeriod {
+	first := fd.first.AddDate(0, 0, 7*(fd.Week-1))
+	last := first.AddDate(0, 0, 6)
+	if last.After(fd.last) {
+		last = fd.last
+	}
+	return datePeriod{first, last}
+}
+
+// FiscalCalendarRegistry holds the fiscal calendars available to the tools,
+// keyed by case-insensitive ID.
+type FiscalCalendarRegistry struct {
+	mu        sync.RWMutex
+	calendars map[string]*FiscalCalendar
+}
+
+// NewFiscalCalendarRegistry returns a registry holding the built-in
+// calendars.
+func NewFiscalCalendarRegistry() *FiscalCalendarRegistry {
+	r := &FiscalCalendarRegistry{calendars: map[string]*FiscalCalendar{}}
+	for _, c := range builtinFiscalCalendars() {
+		r.Add(c)
+	}
+	return r
+}
+
+// Add registers c, replacing any calendar with the same ID.
+func (r *FiscalCalendarRegistry) Add(c *FiscalCalendar) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calendars[strings.ToUpper(c.ID)] = c
+}
+
+// Lookup returns the calendar with the given ID.
+func (r *FiscalCalendarRegistry) Lookup(id string) (*FiscalCalendar, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if c, ok := r.calendars[strings.ToUpper(strings.TrimSpace(id))]; ok {
+		return c, nil
+	}
+	return nil, NewUnknownFiscalCalendarError(id, r.idsLocked())
+}
+
+// IDs lists the registered calendar IDs in sorted order.
+func (r *FiscalCalendarRegistry) IDs() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.idsLocked()
+}
+
+func (r *FiscalCalendarRegistry) idsLocked() []string {
+	ids := make([]string, 0, len(r.calendars))
+	for _, c := range r.calendars {
+		ids = append(ids, c.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// LoadFile adds the calendars described in a JSON file holding an array of
+// FiscalCalendarSpec objects such as:
+//
+//	{"id": "ACME", "name": "ACME Corp", "startMonth": 7},
+//	{"id": "ACME-RETAIL", "startMonth": 9, "pattern": "4-4-5", "yearEnd": "last", "weekday": "Saturday"}
+//
+// Nothing is added unless every calendar in the file is valid.
+func (r *FiscalCalendarRegistry) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return NewFiscalCalendarLoadError(path, err)
+	}
+	var specs []FiscalCalendarSpec
+	if err := json.Unmarshal(data, &specs); err != nil {
+		return NewFiscalCalendarLoadError(path, err)
+	}
+	var calendars []*FiscalCalendar
+	for _, spec := range specs {
+		c, err := NewFiscalCalendar(spec)
+		if err != nil {
+			return NewFiscalCalendarLoadError(path, err)
+		}
+		calendars = append(calendars, c)
+	}
+	for _, c := range calendars {
+		r.Add(c)
+	}
+	return nil
+}
+
+// builtinFiscalCalendars returns the calendars compiled into the server.
+func builtinFiscalCalendars() []*FiscalCalendar {
+	specs := []FiscalCalendarSpec{
+		{ID: "CALENDAR", Name: "Calendar year", StartMonth: 1},
+		{ID: "US", Name: "United States federal government", StartMonth: 10},
+		{ID: "UK", Name: "United Kingdom government", StartMonth: 4, NamedFor: "start"},
+		{ID: "AU", Name: "Australian government", StartMonth: 7},
+		{ID: "NRF", Name: "National Retail Federation 4-5-4 calendar", StartMonth: 2, Pattern: "4-5-4", YearEnd: FiscalYearEndNearest, Weekday: "Saturday", NamedFor: "start"},
+	}
+	var out []*FiscalCalendar
+	for _, spec := range specs {
+		c, err := NewFiscalCalendar(spec)
+		if err != nil {
+			panic(err)
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// newFiscalDateOutput describes the position of fd in c.
+func newFiscalDateOutput(c *FiscalCalendar, fd FiscalDate) FiscalDateOutput {
+	output := FiscalDateOutput{
+		Date:         fd.Date.Format(dateFormat),
+		Calendar:     c.ID,
+		CalendarName: c.Name,
+		FiscalYear:   fd.Year,
+		Label:        fd.Label(),
+		Quarter:      fd.Quarter,
+		Period:       fd.Period,
+		Week:         fd.Week,
+		DayOfYear:    daysFrom(fd.first, fd.Date) + 1,
+		Periods: FiscalPeriodsOutput{
+			Week:    newPeriodOutput(fd.week(), fd.Date),
+			Period:  newPeriodOutput(fd.periods[fd.Period-1], fd.Date),
+			Quarter: newPeriodOutput(fd.quarter(fd.Quarter), fd.Date),
+			Year:    newPeriodOutput(fd.datePeriod, fd.Date),
+		},
+	}
+	if c.WeekBased() {
+		output.WeeksInYear = fd.days() / 7
+	}
+	return output
+}
+
+// newFiscalYearOutput lists the quarters and periods of y in c.
+func newFiscalYearOutput(c *FiscalCalendar, y FiscalYear) FiscalYearOutput {
+	period := func(n int, p datePeriod) FiscalPeriodOutput {
+		out := FiscalPeriodOutput{Number: n, First: p.first.Format(dateFormat), Last: p.last.Format(dateFormat), Days: p.days()}
+		if c.WeekBased() {
+			out.Weeks = p.days() / 7
+		}
+		return out
+	}
+	output := period(0, y.datePeriod)
+	yearOutput := FiscalYearOutput{
+		Calendar:     c.ID,
+		CalendarName: c.Name,
+		Rules:        c.Describe(),
+		FiscalYear:   y.Year,
+		Label:        y.Label(),
+		First:        output.First,
+		Last:         output.Last,
+		Days:         output.Days,
+		Weeks:        output.Weeks,
+	}
+	for q := 1; q <= 4; q++ {
+		yearOutput.Quarters = append(yearOutput.Quarters, period(q, y.quarter(q)))
+	}
+	for i, p := range y.periods {
+		yearOutput.Periods = append(yearOutput.Periods, period(i+1, p))
+	}
+	return yearOutput
+}
+
+// describeFiscalPeriod summarizes a quarter or period, e.g. "2024-02-04 to
+// 2024-03-02 (4 weeks)".
+func describeFiscalPeriod(p FiscalPeriodOutput) string {
+	if p.Weeks > 0 {
+		return fmt.Sprintf("%s to %s (%s)", p.First, p.Last, plural(p.Weeks, "week"))
+	}
+	return fmt.Sprintf("%s to %s (%s)", p.First, p.Last, plural(p.Days, "day"))
+}
@@ -0,0 +1,149 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFiscalYear(t *testing.T) {
+	registry := NewFiscalCalendarRegistry()
+	lastSaturday, err := NewFiscalCalendar(FiscalCalendarSpec{ID: "LAST", StartMonth: 10, Pattern: "4-4-5", YearEnd: FiscalYearEndLast, Weekday: "Saturday"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry.Add(lastSaturday)
+
+	testCases := []struct {
+		desc        string
+		calendar    string
+		year        int
+		first, last string
+		// firstPeriods holds the last days of the first three periods.
+		firstPeriods []string
+	}{
+		{desc: "Calendar year", calendar: "CALENDAR", year: 2024, first: "2024-01-01", last: "2024-12-31", firstPeriods: []string{"2024-01-31", "2024-02-29", "2024-03-31"}},
+		{desc: "Named for the end year", calendar: "US", year: 2024, first: "2023-10-01", last: "2024-09-30", firstPeriods: []string{"2023-10-31", "2023-11-30", "2023-12-31"}},
+		{desc: "Named for the start year", calendar: "UK", year: 2024, first: "2024-04-01", last: "2025-03-31", firstPeriods: []string{"2024-04-30", "2024-05-31", "2024-06-30"}},
+		{desc: "Nearest Saturday, 52 weeks", calendar: "NRF", year: 2024, first: "2024-02-04", last: "2025-02-01", firstPeriods: []string{"2024-03-02", "2024-04-06", "2024-05-04"}},
+		{desc: "Nearest Saturday, 53 weeks", calendar: "NRF", year: 2023, first: "2023-01-29", last: "2024-02-03", firstPeriods: []string{"2023-02-25", "2023-04-01", "2023-04-29"}},
+		{desc: "Last Saturday, 52 weeks", calendar: "LAST", year: 2024, first: "2023-10-01", last: "2024-09-28", firstPeriods: []string{"2023-10-28", "2023-11-25", "2023-12-30"}},
+		{desc: "Last Saturday, 53 weeks", calendar: "LAST", year: 2023, first: "2022-09-25", last: "2023-09-30", firstPeriods: []string{"2022-10-22", "2022-11-19", "2022-12-24"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			c, err := registry.Lookup(tc.calendar)
+			if err != nil {
+				t.Fatal(err)
+			}
+			y := c.Year(tc.year)
+			if got, want := y.first.Format(dateFormat)+" to "+y.last.Format(dateFormat), tc.first+" to "+tc.last; got != want {
+				t.Errorf("Year(%d) = %s, want %s", tc.year, got, want)
+			}
+			for i, want := range tc.firstPeriods {
+				if got := y.periods[i].last.Format(dateFormat); got != want {
+					t.Errorf("period %d ends %s, want %s", i+1, got, want)
+				}
+			}
+			if got := y.periods[11].last; !got.Equal(y.last) {
+				t.Errorf("period 12 ends %s, want the end of the year", got.Format(dateFormat))
+			}
+			for _, d := range []time.Time{y.first, y.last} {
+				if got := c.YearOf(d).Year; got != tc.year {
+					t.Errorf("YearOf(%s) = %d, want %d", d.Format(dateFormat), got, tc.year)
+				}
+			}
+		})
+	}
+}
+
+func TestFiscalCalendarDate(t *testing.T) {
+	registry := NewFiscalCalendarRegistry()
+	testCases := []struct {
+		calendar                    string
+		date                        string
+		year, quarter, period, week int
+	}{
+		{calendar: "US", date: "2023-10-01", year: 2024, quarter: 1, period: 1, week: 1},
+		{calendar: "US", date: "2024-09-30", year: 2024, quarter: 4, period: 12, week: 53},
+		{calendar: "UK", date: "2025-01-15", year: 2024, quarter: 4, period: 10, week: 42},
+		{calendar: "NRF", date: "2025-02-01", year: 2024, quarter: 4, period: 12, week: 52},
+		{calendar: "NRF", date: "2024-02-03", year: 2023, quarter: 4, period: 12, week: 53},
+		{calendar: "NRF", date: "2024-04-07", year: 2024, quarter: 1, period: 3, week: 10},
+	}
+	for _, tc := range testCases {
+		c, err := registry.Lookup(tc.calendar)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d, _ := time.Parse(dateFormat, tc.date)
+		fd := c.Date(d)
+		if fd.Year != tc.year || fd.Quarter != tc.quarter || fd.Period != tc.period || fd.Week != tc.week {
+			t.Errorf("%s Date(%s) = FY%d Q%d P%d W%d, want FY%d Q%d P%d W%d", tc.calendar, tc.date, fd.Year, fd.Quarter, fd.Period, fd.Week, tc.year, tc.quarter, tc.period, tc.week)
+		}
+	}
+}
+
+func TestNewFiscalCalendar(t *testing.T) {
+	testCases := []struct {
+		desc string
+		spec FiscalCalendarSpec
+	}{
+		{desc: "Missing id", spec: FiscalCalendarSpec{StartMonth: 1}},
+		{desc: "Invalid start month", spec: FiscalCalendarSpec{ID: "X", StartMonth: 13}},
+		{desc: "Invalid pattern", spec: FiscalCalendarSpec{ID: "X", StartMonth: 2, Pattern: "4-4-4", YearEnd: FiscalYearEndLast, Weekday: "Saturday"}},
+		{desc: "Missing year end", spec: FiscalCalendarSpec{ID: "X", StartMonth: 2, Pattern: "4-4-5", Weekday: "Saturday"}},
+		{desc: "Invalid weekday", spec: FiscalCalendarSpec{ID: "X", StartMonth: 2, Pattern: "4-4-5", YearEnd: FiscalYearEndLast, Weekday: "Caturday"}},
+		{desc: "Year end without a pattern", spec: FiscalCalendarSpec{ID: "X", StartMonth: 2, YearEnd: FiscalYearEndLast}},
+		{desc: "Invalid naming", spec: FiscalCalendarSpec{ID: "X", StartMonth: 2, NamedFor: "middle"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if _, err := NewFiscalCalendar(tc.spec); err == nil {
+				t.Errorf("NewFiscalCalendar(%+v) succeeded, want an error", tc.spec)
+			}
+		})
+	}
+}
+
+func TestFiscalCalendarRegistryLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fiscal.json")
+	content := `[{"id": "acme", "name": "ACME Corp", "startMonth": 7},
+		{"id": "ACME-RETAIL", "startMonth": 9, "pattern": "5-4-4", "yearEnd": "nearest", "weekday": "Sunday", "namedFor": "start"}]`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	registry := NewFiscalCalendarRegistry()
+	if err := registry.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	c, err := registry.Lookup("ACME")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if got := c.Year(2025).first.Format(dateFormat); got != "2024-07-01" {
+		t.Errorf("ACME FY2025 starts %s, want 2024-07-01", got)
+	}
+	retail, err := registry.Lookup("acme-retail")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if got := retail.Year(2024).periods[0].days(); got != 35 {
+		t.Errorf("ACME-RETAIL period 1 has %d days, want 35", got)
+	}
+
+	bad := filepath.Join(t.TempDir(), "bad.json")
+	if err := os.WriteFile(bad, []byte(`[{"id": "GOOD", "startMonth": 1}, {"id": "BAD", "startMonth": 0}]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.LoadFile(bad); err == nil {
+		t.Error("LoadFile() of an invalid calendar succeeded")
+	}
+	if _, err := registry.Lookup("GOOD"); err == nil {
+		t.Error("LoadFile() added calendars from a file with an invalid one")
+	}
+	if err := registry.LoadFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadFile() of a missing file succeeded")
+	}
+}
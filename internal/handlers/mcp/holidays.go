@@ -0,0 +1,581 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Weekend is the set of days of the week that are not working days.
+type Weekend uint8
+
+// DefaultWeekend is Saturday and Sunday.
+var DefaultWeekend = NewWeekend(time.Saturday, time.Sunday)
+
+// wholeWeek is every day of the week, which no weekend may be: there would
+// be no working day to find.
+const wholeWeek Weekend = 1<<7 - 1
+
+// NewWeekend returns a Weekend made of the given days.
+func NewWeekend(days ...time.Weekday) Weekend {
+	var w Weekend
+	for _, d := range days {
+		w |= 1 << d
+	}
+	return w
+}
+
+// ParseWeekend parses a comma-separated list of day names or abbreviations
+// such as "Friday,Saturday".  "none" is a seven-day working week and an
+// empty string yields def.  A weekend must leave at least one working day.
+func ParseWeekend(s string, def Weekend) (Weekend, error) {
+	normalized := strings.ToLower(strings.TrimSpace(s))
+	switch normalized {
+	case "":
+		return def, nil
+	case "none":
+		return 0, nil
+	}
+	var w Weekend
+	for _, name := range strings.FieldsFunc(normalized, func(r rune) bool { return r == ',' || r == ' ' || r == '/' }) {
+		d, ok := lookupWeekday(name)
+		if !ok {
+			return 0, NewInvalidWeekendError(s)
+		}
+		w |= NewWeekend(d)
+	}
+	if w == 0 || w == wholeWeek {
+		return 0, NewInvalidWeekendError(s)
+	}
+	return w, nil
+}
+
+// Contains reports whether d is a weekend day.
+func (w Weekend) Contains(d time.Weekday) bool {
+	return w&(1<<d) != 0
+}
+
+// Days lists the weekend days starting from Monday.
+func (w Weekend) Days() []string {
+	var days []string
+	for i := 1; i <= 7; i++ {
+		if d := time.Weekday(i % 7); w.Contains(d) {
+			days = append(days, d.String())
+		}
+	}
+	return days
+}
+
+func (w Weekend) String() string {
+	if w == 0 {
+		return "none"
+	}
+	return strings.Join(w.Days(), ", ")
+}
+
+// Holiday is a single non-working day in a holiday calendar.  Dates are
+// calendar dates held as midnight UTC.
+type Holiday struct {
+	Name string
+	// Date is the day the holiday is observed as a non-working day.
+	Date time.Time
+	// Actual is the day the holiday falls on before any shift for a weekend.
+	Actual time.Time
+}
+
+// Shifted reports whether the holiday is observed on a different day than it
+// falls on.
+func (h Holiday) Shifted() bool {
+	return !h.Date.Equal(h.Actual)
+}
+
+// HolidayCalendar describes the public holidays of a region or organisation.
+type HolidayCalendar interface {
+	// ID is the short, case-insensitive name used to select the calendar.
+	ID() string
+	// Name is a human-readable description of the calendar.
+	Name() string
+	// Weekend is the calendar's usual weekend.
+	Weekend() Weekend
+	// Holidays returns the holidays observed in year, sorted by date.
+	Holidays(year int) []Holiday
+}
+
+// ObservedPolicy selects how a holiday that falls on a weekend is moved.
+type ObservedPolicy string
+
+const (
+	// ObservedNone leaves the holiday on the weekend.
+	ObservedNone ObservedPolicy = ""
+	// ObservedNearest moves a holiday on the first weekend day back to the
+	// preceding working day and one on a later weekend day forward to the
+	// following working day, e.g. US federal holidays.
+	ObservedNearest ObservedPolicy = "nearest"
+	// ObservedSubstitute moves the holiday forward to the next working day
+	// that is not already a holiday, e.g. UK bank holidays.
+	ObservedSubstitute ObservedPolicy = "substitute"
+)
+
+// Computus selects how Easter Sunday is calculated.
+type Computus string
+
+const (
+	// ComputusWestern is the Gregorian Easter used by Western churches.
+	ComputusWestern Computus = "western"
+	// ComputusOrthodox is the Julian Easter used by Orthodox churches,
+	// expressed as a Gregorian date.
+	ComputusOrthodox Computus = "orthodox"
+)
+
+// HolidayRule computes the date of one holiday in a given year.  Exactly one
+// of Date, EasterOffset, Weekday or Day selects the kind of rule:
+//
+//   - Date: a one-off holiday
+//   - EasterOffset: a number of days from Easter Sunday, e.g. -2 for Good Friday
+//   - Weekday and Nth: the nth, or with -1 the last, weekday of Month
+//   - Day: a fixed day of Month
+type HolidayRule struct {
+	Name string `json:"name"`
+	// Date is a one-off holiday in YYYY-MM-DD form.
+	Date string `json:"date,omitempty"`
+	// EasterOffset places the holiday this many days after Easter Sunday as
+	// calculated by Easter, which defaults to western.
+	EasterOffset *int     `json:"easterOffset,omitempty"`
+	Easter       Computus `json:"easter,omitempty"`
+	Month        int      `json:"month,omitempty"`
+	// Day is a fixed day of Month.
+	Day int `json:"day,omitempty"`
+	// Weekday and Nth select the nth weekday of Month; -1 is the last.
+	Weekday  string         `json:"weekday,omitempty"`
+	Nth      int            `json:"nth,omitempty"`
+	Observed ObservedPolicy `json:"observed,omitempty"`
+	// From and Until bound the years the rule applies to, inclusive.
+	From        int   `json:"from,omitempty"`
+	Until       int   `json:"until,omitempty"`
+	ExceptYears []int `json:"exceptYears,omitempty"`
+}
+
+// validate checks that the rule is well formed.
+func (r HolidayRule) validate() error {
+	kinds := 0
+	if r.Date != "" {
+		kinds++
+		if _, err := time.Parse(dateFormat, r.Date); err != nil {
+			return fmt.Errorf("date must be YYYY-MM-DD")
+		}
+	}
+	if r.EasterOffset != nil {
+		kinds++
+	}
+	if r.Weekday != "" {
+		kinds++
+		if _, ok := lookupWeekday(strings.ToLower(r.Weekday)); !ok {
+			return fmt.Errorf("unknown weekday %q", r.Weekday)
+		}
+		if r.Nth == 0 || r.Nth < -1 || r.Nth > 5 {
+			return fmt.Errorf("nth must be 1 to 5, or -1 for the last")
+		}
+	}
+	if r.Day != 0 {
+		kinds++
+		if r.Day < 1 || r.Day > 31 {
+			return fmt.Errorf("day must be 1 to 31")
+		}
+	}
+	if kinds != 1 {
+		return fmt.Errorf("exactly one of date, easterOffset, weekday or day must be set")
+	}
+	if (r.Weekday != "" || r.Day != 0) && (r.Month < 1 || r.Month > 12) {
+		return fmt.Errorf("month must be 1 to 12")
+	}
+	switch r.Observed {
+	case ObservedNone, ObservedNearest, ObservedSubstitute:
+	default:
+		return fmt.Errorf("observed must be nearest or substitute")
+	}
+	switch r.Easter {
+	case "":
+	case ComputusWestern, ComputusOrthodox:
+		if r.EasterOffset == nil {
+			return fmt.Errorf("easter requires easterOffset")
+		}
+	default:
+		return fmt.Errorf("easter must be western or orthodox")
+	}
+	return nil
+}
+
+// date returns the day the rule's holiday falls on in year, if it has one.
+func (r HolidayRule) date(year int) (time.Time, bool) {
+	if (r.From != 0 && year < r.From) || (r.Until != 0 && year > r.Until) {
+		return time.Time{}, false
+	}
+	for _, y := range r.ExceptYears {
+		if y == year {
+			return time.Time{}, false
+		}
+	}
+
+	switch {
+	case r.Date != "":
+		d, err := time.Parse(dateFormat, r.Date)
+		return d, err == nil && d.Year() == year
+	case r.EasterOffset != nil:
+		easter := westernEaster(year)
+		if r.Easter == ComputusOrthodox {
+			easter = orthodoxEaster(year)
+		}
+		return easter.AddDate(0, 0, *r.EasterOffset), true
+	case r.Weekday != "":
+		weekday, _ := lookupWeekday(strings.ToLower(r.Weekday))
+		return nthWeekdayOfMonth(year, time.Month(r.Month), weekday, r.Nth)
+	default:
+		if r.Day > daysIn(time.Month(r.Month), year) {
+			return time.Time{}, false
+		}
+		return time.Date(year, time.Month(r.Month), r.Day, 0, 0, 0, 0, time.UTC), true
+	}
+}
+
+// nthWeekdayOfMonth returns the nth weekday of a month, counting from the end
+// when nth is negative.
+func nthWeekdayOfMonth(year int, month time.Month, weekday time.Weekday, nth int) (time.Time, bool) {
+	if nth < 0 {
+		last := time.Date(year, month, daysIn(month, year), 0, 0, 0, 0, time.UTC)
+		back := (int(last.Weekday()) - int(weekday) + 7) % 7
+		d := last.AddDate(0, 0, -back-7*(-nth-1))
+		return d, d.Month() == month
+	}
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	ahead := (int(weekday) - int(first.Weekday()) + 7) % 7
+	d := first.AddDate(0, 0, ahead+7*(nth-1))
+	return d, d.Month() == month
+}
+
+// westernEaster returns Easter Sunday in the Gregorian calendar using the
+// anonymous Gregorian algorithm.
+func westernEaster(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// orthodoxEaster returns Orthodox Easter Sunday, computed in the Julian
+// calendar with Meeus' algorithm and converted to a Gregorian date.
+func orthodoxEaster(year int) time.Time {
+	a, b, c := year%4, year%7, year%19
+	d := (19*c + 15) % 30
+	e := (2*a + 4*b - d + 34) % 7
+	month := (d + e + 114) / 31
+	day := (d+e+114)%31 + 1
+	// The Julian calendar falls behind by a day in each century year not
+	// divisible by 400; Easter is always after the February those skip.
+	drift := year/100 - year/400 - 2
+	return time.Date(year, time.Month(month), day+drift, 0, 0, 0, 0, time.UTC)
+}
+
+// RuleCalendar is a HolidayCalendar computed from HolidayRules.
+type RuleCalendar struct {
+	id      string
+	name    string
+	weekend Weekend
+	rules   []HolidayRule
+}
+
+// NewRuleCalendar returns a calendar after checking its weekend and rules.
+func NewRuleCalendar(id, name string, weekend Weekend, rules []HolidayRule) (*RuleCalendar, error) {
+	if weekend&wholeWeek == wholeWeek {
+		return nil, NewInvalidWeekendError(weekend.String())
+	}
+	for _, r := range rules {
+		if err := r.validate(); err != nil {
+			return nil, NewInvalidHolidayRuleError(id, r.Name, err.Error())
+		}
+	}
+	return &RuleCalendar{id: id, name: name, weekend: weekend, rules: rules}, nil
+}
+
+func (c *RuleCalendar) ID() string       { return c.id }
+func (c *RuleCalendar) Name() string     { return c.name }
+func (c *RuleCalendar) Weekend() Weekend { return c.weekend }
+
+// Holidays returns the holidays observed in year.  A holiday may be observed
+// in a neighbouring year, e.g. a Saturday New Year's Day observed on the
+// preceding December 31st.
+func (c *RuleCalendar) Holidays(year int) []Holiday {
+	var holidays []Holiday
+	for y := year - 1; y <= year+1; y++ {
+		for _, h := range c.observe(y) {
+			if h.Date.Year() == year {
+				holidays = append(holidays, h)
+			}
+		}
+	}
+	sort.SliceStable(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+	return holidays
+}
+
+// observe evaluates every rule for year and moves holidays that fall on a
+// weekend according to their observed policy.
+func (c *RuleCalendar) observe(year int) []Holiday {
+	var holidays, moving []Holiday
+	var policies []ObservedPolicy
+	occupied := map[time.Time]bool{}
+	for _, r := range c.rules {
+		d, ok := r.date(year)
+		if !ok {
+			continue
+		}
+		h := Holiday{Name: r.Name, Date: d, Actual: d}
+		if r.Observed != ObservedNone && c.weekend.Contains(d.Weekday()) {
+			moving = append(moving, h)
+			policies = append(policies, r.Observed)
+			continue
+		}
+		holidays = append(holidays, h)
+		occupied[d] = true
+	}
+
+	// Holidays are moved in date order so that, for example, Christmas Day
+	// takes the first substitute day and Boxing Day the next.
+	order := make([]int, len(moving))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return moving[order[i]].Actual.Before(moving[order[j]].Actual) })
+	for _, i := range order {
+		h := moving[i]
+		switch policies[i] {
+		case ObservedNearest:
+			step := 1
+			if !c.weekend.Contains(h.Actual.AddDate(0, 0, -1).Weekday()) {
+				step = -1
+			}
+			for c.weekend.Contains(h.Date.Weekday()) {
+				h.Date = h.Date.AddDate(0, 0, step)
+			}
+		case ObservedSubstitute:
+			for c.weekend.Contains(h.Date.Weekday()) || occupied[h.Date] {
+				h.Date = h.Date.AddDate(0, 0, 1)
+			}
+		}
+		holidays = append(holidays, h)
+		occupied[h.Date] = true
+	}
+	return holidays
+}
+
+// HolidayRegistry holds the holiday calendars available to the tools, keyed
+// by case-insensitive ID.
+type HolidayRegistry struct {
+	mu        sync.RWMutex
+	calendars map[string]HolidayCalendar
+}
+
+// NewHolidayRegistry returns a registry holding the built-in calendars.
+func NewHolidayRegistry() *HolidayRegistry {
+	r := &HolidayRegistry{calendars: map[string]HolidayCalendar{}}
+	for _, c := range builtinHolidayCalendars() {
+		r.Add(c)
+	}
+	return r
+}
+
+// Add registers c, replacing any calendar with the same ID.
+func (r *HolidayRegistry) Add(c HolidayCalendar) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calendars[strings.ToUpper(c.ID())] = c
+}
+
+// Lookup returns the calendar with the given ID.
+func (r *HolidayRegistry) Lookup(id string) (HolidayCalendar, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if c, ok := r.calendars[strings.ToUpper(strings.TrimSpace(id))]; ok {
+		return c, nil
+	}
+	return nil, NewUnknownHolidayCalendarError(id, r.idsLocked())
+}
+
+// IDs lists the registered calendar IDs in sorted order.
+func (r *HolidayRegistry) IDs() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.idsLocked()
+}
+
+func (r *HolidayRegistry) idsLocked() []string {
+	ids := make([]string, 0, len(r.calendars))
+	for _, c := range r.calendars {
+		ids = append(ids, c.ID())
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// holidayCalendarFile is one calendar in a file read by LoadFile.
+type holidayCalendarFile struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Extends names a registered calendar whose rules are included first.
+	Extends  string        `json:"extends,omitempty"`
+	Weekend  []string      `json:"weekend,omitempty"`
+	Holidays []HolidayRule `json:"holidays"`
+}
+
+// LoadFile adds the calendars described in a JSON file holding an array of
+// objects such as:
+//
+//	{"id": "ACME", "name": "ACME Corp", "extends": "US", "weekend": ["Saturday", "Sunday"],
+//	 "holidays": [{"name": "Founders Day", "month": 3, "day": 14, "observed": "nearest"}]}
+func (r *HolidayRegistry) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return NewHolidayCalendarLoadError(path, err)
+	}
+	var files []holidayCalendarFile
+	if err := json.Unmarshal(data, &files); err != nil {
+		return NewHolidayCalendarLoadError(path, err)
+	}
+
+	for _, f := range files {
+		if f.ID == "" {
+			return NewHolidayCalendarLoadError(path, fmt.Errorf("every calendar needs an id"))
+		}
+		name := f.Name
+		if name == "" {
+			name = f.ID
+		}
+		weekend := DefaultWeekend
+		var rules []HolidayRule
+		if f.Extends != "" {
+			base, err := r.Lookup(f.Extends)
+			if err != nil {
+				return NewHolidayCalendarLoadError(path, err)
+			}
+			weekend = base.Weekend()
+			ruled, ok := base.(*RuleCalendar)
+			if !ok {
+				return NewHolidayCalendarLoadError(path, fmt.Errorf("calendar %q cannot be extended", f.Extends))
+			}
+			rules = append(rules, ruled.rules...)
+		}
+		if len(f.Weekend) > 0 {
+			weekend, err = ParseWeekend(strings.Join(f.Weekend, ","), DefaultWeekend)
+			if err != nil {
+				return NewHolidayCalendarLoadError(path, err)
+			}
+		}
+		c, err := NewRuleCalendar(f.ID, name, weekend, append(rules, f.Holidays...))
+		if err != nil {
+			return NewHolidayCalendarLoadError(path, err)
+		}
+		r.Add(c)
+	}
+	return nil
+}
+
+// builtinHolidayCalendars returns the calendars compiled into the server.
+func builtinHolidayCalendars() []HolidayCalendar {
+	easter := func(offset int) *int { return &offset }
+	calendars := []struct {
+		id, name string
+		rules    []HolidayRule
+	}{
+		{
+			id:   "US",
+			name: "United States federal holidays",
+			rules: []HolidayRule{
+				{Name: "New Year's Day", Month: 1, Day: 1, Observed: ObservedNearest},
+				{Name: "Birthday of Martin Luther King, Jr.", Month: 1, Weekday: "Monday", Nth: 3, From: 1986},
+				{Name: "Washington's Birthday", Month: 2, Weekday: "Monday", Nth: 3},
+				{Name: "Memorial Day", Month: 5, Weekday: "Monday", Nth: -1},
+				{Name: "Juneteenth National Independence Day", Month: 6, Day: 19, Observed: ObservedNearest, From: 2021},
+				{Name: "Independence Day", Month: 7, Day: 4, Observed: ObservedNearest},
+				{Name: "Labor Day", Month: 9, Weekday: "Monday", Nth: 1},
+				{Name: "Columbus Day", Month: 10, Weekday: "Monday", Nth: 2},
+				{Name: "Veterans Day", Month: 11, Day: 11, Observed: ObservedNearest},
+				{Name: "Thanksgiving Day", Month: 11, Weekday: "Thursday", Nth: 4},
+				{Name: "Christmas Day", Month: 12, Day: 25, Observed: ObservedNearest},
+			},
+		},
+		{
+			id:   "UK",
+			name: "United Kingdom bank holidays (England and Wales)",
+			rules: []HolidayRule{
+				{Name: "New Year's Day", Month: 1, Day: 1, Observed: ObservedSubstitute},
+				{Name: "Good Friday", EasterOffset: easter(-2)},
+				{Name: "Easter Monday", EasterOffset: easter(1)},
+				{Name: "Early May bank holiday", Month: 5, Weekday: "Monday", Nth: 1, ExceptYears: []int{1995, 2020}},
+				{Name: "Early May bank holiday (VE Day)", Date: "1995-05-08"},
+				{Name: "Early May bank holiday (VE Day)", Date: "2020-05-08"},
+				{Name: "Spring bank holiday", Month: 5, Weekday: "Monday", Nth: -1, ExceptYears: []int{2002, 2012, 2022}},
+				{Name: "Spring bank holiday", Date: "2002-06-04"},
+				{Name: "Golden Jubilee bank holiday", Date: "2002-06-03"},
+				{Name: "Spring bank holiday", Date: "2012-06-04"},
+				{Name: "Diamond Jubilee bank holiday", Date: "2012-06-05"},
+				{Name: "Spring bank holiday", Date: "2022-06-02"},
+				{Name: "Platinum Jubilee bank holiday", Date: "2022-06-03"},
+				{Name: "Bank holiday for the State Funeral of Queen Elizabeth II", Date: "2022-09-19"},
+				{Name: "Bank holiday for the coronation of King Charles III", Date: "2023-05-08"},
+				{Name: "Summer bank holiday", Month: 8, Weekday: "Monday", Nth: -1},
+				{Name: "Christmas Day", Month: 12, Day: 25, Observed: ObservedSubstitute},
+				{Name: "Boxing Day", Month: 12, Day: 26, Observed: ObservedSubstitute},
+			},
+		},
+		{
+			id:   "GR",
+			name: "Greece public holidays",
+			rules: []HolidayRule{
+				{Name: "New Year's Day", Month: 1, Day: 1},
+				{Name: "Epiphany", Month: 1, Day: 6},
+				{Name: "Clean Monday", EasterOffset: easter(-48), Easter: ComputusOrthodox},
+				{Name: "Independence Day", Month: 3, Day: 25},
+				{Name: "Orthodox Good Friday", EasterOffset: easter(-2), Easter: ComputusOrthodox},
+				{Name: "Orthodox Easter Monday", EasterOffset: easter(1), Easter: ComputusOrthodox},
+				{Name: "Labour Day", Month: 5, Day: 1},
+				{Name: "Whit Monday", EasterOffset: easter(50), Easter: ComputusOrthodox},
+				{Name: "Assumption of Mary", Month: 8, Day: 15},
+				{Name: "Ochi Day", Month: 10, Day: 28},
+				{Name: "Christmas Day", Month: 12, Day: 25},
+				{Name: "Synaxis of the Mother of God", Month: 12, Day: 26},
+			},
+		},
+		{
+			id:   "TARGET2",
+			name: "TARGET2 (euro area payment system) closing days",
+			rules: []HolidayRule{
+				{Name: "New Year's Day", Month: 1, Day: 1},
+				{Name: "Good Friday", EasterOffset: easter(-2)},
+				{Name: "Easter Monday", EasterOffset: easter(1)},
+				{Name: "Labour Day", Month: 5, Day: 1},
+				{Name: "Christmas Day", Month: 12, Day: 25},
+				{Name: "Christmas Holiday", Month: 12, Day: 26},
+			},
+		},
+	}
+
+	var out []HolidayCalendar
+	for _, c := range calendars {
+		rc, err := NewRuleCalendar(c.id, c.name, DefaultWeekend, c.rules)
+		if err != nil {
+			panic(err)
+		}
+		out = append(out, rc)
+	}
+	return out
+}
@@ -0,0 +1,230 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWesternEaster(t *testing.T) {
+	testCases := []struct {
+		year int
+		want string
+	}{
+		{1961, "1961-04-02"},
+		{2000, "2000-04-23"},
+		{2019, "2019-04-21"},
+		{2024, "2024-03-31"},
+		{2025, "2025-04-20"},
+		{2038, "2038-04-25"},
+	}
+	for _, tc := range testCases {
+		if got := westernEaster(tc.year).Format(dateFormat); got != tc.want {
+			t.Errorf("westernEaster(%d) = %s, want %s", tc.year, got, tc.want)
+		}
+	}
+}
+
+func TestOrthodoxEaster(t *testing.T) {
+	testCases := []struct {
+		year int
+		want string
+	}{
+		{2000, "2000-04-30"},
+		{2021, "2021-05-02"},
+		{2023, "2023-04-16"},
+		{2024, "2024-05-05"},
+		{2025, "2025-04-20"},
+		{2100, "2100-05-02"},
+	}
+	for _, tc := range testCases {
+		if got := orthodoxEaster(tc.year).Format(dateFormat); got != tc.want {
+			t.Errorf("orthodoxEaster(%d) = %s, want %s", tc.year, got, tc.want)
+		}
+	}
+}
+
+func TestNthWeekdayOfMonth(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		year    int
+		month   time.Month
+		weekday time.Weekday
+		nth     int
+		want    string
+	}{
+		{desc: "Fourth Thursday of November", year: 2024, month: time.November, weekday: time.Thursday, nth: 4, want: "2024-11-28"},
+		{desc: "First Monday when the month starts on a Monday", year: 2024, month: time.January, weekday: time.Monday, nth: 1, want: "2024-01-01"},
+		{desc: "Last Monday of May", year: 2024, month: time.May, weekday: time.Monday, nth: -1, want: "2024-05-27"},
+		{desc: "Last Saturday when the month ends on a Saturday", year: 2024, month: time.August, weekday: time.Saturday, nth: -1, want: "2024-08-31"},
+		{desc: "No fifth Monday", year: 2024, month: time.February, weekday: time.Monday, nth: 5, want: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, ok := nthWeekdayOfMonth(tc.year, tc.month, tc.weekday, tc.nth)
+			if tc.want == "" {
+				if ok {
+					t.Errorf("nthWeekdayOfMonth() = %s, want none", got.Format(dateFormat))
+				}
+				return
+			}
+			if !ok || got.Format(dateFormat) != tc.want {
+				t.Errorf("nthWeekdayOfMonth() = %s, %v, want %s", got.Format(dateFormat), ok, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseWeekend(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		input   string
+		want    Weekend
+		wantErr bool
+	}{
+		{desc: "Default", input: "", want: DefaultWeekend},
+		{desc: "Friday and Saturday", input: "Friday,Saturday", want: NewWeekend(time.Friday, time.Saturday)},
+		{desc: "Abbreviations separated by spaces", input: "fri sat", want: NewWeekend(time.Friday, time.Saturday)},
+		{desc: "Single day", input: "sunday", want: NewWeekend(time.Sunday)},
+		{desc: "No weekend", input: "none", want: 0},
+		{desc: "Unknown day", input: "Funday", wantErr: true},
+		{desc: "Every day", input: "mon,tue,wed,thu,fri,sat,sun", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := ParseWeekend(tc.input, DefaultWeekend)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseWeekend() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ParseWeekend() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBuiltinHolidayCalendars(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		calendar string
+		year     int
+		name     string
+		want     string
+		// wantActual is set when the holiday is observed on another day.
+		wantActual string
+	}{
+		{desc: "US Thanksgiving", calendar: "US", year: 2024, name: "Thanksgiving Day", want: "2024-11-28"},
+		{desc: "US Saturday holiday observed on Friday", calendar: "US", year: 2026, name: "Independence Day", want: "2026-07-03", wantActual: "2026-07-04"},
+		{desc: "US Sunday holiday observed on Monday", calendar: "US", year: 2022, name: "Juneteenth National Independence Day", want: "2022-06-20", wantActual: "2022-06-19"},
+		{desc: "US New Year's Day observed in the previous year", calendar: "US", year: 2021, name: "New Year's Day", want: "2021-12-31", wantActual: "2022-01-01"},
+		{desc: "UK Good Friday", calendar: "UK", year: 2025, name: "Good Friday", want: "2025-04-18"},
+		{desc: "UK Christmas Day substitute", calendar: "UK", year: 2021, name: "Christmas Day", want: "2021-12-27", wantActual: "2021-12-25"},
+		{desc: "UK Boxing Day takes the next substitute day", calendar: "UK", year: 2021, name: "Boxing Day", want: "2021-12-28", wantActual: "2021-12-26"},
+		{desc: "UK Christmas Day on a Sunday", calendar: "UK", year: 2022, name: "Christmas Day", want: "2022-12-27", wantActual: "2022-12-25"},
+		{desc: "UK moved spring bank holiday", calendar: "UK", year: 2022, name: "Spring bank holiday", want: "2022-06-02"},
+		{desc: "GR Clean Monday from Orthodox Easter", calendar: "GR", year: 2024, name: "Clean Monday", want: "2024-03-18"},
+		{desc: "GR Whit Monday from Orthodox Easter", calendar: "GR", year: 2024, name: "Whit Monday", want: "2024-06-24"},
+		{desc: "TARGET2 Easter Monday", calendar: "TARGET2", year: 2024, name: "Easter Monday", want: "2024-04-01"},
+		{desc: "TARGET2 holidays are not moved", calendar: "TARGET2", year: 2021, name: "Christmas Day", want: "2021-12-25"},
+	}
+
+	registry := NewHolidayRegistry()
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			c, err := registry.Lookup(tc.calendar)
+			if err != nil {
+				t.Fatalf("Lookup() error = %v", err)
+			}
+			var found *Holiday
+			holidays := c.Holidays(tc.year)
+			for i, h := range holidays {
+				if h.Name == tc.name && h.Date.Format(dateFormat) == tc.want {
+					found = &holidays[i]
+				}
+			}
+			if found == nil {
+				t.Fatalf("Holidays(%d) = %+v, want %s on %s", tc.year, holidays, tc.name, tc.want)
+			}
+			wantActual := tc.wantActual
+			if wantActual == "" {
+				wantActual = tc.want
+			}
+			if got := found.Actual.Format(dateFormat); got != wantActual {
+				t.Errorf("%s falls on %s, want %s", tc.name, got, wantActual)
+			}
+		})
+	}
+
+	t.Run("UK 2022 has no last Monday of May holiday", func(t *testing.T) {
+		c, _ := registry.Lookup("uk")
+		for _, h := range c.Holidays(2022) {
+			if h.Date.Format(dateFormat) == "2022-05-30" {
+				t.Errorf("unexpected holiday %+v", h)
+			}
+		}
+	})
+}
+
+func TestHolidayRegistryLoadFile(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		content string
+		wantErr bool
+		// check is a date expected to be a holiday in calendar ACME.
+		check string
+	}{
+		{
+			desc:    "Extends a built-in calendar",
+			content: `[{"id": "ACME", "name": "ACME Corp", "extends": "US", "holidays": [{"name": "Founders Day", "month": 3, "day": 14, "observed": "nearest"}]}]`,
+			check:   "2024-11-28",
+		},
+		{
+			desc:    "Custom weekend and rules",
+			content: `[{"id": "ACME", "weekend": ["Friday", "Saturday"], "holidays": [{"name": "Founders Day", "month": 3, "day": 14}, {"name": "Office move", "date": "2024-06-30"}, {"name": "Spring Monday", "easterOffset": 1}]}]`,
+			check:   "2024-06-30",
+		},
+		{
+			desc:    "Orthodox Easter rule",
+			content: `[{"id": "ACME", "holidays": [{"name": "Orthodox Easter Monday", "easterOffset": 1, "easter": "orthodox"}]}]`,
+			check:   "2024-05-06",
+		},
+		{desc: "Invalid JSON", content: `{`, wantErr: true},
+		{desc: "Unknown computus", content: `[{"id": "ACME", "holidays": [{"name": "Bad", "easterOffset": 1, "easter": "lunar"}]}]`, wantErr: true},
+		{desc: "Missing id", content: `[{"holidays": []}]`, wantErr: true},
+		{desc: "Rule with two kinds", content: `[{"id": "ACME", "holidays": [{"name": "Bad", "month": 1, "day": 1, "weekday": "Monday", "nth": 1}]}]`, wantErr: true},
+		{desc: "Unknown base calendar", content: `[{"id": "ACME", "extends": "Atlantis", "holidays": []}]`, wantErr: true},
+		{desc: "Invalid weekend", content: `[{"id": "ACME", "weekend": ["Caturday"], "holidays": []}]`, wantErr: true},
+		{desc: "Weekend of every day", content: `[{"id": "ACME", "weekend": ["Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"], "holidays": [{"name": "Founders Day", "month": 3, "day": 14, "observed": "nearest"}]}]`, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "calendars.json")
+			if err := os.WriteFile(path, []byte(tc.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			registry := NewHolidayRegistry()
+			err := registry.LoadFile(path)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("LoadFile() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			c, err := registry.Lookup("acme")
+			if err != nil {
+				t.Fatalf("Lookup() error = %v", err)
+			}
+			d, _ := time.Parse(dateFormat, tc.check)
+			if _, ok := newBusinessCalendar(c, c.Weekend()).holiday(d); !ok {
+				t.Errorf("%s is not a holiday in %+v", tc.check, c.Holidays(d.Year()))
+			}
+		})
+	}
+
+	t.Run("Missing file", func(t *testing.T) {
+		if err := NewHolidayRegistry().LoadFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+			t.Error("LoadFile() expected error")
+		}
+	})
+}
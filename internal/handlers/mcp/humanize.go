@@ -0,0 +1,199 @@
+package mcp
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OutputStyle selects how TimeSince, TimeUntil and TimeDifference present a
+// duration.
+type OutputStyle string
+
+const (
+	// OutputStyleRaw is Go's duration format, e.g. "8760h0m0s".
+	OutputStyleRaw OutputStyle = "raw"
+	// OutputStyleCalendar is a calendar decomposition, e.g. "1 year, 2 months".
+	OutputStyleCalendar OutputStyle = "calendar"
+	// OutputStyleTotals lists the duration in days, weeks, hours, minutes and
+	// seconds.
+	OutputStyleTotals OutputStyle = "totals"
+	// OutputStyleApproximate is a short phrase, e.g. "about 14 months".
+	OutputStyleApproximate OutputStyle = "approximate"
+	// OutputStyleAll combines the calendar, totals and approximate styles.
+	OutputStyleAll OutputStyle = "all"
+)
+
+// DefaultOutputStyle is used when no style is requested.
+const DefaultOutputStyle = OutputStyleRaw
+
+// ParseOutputStyle maps a string to an OutputStyle.  An empty string yields
+// the default style.
+func ParseOutputStyle(s string) (OutputStyle, error) {
+	switch style := OutputStyle(strings.ToLower(strings.TrimSpace(s))); style {
+	case "":
+		return DefaultOutputStyle, nil
+	case OutputStyleRaw, OutputStyleCalendar, OutputStyleTotals, OutputStyleApproximate, OutputStyleAll:
+		return style, nil
+	default:
+		return "", NewInvalidOutputStyleError(s)
+	}
+}
+
+// formatDuration describes the span from start to end, which must not be
+// before start, in the given style.  Calendar components are counted on the
+// wall clock of start's location.  suffix (e.g. "ago") is appended to the
+// approximate phrase.
+func formatDuration(start, end time.Time, style OutputStyle, suffix string) string {
+	approximate := approximateDuration(end.Sub(start))
+	if suffix != "" {
+		approximate += " " + suffix
+	}
+	switch style {
+	case OutputStyleCalendar:
+		return calendarBreakdown(start, end).phrase()
+	case OutputStyleTotals:
+		return durationTotals(end.Sub(start))
+	case OutputStyleApproximate:
+		return approximate
+	case OutputStyleAll:
+		return fmt.Sprintf("%s (%s; %s; exact: %s)", calendarBreakdown(start, end).phrase(), approximate, durationTotals(end.Sub(start)), end.Sub(start))
+	default:
+		return end.Sub(start).String()
+	}
+}
+
+// calendarSpan is a span broken down into calendar and clock components.
+type calendarSpan struct {
+	Years   int `json:"years"`
+	Months  int `json:"months"`
+	Days    int `json:"days"`
+	Hours   int `json:"hours"`
+	Minutes int `json:"minutes"`
+	Seconds int `json:"seconds"`
+}
+
+// calendarBreakdown counts whole years, months and days from start towards
+// end on start's wall clock, then splits the remainder into clock units.
+func calendarBreakdown(start, end time.Time) calendarSpan {
+	end = end.In(start.Location())
+
+	months := (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month())
+	cursor := addCalendar(start, 0, months, 0)
+	for months > 0 && cursor.After(end) {
+		months--
+		cursor = addCalendar(start, 0, months, 0)
+	}
+
+	days := int(end.Sub(cursor).Hours() / 24)
+	for days > 0 && addCalendar(cursor, 0, 0, days).After(end) {
+		days--
+	}
+	for !addCalendar(cursor, 0, 0, days+1).After(end) {
+		days++
+	}
+	rest := end.Sub(addCalendar(cursor, 0, 0, days))
+
+	return calendarSpan{
+		Years:   months / 12,
+		Months:  months % 12,
+		Days:    days,
+		Hours:   int(rest / time.Hour),
+		Minutes: int(rest % time.Hour / time.Minute),
+		Seconds: int(rest % time.Minute / time.Second),
+	}
+}
+
+// addCalendar moves t by whole calendar units, clamping at month ends.
+func addCalendar(t time.Time, years, months, days int) time.Time {
+	shifted, err := CalendarDuration{Years: years, Months: months, Days: days}.AddTo(t, MonthEndClamp, DSTPolicyEarlier)
+	if err != nil {
+		return t.AddDate(years, months, days)
+	}
+	return shifted
+}
+
+// duration converts c to a CalendarDuration.
+func (c calendarSpan) duration() CalendarDuration {
+	return CalendarDuration{
+		Years:  c.Years,
+		Months: c.Months,
+		Days:   c.Days,
+		Clock:  time.Duration(c.Hours)*time.Hour + time.Duration(c.Minutes)*time.Minute + time.Duration(c.Seconds)*time.Second,
+	}
+}
+
+func (c calendarSpan) phrase() string {
+	var parts []string
+	for _, p := range []struct {
+		n    int
+		unit string
+	}{
+		{c.Years, "year"}, {c.Months, "month"}, {c.Days, "day"},
+		{c.Hours, "hour"}, {c.Minutes, "minute"}, {c.Seconds, "second"},
+	} {
+		if p.n != 0 {
+			parts = append(parts, plural(p.n, p.unit))
+		}
+	}
+	if len(parts) == 0 {
+		return "0 seconds"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// durationTotals expresses d in each unit, e.g. "1.5 days, 0.21 weeks,
+// 36 hours, 2160 minutes, 129600 seconds".
+func durationTotals(d time.Duration) string {
+	return fmt.Sprintf("%s days, %s weeks, %s hours, %s minutes, %s seconds",
+		formatAmount(d.Hours()/24), formatAmount(d.Hours()/(24*7)), formatAmount(d.Hours()),
+		formatAmount(d.Minutes()), formatAmount(d.Seconds()))
+}
+
+// approximateDuration describes d in a short phrase such as "about 3 hours".
+func approximateDuration(d time.Duration) string {
+	const day = 24 * time.Hour
+	const month = time.Duration(30.436875 * float64(day))
+	const year = 12 * month
+
+	switch {
+	case d < 45*time.Second:
+		return "a few seconds"
+	case d < 90*time.Second:
+		return "about a minute"
+	case d < 45*time.Minute:
+		return plural(roundTo(d, time.Minute), "minute")
+	case d < 90*time.Minute:
+		return "about an hour"
+	case d < 22*time.Hour:
+		return "about " + plural(roundTo(d, time.Hour), "hour")
+	case d < 36*time.Hour:
+		return "about a day"
+	case d < 26*day:
+		return "about " + plural(roundTo(d, day), "day")
+	case d < 45*day:
+		return "about a month"
+	case d < 18*month:
+		return "about " + plural(roundTo(d, month), "month")
+	default:
+		return "about " + plural(roundTo(d, year), "year")
+	}
+}
+
+func roundTo(d, unit time.Duration) int {
+	return int(math.Round(float64(d) / float64(unit)))
+}
+
+func plural(n int, unit string) string {
+	if n == 1 || n == -1 {
+		return fmt.Sprintf("%d %s", n, unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// formatAmount formats f with at most two decimal places.
+func formatAmount(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,113 @@
+package mcp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDuration(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		desc   string
+		start  time.Time
+		end    time.Time
+		style  OutputStyle
+		suffix string
+		want   string
+	}{
+		{
+			desc:  "Raw",
+			start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			end:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			style: OutputStyleRaw,
+			want:  "8760h0m0s",
+		},
+		{
+			desc:  "Calendar",
+			start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			end:   time.Date(2024, 3, 4, 4, 0, 5, 0, time.UTC),
+			style: OutputStyleCalendar,
+			want:  "1 year, 2 months, 3 days, 4 hours, 5 seconds",
+		},
+		{
+			desc:  "Calendar from the end of a month",
+			start: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			end:   time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			style: OutputStyleCalendar,
+			want:  "1 month, 1 day",
+		},
+		{
+			desc:  "Calendar days across DST",
+			start: time.Date(2024, 3, 9, 12, 0, 0, 0, newYork),
+			end:   time.Date(2024, 3, 11, 12, 0, 0, 0, newYork),
+			style: OutputStyleCalendar,
+			want:  "2 days",
+		},
+		{
+			desc:  "Calendar zero",
+			start: time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC),
+			end:   time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC),
+			style: OutputStyleCalendar,
+			want:  "0 seconds",
+		},
+		{
+			desc:  "Totals",
+			start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			end:   time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC),
+			style: OutputStyleTotals,
+			want:  "1.5 days, 0.21 weeks, 36 hours, 2160 minutes, 129600 seconds",
+		},
+		{
+			desc:   "Approximate",
+			start:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			end:    time.Date(2024, 1, 1, 3, 10, 0, 0, time.UTC),
+			style:  OutputStyleApproximate,
+			suffix: "from now",
+			want:   "about 3 hours from now",
+		},
+		{
+			desc:  "All",
+			start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			end:   time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC),
+			style: OutputStyleAll,
+			want:  "1 day, 12 hours (about 2 days; 1.5 days, 0.21 weeks, 36 hours, 2160 minutes, 129600 seconds; exact: 36h0m0s)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := formatDuration(tc.start, tc.end, tc.style, tc.suffix); got != tc.want {
+				t.Errorf("formatDuration() got = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestApproximateDuration(t *testing.T) {
+	testCases := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{10 * time.Second, "a few seconds"},
+		{time.Minute, "about a minute"},
+		{20 * time.Minute, "20 minutes"},
+		{time.Hour, "about an hour"},
+		{30 * time.Hour, "about a day"},
+		{10 * 24 * time.Hour, "about 10 days"},
+		{30 * 24 * time.Hour, "about a month"},
+		{426 * 24 * time.Hour, "about 14 months"},
+		{3 * 365 * 24 * time.Hour, "about 3 years"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.want, func(t *testing.T) {
+			if got := approximateDuration(tc.duration); got != tc.want {
+				t.Errorf("approximateDuration() got = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,404 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// isoDateTime holds the fields of a parsed ISO 8601 / RFC 3339 timestamp.
+// Wall-clock fields are kept separate from the offset so that inputs without
+// an offset can be interpreted in a caller-supplied zone.
+type isoDateTime struct {
+	year   int
+	month  time.Month
+	day    int
+	hour   int
+	minute int
+	second int
+	nsec   int
+
+	// endOfDay is set for the ISO 8601 "24:00" notation, which denotes
+	// midnight at the end of the given day.
+	endOfDay bool
+
+	hasOffset bool
+	offset    int // seconds east of UTC
+	utc       bool
+}
+
+// instant returns the timestamp as a time.Time.  It must only be called
+// when the input carried an explicit offset.
+func (d isoDateTime) instant() time.Time {
+	loc := time.UTC
+	if !d.utc {
+		loc = time.FixedZone("", d.offset)
+	}
+	t := time.Date(d.year, d.month, d.day, d.hour, d.minute, d.second, d.nsec, loc)
+	if d.endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t
+}
+
+// isoGrammar is one alternative of the ISO 8601 date grammar.
+type isoGrammar struct {
+	name  string
+	parse func(sc *isoScanner, d *isoDateTime) bool
+}
+
+// isoDateGrammars lists the supported date forms.  Each may be followed by a
+// time of day and an offset.
+var isoDateGrammars = []isoGrammar{
+	{"calendar date (YYYY-MM-DD)", parseISOCalendarExtended},
+	{"calendar date, basic format (YYYYMMDD)", parseISOCalendarBasic},
+	{"week date (YYYY-Www-D)", parseISOWeekExtended},
+	{"week date, basic format (YYYYWwwD)", parseISOWeekBasic},
+	{"ordinal date (YYYY-DDD)", parseISOOrdinalExtended},
+	{"ordinal date, basic format (YYYYDDD)", parseISOOrdinalBasic},
+	{"year and month (YYYY-MM)", parseISOYearMonth},
+	{"year (YYYY)", parseISOYear},
+}
+
+// parseISO8601 parses an ISO 8601 / RFC 3339 date or date/time.  When no
+// alternative matches, the returned error names the alternative that
+// consumed the most input.
+func parseISO8601(input string) (isoDateTime, error) {
+	s := strings.TrimSpace(input)
+
+	var closest *isoScanner
+	var closestName string
+	for _, g := range isoDateGrammars {
+		sc := &isoScanner{s: s}
+		var d isoDateTime
+		if g.parse(sc, &d) && sc.ok() && parseISOTimeAndOffset(sc, &d) && sc.ok() && sc.done() {
+			return d, nil
+		}
+		if closest == nil || sc.pos > closest.pos {
+			closest = sc
+			closestName = g.name
+		}
+	}
+	return isoDateTime{}, NewISO8601FormatError(input, closestName, closest.pos, closest.reason)
+}
+
+// parseISOTimeAndOffset parses an optional "THH:MM:SS.fff±HH:MM" suffix.
+func parseISOTimeAndOffset(sc *isoScanner, d *isoDateTime) bool {
+	if sc.done() {
+		return true
+	}
+	if !sc.accept("T", "t", " ") {
+		return sc.fail("expected \"T\" or a space before the time of day")
+	}
+
+	extended := sc.peekAt(2) == ':'
+	hour, ok := sc.digits(2, "hour")
+	if !ok {
+		return false
+	}
+	minute, second := 0, 0
+	var num, den int64 = 0, 1
+	// unit is the length of the lowest-order component that was given, which
+	// any decimal fraction applies to.
+	unit := time.Hour
+	if (extended && sc.accept(":")) || (!extended && sc.isDigit()) {
+		if minute, ok = sc.digits(2, "minute"); !ok {
+			return false
+		}
+		unit = time.Minute
+		if (extended && sc.accept(":")) || (!extended && sc.isDigit()) {
+			if second, ok = sc.digits(2, "second"); !ok {
+				return false
+			}
+			unit = time.Second
+		}
+	}
+	if sc.accept(".", ",") {
+		if num, den, ok = sc.fraction(); !ok {
+			return false
+		}
+	}
+
+	if hour > 24 {
+		return sc.fail(fmt.Sprintf("hour %d out of range", hour))
+	}
+	if minute > 59 {
+		return sc.fail(fmt.Sprintf("minute %d out of range", minute))
+	}
+	if second > 60 {
+		return sc.fail(fmt.Sprintf("second %d out of range", second))
+	}
+	if hour == 24 {
+		if minute != 0 || second != 0 || num != 0 {
+			return sc.fail("24:00 must not have non-zero minutes or seconds")
+		}
+		d.endOfDay = true
+		hour = 0
+	}
+
+	extra := time.Duration(num) * (unit / time.Duration(den))
+	base := time.Duration(minute)*time.Minute + time.Duration(second)*time.Second + extra
+	d.hour = hour
+	d.minute = int(base / time.Minute)
+	d.second = int(base % time.Minute / time.Second)
+	d.nsec = int(base % time.Second)
+
+	return parseISOOffset(sc, d)
+}
+
+// parseISOOffset parses an optional "Z", "±HH:MM", "±HHMM" or "±HH" suffix.
+func parseISOOffset(sc *isoScanner, d *isoDateTime) bool {
+	if sc.done() {
+		return true
+	}
+	// Allow the "YYYY-MM-DD HH:MM:SS -0700" form this server emits.
+	if sc.s[sc.pos] == ' ' && strings.ContainsRune("+-Zz", rune(sc.peekAt(1))) {
+		sc.pos++
+	}
+	if sc.accept("Z", "z") {
+		d.hasOffset = true
+		d.utc = true
+		return true
+	}
+	sign := 1
+	switch {
+	case sc.accept("+"):
+	case sc.accept("-"), sc.accept("−"):
+		sign = -1
+	default:
+		return sc.fail("expected \"Z\" or a \"+HH:MM\" offset")
+	}
+	hours, ok := sc.digits(2, "offset hour")
+	if !ok {
+		return false
+	}
+	minutes := 0
+	if sc.accept(":") || sc.isDigit() {
+		if minutes, ok = sc.digits(2, "offset minute"); !ok {
+			return false
+		}
+	}
+	if hours > 23 || minutes > 59 {
+		return sc.fail("offset out of range")
+	}
+	d.hasOffset = true
+	d.offset = sign * (hours*3600 + minutes*60)
+	return true
+}
+
+func parseISOCalendarExtended(sc *isoScanner, d *isoDateTime) bool {
+	return parseISOYearInto(sc, d) && sc.expect("-") &&
+		parseISOMonthInto(sc, d) && sc.expect("-") &&
+		parseISODayInto(sc, d)
+}
+
+func parseISOCalendarBasic(sc *isoScanner, d *isoDateTime) bool {
+	return parseISOYearInto(sc, d) && parseISOMonthInto(sc, d) && parseISODayInto(sc, d)
+}
+
+func parseISOYearMonth(sc *isoScanner, d *isoDateTime) bool {
+	if !(parseISOYearInto(sc, d) && sc.expect("-") && parseISOMonthInto(sc, d)) {
+		return false
+	}
+	d.day = 1
+	return true
+}
+
+func parseISOYear(sc *isoScanner, d *isoDateTime) bool {
+	if !parseISOYearInto(sc, d) {
+		return false
+	}
+	d.month, d.day = time.January, 1
+	return true
+}
+
+func parseISOWeekExtended(sc *isoScanner, d *isoDateTime) bool {
+	return parseISOYearInto(sc, d) && sc.expect("-") && parseISOWeekInto(sc, d, true)
+}
+
+func parseISOWeekBasic(sc *isoScanner, d *isoDateTime) bool {
+	return parseISOYearInto(sc, d) && parseISOWeekInto(sc, d, false)
+}
+
+func parseISOOrdinalExtended(sc *isoScanner, d *isoDateTime) bool {
+	return parseISOYearInto(sc, d) && sc.expect("-") && parseISOOrdinalInto(sc, d)
+}
+
+func parseISOOrdinalBasic(sc *isoScanner, d *isoDateTime) bool {
+	return parseISOYearInto(sc, d) && parseISOOrdinalInto(sc, d)
+}
+
+func parseISOYearInto(sc *isoScanner, d *isoDateTime) bool {
+	year, ok := sc.digits(4, "year")
+	d.year = year
+	return ok
+}
+
+func parseISOMonthInto(sc *isoScanner, d *isoDateTime) bool {
+	month, ok := sc.digits(2, "month")
+	if !ok {
+		return false
+	}
+	if month < 1 || month > 12 {
+		return sc.fail(fmt.Sprintf("month %d out of range", month))
+	}
+	d.month = time.Month(month)
+	return true
+}
+
+func parseISODayInto(sc *isoScanner, d *isoDateTime) bool {
+	day, ok := sc.digits(2, "day")
+	if !ok {
+		return false
+	}
+	if day < 1 || day > daysIn(d.month, d.year) {
+		return sc.fail(fmt.Sprintf("day %d out of range for %s %d", day, d.month, d.year))
+	}
+	d.day = day
+	return true
+}
+
+func parseISOWeekInto(sc *isoScanner, d *isoDateTime, extended bool) bool {
+	if !sc.expect("W") {
+		return false
+	}
+	week, ok := sc.digits(2, "week")
+	if !ok {
+		return false
+	}
+	if week < 1 || week > isoWeeksInYear(d.year) {
+		return sc.fail(fmt.Sprintf("week %d out of range for %d", week, d.year))
+	}
+	weekday := 1
+	if (extended && sc.accept("-")) || (!extended && sc.isDigit()) {
+		if weekday, ok = sc.digits(1, "day of week"); !ok {
+			return false
+		}
+		if weekday < 1 || weekday > 7 {
+			return sc.fail(fmt.Sprintf("day of week %d out of range", weekday))
+		}
+	}
+	t := isoWeekStart(d.year, week).AddDate(0, 0, weekday-1)
+	d.year, d.month, d.day = t.Date()
+	return true
+}
+
+func parseISOOrdinalInto(sc *isoScanner, d *isoDateTime) bool {
+	ordinal, ok := sc.digits(3, "day of year")
+	if !ok {
+		return false
+	}
+	days := 365
+	if isLeap(d.year) {
+		days = 366
+	}
+	if ordinal < 1 || ordinal > days {
+		return sc.fail(fmt.Sprintf("day of year %d out of range for %d", ordinal, d.year))
+	}
+	t := time.Date(d.year, time.January, ordinal, 0, 0, 0, 0, time.UTC)
+	d.year, d.month, d.day = t.Date()
+	return true
+}
+
+// isoWeekStart returns the Monday of the given ISO week.  Week 1 is the week
+// containing January 4th.
+func isoWeekStart(year, week int) time.Time {
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+	return monday.AddDate(0, 0, (week-1)*7)
+}
+
+// isoWeeksInYear returns 52 or 53, the number of ISO weeks in year.
+func isoWeeksInYear(year int) int {
+	_, week := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return week
+}
+
+func isLeap(year int) bool {
+	return (year%4 == 0 && year%100 != 0) || (year%400 == 0)
+}
+
+func daysIn(month time.Month, year int) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// isoScanner is a cursor over the input that remembers why it stopped.
+type isoScanner struct {
+	s      string
+	pos    int
+	reason string
+}
+
+func (sc *isoScanner) ok() bool {
+	return sc.reason == ""
+}
+
+func (sc *isoScanner) done() bool {
+	return sc.pos >= len(sc.s)
+}
+
+func (sc *isoScanner) fail(reason string) bool {
+	if sc.reason == "" {
+		sc.reason = reason
+	}
+	return false
+}
+
+func (sc *isoScanner) isDigit() bool {
+	return !sc.done() && sc.s[sc.pos] >= '0' && sc.s[sc.pos] <= '9'
+}
+
+func (sc *isoScanner) peekAt(n int) byte {
+	if sc.pos+n >= len(sc.s) {
+		return 0
+	}
+	return sc.s[sc.pos+n]
+}
+
+// accept consumes the first of tokens found at the cursor.
+func (sc *isoScanner) accept(tokens ...string) bool {
+	for _, tok := range tokens {
+		if strings.HasPrefix(sc.s[sc.pos:], tok) {
+			sc.pos += len(tok)
+			return true
+		}
+	}
+	return false
+}
+
+func (sc *isoScanner) expect(tok string) bool {
+	if sc.accept(tok) {
+		return true
+	}
+	return sc.fail(fmt.Sprintf("expected %q", tok))
+}
+
+// digits consumes exactly n digits.
+func (sc *isoScanner) digits(n int, field string) (int, bool) {
+	v := 0
+	for i := 0; i < n; i++ {
+		if !sc.isDigit() {
+			return 0, sc.fail(fmt.Sprintf("expected %d-digit %s", n, field))
+		}
+		v = v*10 + int(sc.s[sc.pos]-'0')
+		sc.pos++
+	}
+	return v, true
+}
+
+// fraction consumes the digits of a decimal fraction and returns it as
+// numerator and denominator.  Digits beyond nanosecond precision are ignored.
+func (sc *isoScanner) fraction() (int64, int64, bool) {
+	if !sc.isDigit() {
+		return 0, 1, sc.fail("expected digits after decimal mark")
+	}
+	var num, den int64 = 0, 1
+	for sc.isDigit() {
+		if den < 1e9 {
+			num = num*10 + int64(sc.s[sc.pos]-'0')
+			den *= 10
+		}
+		sc.pos++
+	}
+	return num, den, true
+}
@@ -0,0 +1,197 @@
+package mcp
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseISO8601(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		input   string
+		want    time.Time
+		offset  bool
+		wantErr bool
+	}{
+		{
+			desc:   "RFC 3339 with fractional seconds and Z",
+			input:  "2024-05-01T13:45:00.123Z",
+			want:   time.Date(2024, 5, 1, 13, 45, 0, 123000000, time.UTC),
+			offset: true,
+		},
+		{
+			desc:   "Reduced precision time with offset",
+			input:  "2024-05-01T13:45+02:00",
+			want:   time.Date(2024, 5, 1, 11, 45, 0, 0, time.UTC),
+			offset: true,
+		},
+		{
+			desc:   "Basic format with basic offset",
+			input:  "20240501T134500-0530",
+			want:   time.Date(2024, 5, 1, 19, 15, 0, 0, time.UTC),
+			offset: true,
+		},
+		{
+			desc:   "Hour only offset",
+			input:  "2024-05-01T13:45:00+09",
+			want:   time.Date(2024, 5, 1, 4, 45, 0, 0, time.UTC),
+			offset: true,
+		},
+		{
+			desc:   "Server output format",
+			input:  "2024-05-01 13:45:00 -0700",
+			want:   time.Date(2024, 5, 1, 20, 45, 0, 0, time.UTC),
+			offset: true,
+		},
+		{
+			desc:  "Comma decimal mark",
+			input: "2024-05-01T13:45:00,5",
+			want:  time.Date(2024, 5, 1, 13, 45, 0, 500000000, time.UTC),
+		},
+		{
+			desc:  "Fractional minutes",
+			input: "2024-05-01T13:45.5",
+			want:  time.Date(2024, 5, 1, 13, 45, 30, 0, time.UTC),
+		},
+		{
+			desc:  "Week date",
+			input: "2024-W18-3",
+			want:  time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:  "Week date in previous calendar year",
+			input: "2020-W01-1",
+			want:  time.Date(2019, 12, 30, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:  "Basic week date",
+			input: "2024W183",
+			want:  time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:  "Reduced week date",
+			input: "2024-W18",
+			want:  time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:  "Ordinal date",
+			input: "2024-122",
+			want:  time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:  "Basic ordinal date",
+			input: "2024122",
+			want:  time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:  "Year and month",
+			input: "2024-05",
+			want:  time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:  "Year only",
+			input: "2024",
+			want:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:  "End of day",
+			input: "2024-05-01T24:00",
+			want:  time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:    "Month out of range",
+			input:   "2024-13-01",
+			wantErr: true,
+		},
+		{
+			desc:    "February 30th",
+			input:   "2023-02-30",
+			wantErr: true,
+		},
+		{
+			desc:    "Week 53 in a 52 week year",
+			input:   "2023-W53-1",
+			wantErr: true,
+		},
+		{
+			desc:    "Day 366 in a common year",
+			input:   "2023-366",
+			wantErr: true,
+		},
+		{
+			desc:    "24:00 with minutes",
+			input:   "2024-05-01T24:30",
+			wantErr: true,
+		},
+		{
+			desc:    "Trailing garbage",
+			input:   "2024-05-01T13:45:00Zjunk",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := parseISO8601(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parseISO8601() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if got.hasOffset != tc.offset {
+				t.Errorf("parseISO8601() hasOffset = %v, want %v", got.hasOffset, tc.offset)
+			}
+			var gotTime time.Time
+			if got.hasOffset {
+				gotTime = got.instant()
+			} else {
+				gotTime = time.Date(got.year, got.month, got.day, got.hour, got.minute, got.second, got.nsec, time.UTC)
+				if got.endOfDay {
+					gotTime = gotTime.AddDate(0, 0, 1)
+				}
+			}
+			if !gotTime.Equal(tc.want) {
+				t.Errorf("parseISO8601() got = %v, want %v", gotTime, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseISO8601Closest(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		input string
+		want  string
+	}{
+		{
+			desc:  "Bad calendar day",
+			input: "2024-05-1",
+			want:  "calendar date (YYYY-MM-DD)",
+		},
+		{
+			desc:  "Bad week",
+			input: "2024-W5",
+			want:  "week date (YYYY-Www-D)",
+		},
+		{
+			desc:  "Bad time of day",
+			input: "2024-05-01T1",
+			want:  "calendar date (YYYY-MM-DD)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := parseISO8601(tc.input)
+			var formatErr *InvalidTimeFormatError
+			if !errors.As(err, &formatErr) {
+				t.Fatalf("parseISO8601() error = %v, want InvalidTimeFormatError", err)
+			}
+			if formatErr.Closest != tc.want {
+				t.Errorf("parseISO8601() closest = %q, want %q", formatErr.Closest, tc.want)
+			}
+		})
+	}
+}
@@ -8,6 +8,6 @@ import (
 func init() {
 	srv := NewServer()
 	httpServer := server.NewStreamableHTTPServer(srv.MCPServer)
-	handlers.AddAll("/mcp", srv, httpServer.ServeHTTP)
+	handlers.AddAll("/mcp", srv, srv.SubscriptionHandler(httpServer.ServeHTTP))
 	srv.ready = true
 }
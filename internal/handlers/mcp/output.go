@@ -0,0 +1,695 @@
+package mcp
+
+import (
+	"math"
+	"time"
+)
+
+// The types in this file are the structured results returned alongside each
+// tool's text.  Their JSON schemas are declared as the tools' output schemas
+// in NewServer.
+
+// ZonedTime describes an instant as seen in a particular time zone.
+type ZonedTime struct {
+	DateTime      string `json:"dateTime" jsonschema:"RFC 3339 timestamp including the zone's UTC offset"`
+	TimeZone      string `json:"timeZone" jsonschema:"IANA time zone name"`
+	Abbreviation  string `json:"abbreviation" jsonschema:"zone abbreviation in effect, e.g. EDT"`
+	UTCOffset     string `json:"utcOffset" jsonschema:"UTC offset as +hh:mm or -hh:mm"`
+	OffsetSeconds int    `json:"offsetSeconds" jsonschema:"UTC offset in seconds east of UTC"`
+	IsDST         bool   `json:"isDST" jsonschema:"whether daylight saving time is in effect"`
+	EpochSeconds  int64  `json:"epochSeconds" jsonschema:"seconds since 1970-01-01T00:00:00Z"`
+	DayOfWeek     string `json:"dayOfWeek" jsonschema:"local day of the week, e.g. Monday"`
+}
+
+func newZonedTime(t time.Time) ZonedTime {
+	abbreviation, offset := t.Zone()
+	return ZonedTime{
+		DateTime:      t.Format(time.RFC3339Nano),
+		TimeZone:      t.Location().String(),
+		Abbreviation:  abbreviation,
+		UTCOffset:     t.Format("-07:00"),
+		OffsetSeconds: offset,
+		IsDST:         t.IsDST(),
+		EpochSeconds:  t.Unix(),
+		DayOfWeek:     t.Weekday().String(),
+	}
+}
+
+// ResolvedInput describes how a date/time argument was interpreted.
+type ResolvedInput struct {
+	Input           string     `json:"input" jsonschema:"the date/time as given"`
+	Time            ZonedTime  `json:"time" jsonschema:"the instant the input was resolved to"`
+	LocalTime       string     `json:"localTime" jsonschema:"normal, gap or fold: whether the local wall-clock time occurs once, was skipped or is repeated by a daylight saving transition"`
+	AlternativeTime *ZonedTime `json:"alternativeTime,omitempty" jsonschema:"for a gap or fold, the candidate instant that was not chosen"`
+	Explanation     string     `json:"explanation,omitempty" jsonschema:"how a natural-language input was interpreted"`
+}
+
+func newResolvedInput(input string, wc WallClock) ResolvedInput {
+	r := ResolvedInput{
+		Input:       input,
+		Time:        newZonedTime(wc.Time),
+		LocalTime:   wc.Kind.String(),
+		Explanation: wc.Explanation,
+	}
+	if wc.Kind != WallClockNormal {
+		other := newZonedTime(otherReading(wc))
+		r.AlternativeTime = &other
+	}
+	return r
+}
+
+// DurationOutput describes the span between two instants.
+type DurationOutput struct {
+	Seconds     float64      `json:"seconds" jsonschema:"exact length in seconds"`
+	ISO8601     string       `json:"iso8601" jsonschema:"calendar breakdown as an ISO 8601 duration, e.g. P1Y2M3DT4H"`
+	Calendar    calendarSpan `json:"calendar" jsonschema:"whole calendar and clock units"`
+	Approximate string       `json:"approximate" jsonschema:"short phrase such as about 3 hours"`
+	Text        string       `json:"text" jsonschema:"the duration in the requested outputStyle"`
+}
+
+// newDurationOutput describes the span from start to end, which must not be
+// before start, like formatDuration.
+func newDurationOutput(start, end time.Time, style OutputStyle, suffix string) DurationOutput {
+	span := calendarBreakdown(start, end)
+	return DurationOutput{
+		Seconds:     end.Sub(start).Seconds(),
+		ISO8601:     span.duration().String(),
+		Calendar:    span,
+		Approximate: approximateDuration(end.Sub(start)),
+		Text:        formatDuration(start, end, style, suffix),
+	}
+}
+
+// ElapsedTimeOutput is the structured result of timeSince and timeUntil.
+type ElapsedTimeOutput struct {
+	Input    ResolvedInput  `json:"input"`
+	Now      ZonedTime      `json:"now" jsonschema:"the current time in the input's time zone"`
+	Duration DurationOutput `json:"duration"`
+}
+
+// TimeDifferenceOutput is the structured result of timeDifference.
+type TimeDifferenceOutput struct {
+	First      ResolvedInput  `json:"first"`
+	Second     ResolvedInput  `json:"second"`
+	Comparison string         `json:"comparison" jsonschema:"earlier, later or equal: how the first time compares with the second"`
+	Duration   DurationOutput `json:"duration" jsonschema:"the absolute difference"`
+	Seconds    float64        `json:"seconds" jsonschema:"signed seconds from the first time to the second"`
+}
+
+// LeapYearOutput is the structured result of isLeapYear.
+type LeapYearOutput struct {
+	Year       int  `json:"year"`
+	IsLeapYear bool `json:"isLeapYear"`
+	DaysInYear int  `json:"daysInYear"`
+}
+
+// DayOfWeekOutput is the structured result of dayOfWeek.
+type DayOfWeekOutput struct {
+	Date       string `json:"date" jsonschema:"YYYY-MM-DD"`
+	DayOfWeek  string `json:"dayOfWeek"`
+	ISOWeekday int    `json:"isoWeekday" jsonschema:"1 for Monday through 7 for Sunday"`
+}
+
+// OccurrenceOutput is the structured result of nextOccurrence and
+// previousOccurrence.
+type OccurrenceOutput struct {
+	From       ZonedTime `json:"from"`
+	DayOfWeek  string    `json:"dayOfWeek"`
+	Occurrence ZonedTime `json:"occurrence"`
+	Days       int       `json:"days" jsonschema:"signed number of days from the input to the occurrence"`
+}
+
+// DurationShiftOutput is the structured result of addDuration and
+// subtractDuration.
+type DurationShiftOutput struct {
+	Input    ZonedTime `json:"input"`
+	Duration string    `json:"duration" jsonschema:"the signed duration applied, as ISO 8601"`
+	Result   ZonedTime `json:"result"`
+}
+
+// WeekendOutput is the structured result of isWeekend.
+type WeekendOutput struct {
+	Date      string `json:"date" jsonschema:"YYYY-MM-DD"`
+	DayOfWeek string `json:"dayOfWeek"`
+	IsWeekend bool   `json:"isWeekend"`
+}
+
+// WeekdayOutput is the structured result of isWeekday.
+type WeekdayOutput struct {
+	Date      string `json:"date" jsonschema:"YYYY-MM-DD"`
+	DayOfWeek string `json:"dayOfWeek"`
+	IsWeekday bool   `json:"isWeekday"`
+}
+
+// DaysBetweenOutput is the structured result of daysBetween.
+type DaysBetweenOutput struct {
+	FirstDate  string `json:"firstDate" jsonschema:"YYYY-MM-DD"`
+	SecondDate string `json:"secondDate" jsonschema:"YYYY-MM-DD"`
+	Days       int    `json:"days" jsonschema:"whole days from the first date to the second; negative if the second is earlier"`
+}
+
+// ConvertTimeZoneOutput is the structured result of convertTimeZone.
+type ConvertTimeZoneOutput struct {
+	Source  ResolvedInput `json:"source"`
+	Targets []ZonedTime   `json:"targets"`
+}
+
+// HolidayOutput describes one holiday.
+type HolidayOutput struct {
+	Name       string `json:"name"`
+	Date       string `json:"date" jsonschema:"YYYY-MM-DD the holiday is observed as a non-working day"`
+	ActualDate string `json:"actualDate" jsonschema:"YYYY-MM-DD the holiday falls on before any shift for a weekend"`
+	DayOfWeek  string `json:"dayOfWeek" jsonschema:"day of the week the holiday is observed"`
+}
+
+func newHolidayOutputs(holidays []Holiday) []HolidayOutput {
+	out := make([]HolidayOutput, 0, len(holidays))
+	for _, h := range holidays {
+		out = append(out, HolidayOutput{Name: h.Name, Date: h.Date.Format(dateFormat), ActualDate: h.Actual.Format(dateFormat), DayOfWeek: h.Date.Weekday().String()})
+	}
+	return out
+}
+
+// BusinessDayOutput is the structured result of isBusinessDay.
+type BusinessDayOutput struct {
+	Date          string         `json:"date" jsonschema:"YYYY-MM-DD"`
+	DayOfWeek     string         `json:"dayOfWeek"`
+	IsBusinessDay bool           `json:"isBusinessDay"`
+	IsWeekend     bool           `json:"isWeekend"`
+	Holiday       *HolidayOutput `json:"holiday,omitempty" jsonschema:"the holiday observed on the date, if any"`
+	Calendar      string         `json:"calendar,omitempty" jsonschema:"ID of the holiday calendar used"`
+	Weekend       []string       `json:"weekend" jsonschema:"the days treated as the weekend"`
+}
+
+// AddBusinessDaysOutput is the structured result of addBusinessDays.
+type AddBusinessDaysOutput struct {
+	Input           ZonedTime       `json:"input"`
+	BusinessDays    int             `json:"businessDays" jsonschema:"signed number of business days added"`
+	Result          ZonedTime       `json:"result"`
+	SkippedHolidays []HolidayOutput `json:"skippedHolidays" jsonschema:"holidays passed over that would otherwise have been business days"`
+	Calendar        string          `json:"calendar,omitempty" jsonschema:"ID of the holiday calendar used"`
+	Weekend         []string        `json:"weekend" jsonschema:"the days treated as the weekend"`
+}
+
+// BusinessDaysBetweenOutput is the structured result of businessDaysBetween.
+type BusinessDaysBetweenOutput struct {
+	FirstDate    string          `json:"firstDate" jsonschema:"YYYY-MM-DD"`
+	SecondDate   string          `json:"secondDate" jsonschema:"YYYY-MM-DD"`
+	BusinessDays int             `json:"businessDays" jsonschema:"business days from the first date up to but not including the second; negative if the second is earlier"`
+	CalendarDays int             `json:"calendarDays"`
+	WeekendDays  int             `json:"weekendDays"`
+	Holidays     []HolidayOutput `json:"holidays" jsonschema:"holidays in the range that fall on working days"`
+	Calendar     string          `json:"calendar,omitempty" jsonschema:"ID of the holiday calendar used"`
+	Weekend      []string        `json:"weekend" jsonschema:"the days treated as the weekend"`
+}
+
+// ListHolidaysOutput is the structured result of listHolidays.
+type ListHolidaysOutput struct {
+	Calendar     string          `json:"calendar" jsonschema:"ID of the holiday calendar"`
+	CalendarName string          `json:"calendarName"`
+	Year         int             `json:"year"`
+	Holidays     []HolidayOutput `json:"holidays"`
+}
+
+// UpcomingHolidayOutput is a holiday with its distance from today.
+type UpcomingHolidayOutput struct {
+	HolidayOutput
+	DaysUntil int `json:"daysUntil" jsonschema:"calendar days from today until the holiday is observed; 0 if it is today"`
+}
+
+// NextHolidayOutput is the structured result of nextHoliday.
+type NextHolidayOutput struct {
+	Calendar     string                  `json:"calendar" jsonschema:"ID of the holiday calendar"`
+	CalendarName string                  `json:"calendarName"`
+	Today        string                  `json:"today" jsonschema:"YYYY-MM-DD in the requested time zone"`
+	Holidays     []UpcomingHolidayOutput `json:"holidays"`
+}
+
+// RecurrenceOutput is the structured result of expandRecurrence.
+type RecurrenceOutput struct {
+	Rule        string      `json:"rule" jsonschema:"the normalized RRULE"`
+	Description string      `json:"description" jsonschema:"the rule in English"`
+	Start       ZonedTime   `json:"start" jsonschema:"DTSTART, the time the recurrence is anchored at"`
+	Occurrences []ZonedTime `json:"occurrences"`
+	HasMore     bool        `json:"hasMore" jsonschema:"whether further occurrences follow the last one returned"`
+}
+
+// CronRunsOutput is the structured result of nextCronRuns and
+// previousCronRuns.
+type CronRunsOutput struct {
+	Expression  string      `json:"expression"`
+	Dialect     string      `json:"dialect" jsonschema:"standard, seconds or quartz: how the expression was read"`
+	Description string      `json:"description" jsonschema:"the schedule in plain English"`
+	From        ZonedTime   `json:"from" jsonschema:"the current time the runs are counted from"`
+	Runs        []ZonedTime `json:"runs" jsonschema:"fire times, nearest first"`
+}
+
+// CronExplanationOutput is the structured result of explainCron.
+type CronExplanationOutput struct {
+	Expression  string `json:"expression"`
+	Dialect     string `json:"dialect" jsonschema:"standard, seconds or quartz: how the expression was read"`
+	Description string `json:"description" jsonschema:"the schedule in plain English"`
+}
+
+// SessionCallOutput is one tool call in a session timeline.
+type SessionCallOutput struct {
+	Tool       string    `json:"tool"`
+	Time       ZonedTime `json:"time"`
+	GapSeconds float64   `json:"gapSeconds" jsonschema:"seconds since the previous call, or since the session started for the first call listed"`
+	Gap        string    `json:"gap" jsonschema:"the gap as a short phrase such as about 3 hours"`
+}
+
+// SessionGapOutput is the pause between two tool calls.
+type SessionGapOutput struct {
+	After   SessionCallOutput `json:"after" jsonschema:"the call before the pause"`
+	Before  SessionCallOutput `json:"before" jsonschema:"the call after the pause"`
+	Seconds float64           `json:"seconds"`
+	Text    string            `json:"text" jsonschema:"the pause as a short phrase"`
+}
+
+// SessionTimelineOutput is the structured result of sessionTimeline.
+type SessionTimelineOutput struct {
+	SessionID         string              `json:"sessionId,omitempty" jsonschema:"the MCP session ID; empty outside a session"`
+	Started           ZonedTime           `json:"started" jsonschema:"when the session started, or its first recorded tool call if the start was not seen"`
+	Now               ZonedTime           `json:"now"`
+	Elapsed           DurationOutput      `json:"elapsed" jsonschema:"time since the session started"`
+	ToolCalls         int                 `json:"toolCalls" jsonschema:"tool calls made in the session before this one"`
+	SinceLastCall     *DurationOutput     `json:"sinceLastCall,omitempty" jsonschema:"time since the previous tool call"`
+	LongestGap        *SessionGapOutput   `json:"longestGap,omitempty" jsonschema:"the longest pause between two of the recorded tool calls"`
+	AverageGapSeconds float64             `json:"averageGapSeconds" jsonschema:"mean seconds between consecutive recorded tool calls"`
+	Calls             []SessionCallOutput `json:"calls" jsonschema:"the most recent tool calls, oldest first"`
+	Summary           string              `json:"summary"`
+}
+
+// LapOutput is one split of a timer.
+type LapOutput struct {
+	Lap          int       `json:"lap" jsonschema:"lap number, from 1"`
+	Label        string    `json:"label,omitempty"`
+	Time         ZonedTime `json:"time"`
+	SplitSeconds float64   `json:"splitSeconds" jsonschema:"seconds since the previous lap, or since the timer started for the first"`
+	Split        string    `json:"split"`
+	TotalSeconds float64   `json:"totalSeconds" jsonschema:"seconds since the timer started"`
+}
+
+// TimerOutput describes a named timer.
+type TimerOutput struct {
+	Name           string      `json:"name"`
+	Scope          string      `json:"scope" jsonschema:"session or global"`
+	Running        bool        `json:"running"`
+	Started        ZonedTime   `json:"started"`
+	Stopped        *ZonedTime  `json:"stopped,omitempty"`
+	ElapsedSeconds float64     `json:"elapsedSeconds" jsonschema:"seconds the timer has run, up to now if it is running"`
+	Elapsed        string      `json:"elapsed" jsonschema:"the elapsed time in calendar units, e.g. 1 hour, 5 minutes"`
+	Laps           []LapOutput `json:"laps"`
+}
+
+func newTimerOutput(t Timer, scope TimerScope, now time.Time, loc *time.Location) TimerOutput {
+	end := now
+	if !t.Running() {
+		end = t.Stopped
+	}
+	out := TimerOutput{
+		Name:           t.Name,
+		Scope:          string(scope),
+		Running:        t.Running(),
+		Started:        newZonedTime(t.Started.In(loc)),
+		ElapsedSeconds: t.Elapsed(now).Seconds(),
+		Elapsed:        timerPhrase(t.Started, end),
+		Laps:           make([]LapOutput, 0, len(t.Laps)),
+	}
+	if !t.Running() {
+		stopped := newZonedTime(t.Stopped.In(loc))
+		out.Stopped = &stopped
+	}
+	previous := t.Started
+	for i, lap := range t.Laps {
+		out.Laps = append(out.Laps, LapOutput{
+			Lap:          i + 1,
+			Label:        lap.Label,
+			Time:         newZonedTime(lap.Time.In(loc)),
+			SplitSeconds: lap.Time.Sub(previous).Seconds(),
+			Split:        timerPhrase(previous, lap.Time),
+			TotalSeconds: lap.Time.Sub(t.Started).Seconds(),
+		})
+		previous = lap.Time
+	}
+	return out
+}
+
+// TimersOutput is the structured result of listTimers.
+type TimersOutput struct {
+	Now    ZonedTime     `json:"now"`
+	Timers []TimerOutput `json:"timers" jsonschema:"session timers, then global timers, oldest first"`
+}
+
+// EventOutput describes a registered event as seen at the current time.
+type EventOutput struct {
+	Name             string         `json:"name"`
+	Description      string         `json:"description,omitempty"`
+	Time             ZonedTime      `json:"time" jsonschema:"the event time in the time zone it was registered in"`
+	Status           string         `json:"status" jsonschema:"upcoming, due (for a minute from the event time) or overdue"`
+	SecondsRemaining float64        `json:"secondsRemaining" jsonschema:"seconds until the event, negative once it has passed"`
+	Remaining        DurationOutput `json:"remaining" jsonschema:"the time until the event, or since it once it is overdue"`
+}
+
+func newEventOutput(e Event, now time.Time, loc *time.Location, style OutputStyle) EventOutput {
+	t := e.Time.In(loc)
+	out := EventOutput{
+		Name:             e.Name,
+		Description:      e.Description,
+		Time:             newZonedTime(t),
+		Status:           string(eventStatus(t, now)),
+		SecondsRemaining: t.Sub(now).Seconds(),
+	}
+	if t.Before(now) {
+		out.Remaining = newDurationOutput(t, now.In(loc), style, "")
+	} else {
+		out.Remaining = newDurationOutput(now.In(loc), t, style, "")
+	}
+	return out
+}
+
+// EventsOutput is the structured result of listEvents.
+type EventsOutput struct {
+	Now    ZonedTime     `json:"now"`
+	Events []EventOutput `json:"events" jsonschema:"soonest first"`
+	Hidden int           `json:"hidden" jsonschema:"number of past events left out"`
+}
+
+// CalendarDayOutput is one day of a month calendar.
+type CalendarDayOutput struct {
+	Date      string `json:"date" jsonschema:"YYYY-MM-DD"`
+	DayOfWeek string `json:"dayOfWeek"`
+	DayOfYear int    `json:"dayOfYear"`
+	ISOWeek   int    `json:"isoWeek" jsonschema:"ISO 8601 week number; days early in January may belong to the previous year's last week"`
+}
+
+// MonthCalendarOutput describes the days of a month.
+type MonthCalendarOutput struct {
+	Year        int                 `json:"year"`
+	Month       int                 `json:"month"`
+	MonthName   string              `json:"monthName"`
+	DaysInMonth int                 `json:"daysInMonth"`
+	Days        []CalendarDayOutput `json:"days"`
+	WeekStart   string              `json:"weekStart" jsonschema:"the day the grid's weeks start on"`
+	Weeks       [][]int             `json:"weeks" jsonschema:"the grid's rows, one per week, with the day of the month in each column or 0 for days outside the month"`
+	Grid        string              `json:"grid" jsonschema:"the month laid out as a wall calendar"`
+}
+
+func newMonthCalendarOutput(year int, month time.Month, weekStart time.Weekday) MonthCalendarOutput {
+	days := daysIn(month, year)
+	out := MonthCalendarOutput{
+		Year:        year,
+		Month:       int(month),
+		MonthName:   month.String(),
+		DaysInMonth: days,
+		Days:        make([]CalendarDayOutput, 0, days),
+		WeekStart:   weekStart.String(),
+		Weeks:       monthWeeks(year, month, weekStart),
+		Grid:        monthGrid(year, month, weekStart),
+	}
+	for d := 1; d <= days; d++ {
+		t := time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+		_, week := t.ISOWeek()
+		out.Days = append(out.Days, CalendarDayOutput{
+			Date:      t.Format(dateFormat),
+			DayOfWeek: t.Weekday().String(),
+			DayOfYear: t.YearDay(),
+			ISOWeek:   week,
+		})
+	}
+	return out
+}
+
+// TimeZoneOutput is a time zone's current offset.
+type TimeZoneOutput struct {
+	Name         string `json:"name" jsonschema:"IANA time zone name"`
+	Abbreviation string `json:"abbreviation"`
+	UTCOffset    string `json:"utcOffset" jsonschema:"UTC offset as +hh:mm or -hh:mm"`
+	IsDST        bool   `json:"isDST"`
+}
+
+// TimeZonesOutput lists the known time zones.
+type TimeZonesOutput struct {
+	Now       string           `json:"now" jsonschema:"RFC 3339 timestamp in UTC at which the offsets apply"`
+	TimeZones []TimeZoneOutput `json:"timeZones"`
+}
+
+// TimeZoneCandidateOutput is a time zone that a query may refer to.
+type TimeZoneCandidateOutput struct {
+	TimeZone     string `json:"timeZone" jsonschema:"IANA time zone name, or a fixed offset such as UTC+05:30"`
+	Match        string `json:"match" jsonschema:"what the query matched: timeZone, alias, offset, abbreviation, city, country or similar"`
+	Description  string `json:"description" jsonschema:"what matched, e.g. Tokyo, Japan or India Standard Time"`
+	Score        int    `json:"score" jsonschema:"how well the query matched, from 0 to 100"`
+	Abbreviation string `json:"abbreviation"`
+	UTCOffset    string `json:"utcOffset" jsonschema:"current UTC offset as +hh:mm or -hh:mm"`
+	IsDST        bool   `json:"isDST"`
+}
+
+// FindTimeZoneOutput lists the time zones that a query may refer to.
+type FindTimeZoneOutput struct {
+	Query      string                    `json:"query"`
+	Resolved   string                    `json:"resolved,omitempty" jsonschema:"the time zone the query resolves to when used as a time zone argument; empty if it is ambiguous or unknown"`
+	Candidates []TimeZoneCandidateOutput `json:"candidates" jsonschema:"most likely first"`
+}
+
+// TimeZoneDatabaseOutput describes the time zone database that zones are
+// loaded from.
+type TimeZoneDatabaseOutput struct {
+	Version         string `json:"version" jsonschema:"IANA tzdata release in use, e.g. 2025b; empty if unknown"`
+	Source          string `json:"source" jsonschema:"embedded for the copy built into the server, or the path of the zoneinfo directory or zip file in use"`
+	TimeZones       int    `json:"timeZones" jsonschema:"number of time zones in the database"`
+	EmbeddedVersion string `json:"embeddedVersion" jsonschema:"tzdata release built into the server"`
+	SystemVersion   string `json:"systemVersion,omitempty" jsonschema:"tzdata release installed on the host, if it has a copy that records one"`
+	SystemSource    string `json:"systemSource,omitempty" jsonschema:"where the host's copy was found"`
+}
+
+// ZoneTransitionOutput is a change of a zone's UTC offset or abbreviation.
+type ZoneTransitionOutput struct {
+	At               string `json:"at" jsonschema:"RFC 3339 timestamp at which the change takes effect, with the new offset"`
+	UTC              string `json:"utc" jsonschema:"the same instant in UTC"`
+	Direction        string `json:"direction" jsonschema:"forward or back for the way the clocks move, or unchanged when only the abbreviation or daylight saving flag changes"`
+	LocalTimeBefore  string `json:"localTimeBefore" jsonschema:"wall-clock time at which the change happens under the old offset, e.g. 02:00:00"`
+	LocalTimeAfter   string `json:"localTimeAfter" jsonschema:"wall-clock time the clocks show instead, e.g. 03:00:00"`
+	FromOffset       string `json:"fromOffset" jsonschema:"old UTC offset as +hh:mm or -hh:mm"`
+	ToOffset         string `json:"toOffset" jsonschema:"new UTC offset as +hh:mm or -hh:mm"`
+	FromAbbreviation string `json:"fromAbbreviation"`
+	ToAbbreviation   string `json:"toAbbreviation"`
+	FromIsDST        bool   `json:"fromIsDST"`
+	ToIsDST          bool   `json:"toIsDST"`
+	Description      string `json:"description"`
+}
+
+func newZoneTransitionOutput(tr ZoneTransition) ZoneTransitionOutput {
+	before := tr.LocalBefore()
+	return ZoneTransitionOutput{
+		At:               tr.At.Format(time.RFC3339),
+		UTC:              tr.At.UTC().Format(time.RFC3339),
+		Direction:        tr.Direction(),
+		LocalTimeBefore:  before.Format("15:04:05"),
+		LocalTimeAfter:   tr.At.Format("15:04:05"),
+		FromOffset:       before.Format("-07:00"),
+		ToOffset:         tr.At.Format("-07:00"),
+		FromAbbreviation: tr.FromAbbreviation,
+		ToAbbreviation:   tr.ToAbbreviation,
+		FromIsDST:        tr.FromDST,
+		ToIsDST:          tr.ToDST,
+		Description:      describeZoneTransition(tr),
+	}
+}
+
+// DSTTransitionsOutput lists a zone's changes of offset in a window.
+type DSTTransitionsOutput struct {
+	TimeZone    string                 `json:"timeZone"`
+	From        string                 `json:"from" jsonschema:"RFC 3339 start of the window"`
+	To          string                 `json:"to" jsonschema:"RFC 3339 end of the window, exclusive"`
+	Transitions []ZoneTransitionOutput `json:"transitions" jsonschema:"earliest first"`
+	Truncated   bool                   `json:"truncated" jsonschema:"whether more changes in the window were left out"`
+}
+
+// NextTransitionOutput describes a zone's next change of offset.
+type NextTransitionOutput struct {
+	Now      ZonedTime             `json:"now"`
+	Next     *ZoneTransitionOutput `json:"next,omitempty" jsonschema:"absent when the zone has no change in the next 100 years"`
+	Until    *DurationOutput       `json:"until,omitempty" jsonschema:"time from now until the next change"`
+	Previous *ZoneTransitionOutput `json:"previous,omitempty" jsonschema:"the last change before now, if any"`
+}
+
+// PeriodOutput is the week, month, quarter or year containing a date.
+type PeriodOutput struct {
+	First         string `json:"first" jsonschema:"first day, YYYY-MM-DD"`
+	Last          string `json:"last" jsonschema:"last day, YYYY-MM-DD"`
+	Days          int    `json:"days" jsonschema:"number of days in the period"`
+	DayOfPeriod   int    `json:"dayOfPeriod" jsonschema:"position of the date in the period, from 1"`
+	DaysRemaining int    `json:"daysRemaining" jsonschema:"days after the date until the end of the period"`
+}
+
+// DatePeriodsOutput holds the periods containing a date.
+type DatePeriodsOutput struct {
+	Week    PeriodOutput `json:"week" jsonschema:"the week, starting on weekStart"`
+	Month   PeriodOutput `json:"month"`
+	Quarter PeriodOutput `json:"quarter" jsonschema:"the calendar quarter"`
+	Year    PeriodOutput `json:"year"`
+}
+
+// DateInfoOutput describes a calendar date.
+type DateInfoOutput struct {
+	Date        string            `json:"date" jsonschema:"YYYY-MM-DD"`
+	DayOfWeek   string            `json:"dayOfWeek"`
+	ISOWeekday  int               `json:"isoWeekday" jsonschema:"ISO 8601 weekday number: 1 is Monday and 7 is Sunday"`
+	ISOWeekYear int               `json:"isoWeekYear" jsonschema:"ISO 8601 week-numbering year, which differs from the calendar year for some days around New Year"`
+	ISOWeek     int               `json:"isoWeek" jsonschema:"ISO 8601 week number, from 1 to 53"`
+	ISOWeekDate string            `json:"isoWeekDate" jsonschema:"ISO 8601 week date, e.g. 2025-W05-6"`
+	DayOfYear   int               `json:"dayOfYear"`
+	OrdinalDate string            `json:"ordinalDate" jsonschema:"ISO 8601 ordinal date, e.g. 2025-032"`
+	Quarter     int               `json:"quarter" jsonschema:"calendar quarter, from 1 to 4"`
+	IsLeapYear  bool              `json:"isLeapYear"`
+	WeekStart   string            `json:"weekStart" jsonschema:"the day weeks start on"`
+	Periods     DatePeriodsOutput `json:"periods"`
+}
+
+// FiscalPeriodsOutput holds the fiscal periods containing a date.
+type FiscalPeriodsOutput struct {
+	Week    PeriodOutput `json:"week" jsonschema:"the fiscal week, counted in seven-day weeks from the start of the fiscal year"`
+	Period  PeriodOutput `json:"period" jsonschema:"the fiscal period: a calendar month, or four or five weeks in a 52/53-week calendar"`
+	Quarter PeriodOutput `json:"quarter" jsonschema:"the fiscal quarter"`
+	Year    PeriodOutput `json:"year" jsonschema:"the fiscal year"`
+}
+
+// FiscalDateOutput is the position of a date in a fiscal calendar.
+type FiscalDateOutput struct {
+	Date         string              `json:"date" jsonschema:"YYYY-MM-DD"`
+	Calendar     string              `json:"calendar" jsonschema:"ID of the fiscal calendar"`
+	CalendarName string              `json:"calendarName"`
+	FiscalYear   int                 `json:"fiscalYear" jsonschema:"the number the fiscal year is named for"`
+	Label        string              `json:"label" jsonschema:"the fiscal year's name, e.g. FY2025"`
+	Quarter      int                 `json:"quarter" jsonschema:"fiscal quarter, from 1 to 4"`
+	Period       int                 `json:"period" jsonschema:"fiscal period, from 1 to 12"`
+	Week         int                 `json:"week" jsonschema:"fiscal week, from 1 to 53"`
+	DayOfYear    int                 `json:"dayOfYear" jsonschema:"position of the date in the fiscal year, from 1"`
+	WeeksInYear  int                 `json:"weeksInYear,omitempty" jsonschema:"52 or 53, for a 52/53-week calendar"`
+	Periods      FiscalPeriodsOutput `json:"periods"`
+}
+
+// FiscalPeriodOutput is one quarter or period of a fiscal year.
+type FiscalPeriodOutput struct {
+	Number int    `json:"number" jsonschema:"quarter from 1 to 4 or period from 1 to 12"`
+	First  string `json:"first" jsonschema:"first day, YYYY-MM-DD"`
+	Last   string `json:"last" jsonschema:"last day, YYYY-MM-DD"`
+	Days   int    `json:"days"`
+	Weeks  int    `json:"weeks,omitempty" jsonschema:"number of weeks, for a 52/53-week calendar"`
+}
+
+// FiscalYearOutput lists the quarters and periods of a fiscal year.
+type FiscalYearOutput struct {
+	Calendar     string               `json:"calendar" jsonschema:"ID of the fiscal calendar"`
+	CalendarName string               `json:"calendarName"`
+	Rules        string               `json:"rules" jsonschema:"summary of how the calendar divides the year"`
+	FiscalYear   int                  `json:"fiscalYear" jsonschema:"the number the fiscal year is named for"`
+	Label        string               `json:"label" jsonschema:"the fiscal year's name, e.g. FY2025"`
+	First        string               `json:"first" jsonschema:"first day, YYYY-MM-DD"`
+	Last         string               `json:"last" jsonschema:"last day, YYYY-MM-DD"`
+	Days         int                  `json:"days"`
+	Weeks        int                  `json:"weeks,omitempty" jsonschema:"52 or 53, for a 52/53-week calendar"`
+	Quarters     []FiscalPeriodOutput `json:"quarters"`
+	Periods      []FiscalPeriodOutput `json:"periods"`
+}
+
+// CalendarDateOutput is a date in another calendar.
+type CalendarDateOutput struct {
+	Calendar     string `json:"calendar" jsonschema:"ID of the calendar, e.g. hebrew"`
+	CalendarName string `json:"calendarName"`
+	Year         int    `json:"year" jsonschema:"year; for the Chinese calendar, the Gregorian year the Chinese year starts in, and for the ISO calendar the week-numbering year"`
+	Month        int    `json:"month" jsonschema:"month, from 1; for the Hebrew calendar counted from Nisan, with Adar II as 13, and for the ISO calendar the week"`
+	MonthName    string `json:"monthName"`
+	Day          int    `json:"day" jsonschema:"day of the month; for the ISO calendar the weekday, from 1 for Monday"`
+	IsLeapMonth  bool   `json:"isLeapMonth" jsonschema:"whether the month is an added leap month, such as a Chinese leap month or Adar I"`
+	IsLeapYear   bool   `json:"isLeapYear" jsonschema:"whether the year has a leap day, leap month or, in the ISO calendar, 53 weeks"`
+	YearName     string `json:"yearName,omitempty" jsonschema:"name of the year in the sexagenary cycle, for the Chinese calendar"`
+	Formatted    string `json:"formatted" jsonschema:"the date written out, e.g. 9 Tishri 5786 AM"`
+}
+
+// ConvertCalendarOutput is a day in the Gregorian calendar and the others.
+type ConvertCalendarOutput struct {
+	Date            string               `json:"date" jsonschema:"the Gregorian date, YYYY-MM-DD"`
+	DayOfWeek       string               `json:"dayOfWeek"`
+	JulianDayNumber int                  `json:"julianDayNumber" jsonschema:"days since 1 January 4713 BC in the Julian calendar"`
+	Dates           []CalendarDateOutput `json:"dates"`
+}
+
+func newCalendarDateOutput(c *CalendarSystem, d CalendarDate) CalendarDateOutput {
+	return CalendarDateOutput{
+		Calendar:     c.ID,
+		CalendarName: c.Name,
+		Year:         d.Year,
+		Month:        d.Month,
+		MonthName:    c.MonthName(d),
+		Day:          d.Day,
+		IsLeapMonth:  d.LeapMonth,
+		IsLeapYear:   d.LeapYear,
+		YearName:     c.YearName(d),
+		Formatted:    c.Format(d),
+	}
+}
+
+// SunTimesOutput is the Sun's rising, setting and twilight on a day at a
+// place.  Events the Sun does not reach that day are omitted.
+type SunTimesOutput struct {
+	Date             string     `json:"date" jsonschema:"the local date, YYYY-MM-DD"`
+	Latitude         float64    `json:"latitude" jsonschema:"degrees north"`
+	Longitude        float64    `json:"longitude" jsonschema:"degrees east"`
+	TimeZone         string     `json:"timeZone" jsonschema:"IANA time zone the times are given in"`
+	Daylight         string     `json:"daylight" jsonschema:"normal, polar day (the Sun stays up) or polar night (it stays down)"`
+	SolarNoon        ZonedTime  `json:"solarNoon" jsonschema:"when the Sun crosses the meridian, at its highest"`
+	NoonElevation    float64    `json:"noonElevation" jsonschema:"the Sun's elevation at solar noon, in degrees"`
+	DayLengthSeconds int64      `json:"dayLengthSeconds" jsonschema:"seconds from sunrise to sunset: 86400 in polar day and 0 in polar night"`
+	DayLength        string     `json:"dayLength" jsonschema:"the day length in hours and minutes"`
+	Sunrise          *ZonedTime `json:"sunrise,omitempty"`
+	Sunset           *ZonedTime `json:"sunset,omitempty"`
+	CivilDawn        *ZonedTime `json:"civilDawn,omitempty" jsonschema:"start of civil twilight, when the Sun rises to 6° below the horizon"`
+	CivilDusk        *ZonedTime `json:"civilDusk,omitempty" jsonschema:"end of civil twilight"`
+	NauticalDawn     *ZonedTime `json:"nauticalDawn,omitempty" jsonschema:"start of nautical twilight, at 12° below the horizon"`
+	NauticalDusk     *ZonedTime `json:"nauticalDusk,omitempty" jsonschema:"end of nautical twilight"`
+	AstronomicalDawn *ZonedTime `json:"astronomicalDawn,omitempty" jsonschema:"start of astronomical twilight, at 18° below the horizon"`
+	AstronomicalDusk *ZonedTime `json:"astronomicalDusk,omitempty" jsonschema:"end of astronomical twilight"`
+}
+
+// SolarPositionOutput is where the Sun is in the sky at an instant.
+type SolarPositionOutput struct {
+	Time      ZonedTime `json:"time"`
+	Latitude  float64   `json:"latitude" jsonschema:"degrees north"`
+	Longitude float64   `json:"longitude" jsonschema:"degrees east"`
+	Elevation float64   `json:"elevation" jsonschema:"degrees above the horizon, corrected for refraction; negative below it"`
+	Azimuth   float64   `json:"azimuth" jsonschema:"compass bearing of the Sun, in degrees east of north"`
+	Direction string    `json:"direction" jsonschema:"the nearest compass point to the azimuth, e.g. WNW"`
+	Phase     string    `json:"phase" jsonschema:"day, civil twilight, nautical twilight, astronomical twilight or night"`
+}
+
+func newSunTimesOutput(day SunDay, date time.Time, latitude, longitude float64) SunTimesOutput {
+	crossing := func(c SunCrossing) *ZonedTime {
+		if !c.Occurs() {
+			return nil
+		}
+		z := newZonedTime(c.At)
+		return &z
+	}
+	return SunTimesOutput{
+		Date:             date.Format(dateFormat),
+		Latitude:         latitude,
+		Longitude:        longitude,
+		TimeZone:         date.Location().String(),
+		Daylight:         day.Daylight(),
+		SolarNoon:        newZonedTime(day.SolarNoon),
+		NoonElevation:    math.Round(day.NoonElevation*100) / 100,
+		DayLengthSeconds: int64(day.DayLength().Seconds()),
+		DayLength:        formatDayLength(day.DayLength()),
+		Sunrise:          crossing(day.Sunrise),
+		Sunset:           crossing(day.Sunset),
+		CivilDawn:        crossing(day.CivilDawn),
+		CivilDusk:        crossing(day.CivilDusk),
+		NauticalDawn:     crossing(day.NauticalDawn),
+		NauticalDusk:     crossing(day.NauticalDusk),
+		AstronomicalDawn: crossing(day.AstronomicalDawn),
+		AstronomicalDusk: crossing(day.AstronomicalDusk),
+	}
+}
@@ -0,0 +1,272 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	mcp_go "github.com/mark3labs/mcp-go/mcp"
+)
+
+// hoursPerWorkingDay converts work estimates given in hours to working days.
+const hoursPerWorkingDay = 8
+
+// GroundInTimePrompt tells the model the current date and time in the
+// user's time zone and how long the session has been running, so that it
+// reasons from the real clock rather than from its training data.
+func (s *Server) GroundInTimePrompt(ctx context.Context, request mcp_go.GetPromptRequest) (*mcp_go.GetPromptResult, error) {
+	loc, err := s.loadLocation(promptArgument(request, "timeZone", "UTC"))
+	if err != nil {
+		return nil, err
+	}
+	now := s.TimeManager.Now().In(loc)
+	year, week := now.ISOWeek()
+
+	lines := []string{
+		"Use the following as the current date and time.  Do not rely on your training data for today's date, and call the time tools rather than guessing when you need to work out other dates or durations.",
+		"",
+		"Current time: " + describeZoneTime(now),
+		fmt.Sprintf("Day: %s, day %d of %d, ISO week %d-W%02d", now.Weekday(), now.YearDay(), now.Year(), year, week),
+		"UTC: " + now.UTC().Format(dateTimeFormatTimeZone),
+	}
+	id := sessionID(ctx)
+	if tl, ok := s.Sessions.Timeline(id); ok {
+		started := tl.Started.In(loc)
+		lines = append(lines, fmt.Sprintf("Session: started %s, %s ago; %s so far.", started.Format(dateTimeFormatTimeZone), approximateDuration(now.Sub(started)), plural(tl.TotalCalls, "tool call")))
+	} else {
+		lines = append(lines, "Session: no history is recorded for this session.")
+	}
+	slog.InfoContext(ctx, "GroundInTimePrompt", slog.String("session_id", id), slog.String("time_zone", loc.String()))
+	return promptResult("The current date and time in "+loc.String(), lines), nil
+}
+
+// PlanSchedulePrompt asks the model to plan work against a deadline, giving
+// it the working days that are left so that it does not count them itself.
+func (s *Server) PlanSchedulePrompt(ctx context.Context, request mcp_go.GetPromptRequest) (*mcp_go.GetPromptResult, error) {
+	deadlineInput := promptArgument(request, "deadline", "")
+	estimateInput := promptArgument(request, "estimate", "")
+	if deadlineInput == "" || estimateInput == "" {
+		return nil, fmt.Errorf("both deadline and estimate must be provided")
+	}
+	b, err := s.promptBusinessCalendar(request)
+	if err != nil {
+		return nil, err
+	}
+	estimate, err := parseWorkEstimate(estimateInput)
+	if err != nil {
+		return nil, err
+	}
+	tz := promptArgument(request, "timeZone", "UTC")
+	loc, err := s.loadLocation(tz)
+	if err != nil {
+		return nil, err
+	}
+	now := s.TimeManager.Now().In(loc)
+	deadline, err := ParseTime(&TimeOpts{input: deadlineInput, reference: now, timeZone: tz})
+	if err != nil {
+		return nil, err
+	}
+	if !deadline.After(now) {
+		return nil, fmt.Errorf("the deadline %s has already passed", deadline.Format(dateTimeFormatTimeZone))
+	}
+
+	span, err := b.between(now, deadline)
+	if err != nil {
+		return nil, err
+	}
+	calendar := "no holiday calendar"
+	if id := b.calendarID(); id != "" {
+		calendar = "the " + id + " holiday calendar"
+	}
+	lines := []string{
+		"Help me plan work so that it is finished by a deadline.  Base the plan on these facts rather than on your own date arithmetic.",
+		"",
+		"Now: " + describeZoneTime(now),
+		fmt.Sprintf("Deadline: %s, %s %s, in %s", describeZoneTime(deadline), deadline.Weekday(), deadline.Format(dateFormat), approximateDuration(deadline.Sub(now))),
+		fmt.Sprintf("Working days from today until the deadline day: %s (%s, %s), using %s with a weekend of %s", plural(span.BusinessDays, "working day"), plural(span.CalendarDays, "calendar day"), plural(span.WeekendDays, "weekend day"), calendar, b.weekend),
+	}
+	if len(span.Holidays) > 0 {
+		lines = append(lines, "Holidays before the deadline: "+describeHolidays(span.Holidays))
+	}
+	lines = append(lines, fmt.Sprintf("Work estimate: %s working days (%s)", formatAmount(estimate), estimateInput))
+
+	if slack := float64(span.BusinessDays) - estimate; slack >= 0 {
+		start, _, err := b.addBusinessDays(deadline, -int(math.Ceil(estimate)), DefaultDSTPolicy)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines,
+			fmt.Sprintf("Slack: %s working days", formatAmount(slack)),
+			fmt.Sprintf("Latest start: %s %s", start.Weekday(), start.Format(dateFormat)),
+			"",
+			"Break the work into steps scheduled on working days, keep the slack in mind and point out any risks to the deadline.")
+	} else {
+		lines = append(lines,
+			fmt.Sprintf("Shortfall: the estimate exceeds the working days left by %s", formatAmount(-slack)),
+			"",
+			"The work cannot be finished on working days alone.  Suggest how to cut the scope, add people or move the deadline, and what to do first.")
+	}
+	slog.InfoContext(ctx, "PlanSchedulePrompt", slog.String("deadline", deadline.Format(dateTimeFormatTimeZone)), slog.Float64("estimate", estimate), slog.Int("business_days", span.BusinessDays))
+	return promptResult("Plan work against the deadline "+deadline.Format(dateFormat), lines), nil
+}
+
+// ExplainTimeZoneDifferencePrompt asks the model to explain the difference
+// between two time zones, giving it their offsets at an instant and how the
+// difference varies through that year.
+func (s *Server) ExplainTimeZoneDifferencePrompt(ctx context.Context, request mcp_go.GetPromptRequest) (*mcp_go.GetPromptResult, error) {
+	fromName := promptArgument(request, "fromTimeZone", "")
+	toName := promptArgument(request, "toTimeZone", "")
+	if fromName == "" || toName == "" {
+		return nil, fmt.Errorf("both fromTimeZone and toTimeZone must be provided")
+	}
+	from, err := s.loadLocation(fromName)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.loadLocation(toName)
+	if err != nil {
+		return nil, err
+	}
+	at := s.TimeManager.Now()
+	if input := promptArgument(request, "dateTime", ""); input != "" {
+		at, err = ParseTime(&TimeOpts{input: input, reference: at, timeZone: fromName})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, fromOffset := at.In(from).Zone()
+	_, toOffset := at.In(to).Zone()
+	lines := []string{
+		fmt.Sprintf("Explain the time difference between %s and %s to me, including how it affects scheduling between the two.  Use these facts rather than your own knowledge of the zones, which may be out of date.", from, to),
+		"",
+		"At " + at.UTC().Format(dateTimeFormatTimeZone) + ":",
+		fmt.Sprintf("%s: %s", from, describeZoneTime(at.In(from))),
+		fmt.Sprintf("%s: %s", to, describeZoneTime(at.In(to))),
+		describeOffsetDifference(from.String(), to.String(), toOffset-fromOffset),
+		"",
+		fmt.Sprintf("Through %d, at noon UTC each day:", at.Year()),
+	}
+	for _, d := range yearOffsetDifferences(at.Year(), from, to) {
+		lines = append(lines, fmt.Sprintf("- %s on %s", describeOffsetDifference(from.String(), to.String(), d.difference), plural(d.days, "day")))
+	}
+	slog.InfoContext(ctx, "ExplainTimeZoneDifferencePrompt", slog.String("from", from.String()), slog.String("to", to.String()), slog.String("at", at.Format(dateTimeFormatTimeZone)))
+	return promptResult(fmt.Sprintf("The time difference between %s and %s", from, to), lines), nil
+}
+
+// offsetDifference is a difference between two zones' UTC offsets, in
+// seconds, and the number of days it held.
+type offsetDifference struct {
+	difference int
+	days       int
+}
+
+// yearOffsetDifferences returns the differences between the offsets of to
+// and from on the days of year, most common first.  There is more than one
+// when only one of the zones observes daylight saving time, or they change
+// their clocks on different dates.
+func yearOffsetDifferences(year int, from, to *time.Location) []offsetDifference {
+	days := map[int]int{}
+	for d := time.Date(year, time.January, 1, 12, 0, 0, 0, time.UTC); d.Year() == year; d = d.AddDate(0, 0, 1) {
+		_, fromOffset := d.In(from).Zone()
+		_, toOffset := d.In(to).Zone()
+		days[toOffset-fromOffset]++
+	}
+	differences := make([]offsetDifference, 0, len(days))
+	for difference, n := range days {
+		differences = append(differences, offsetDifference{difference, n})
+	}
+	sort.Slice(differences, func(i, j int) bool {
+		if differences[i].days == differences[j].days {
+			return differences[i].difference < differences[j].difference
+		}
+		return differences[i].days > differences[j].days
+	})
+	return differences
+}
+
+// describeOffsetDifference says how far the clocks in zone to are ahead of
+// or behind those in zone from, given the difference of their offsets in
+// seconds.
+func describeOffsetDifference(from, to string, seconds int) string {
+	if seconds == 0 {
+		return fmt.Sprintf("%s and %s show the same time", to, from)
+	}
+	direction := "ahead of"
+	if seconds < 0 {
+		direction = "behind"
+	}
+	return fmt.Sprintf("%s is %s %s %s", to, offsetAmount(seconds), direction, from)
+}
+
+// offsetAmount says how large a difference of offsets in seconds is, e.g.
+// "5 hours 30 minutes", ignoring its sign.
+func offsetAmount(seconds int) string {
+	if seconds < 0 {
+		seconds = -seconds
+	}
+	amount := plural(seconds/3600, "hour")
+	switch minutes := seconds % 3600 / 60; {
+	case seconds < 3600:
+		amount = plural(minutes, "minute")
+	case minutes != 0:
+		amount += " " + plural(minutes, "minute")
+	}
+	return amount
+}
+
+// parseWorkEstimate reads a work estimate as a number of working days:
+// either a plain number or a duration in days and hours, with hours counted
+// at hoursPerWorkingDay a day.
+func parseWorkEstimate(input string) (float64, error) {
+	days, err := strconv.ParseFloat(strings.TrimSpace(input), 64)
+	if err != nil {
+		d, err := ParseCalendarDuration(input)
+		if err != nil {
+			return 0, err
+		}
+		if d.Years != 0 || d.Months != 0 {
+			return 0, NewInvalidDurationError(input, "give the estimate in days or hours")
+		}
+		days = float64(d.Days) + d.Clock.Hours()/hoursPerWorkingDay
+	}
+	if days <= 0 || math.IsInf(days, 0) || math.IsNaN(days) {
+		return 0, NewInvalidDurationError(input, "the estimate must be positive")
+	}
+	return days, nil
+}
+
+// promptBusinessCalendar builds the working-day rules selected by the
+// holidayCalendar argument of a prompt.
+func (s *Server) promptBusinessCalendar(request mcp_go.GetPromptRequest) (*businessCalendar, error) {
+	id := promptArgument(request, "holidayCalendar", "")
+	if id == "" {
+		return newBusinessCalendar(nil, DefaultWeekend), nil
+	}
+	calendar, err := s.Holidays.Lookup(id)
+	if err != nil {
+		return nil, err
+	}
+	return newBusinessCalendar(calendar, calendar.Weekend()), nil
+}
+
+// promptArgument returns a prompt argument with surrounding space removed,
+// or def if it is empty.
+func promptArgument(request mcp_go.GetPromptRequest, name, def string) string {
+	if v := strings.TrimSpace(request.Params.Arguments[name]); v != "" {
+		return v
+	}
+	return def
+}
+
+// promptResult returns lines as the text of a single user message.
+func promptResult(description string, lines []string) *mcp_go.GetPromptResult {
+	return mcp_go.NewGetPromptResult(description, []mcp_go.PromptMessage{
+		mcp_go.NewPromptMessage(mcp_go.RoleUser, mcp_go.NewTextContent(strings.Join(lines, "\n"))),
+	})
+}
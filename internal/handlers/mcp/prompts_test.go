@@ -0,0 +1,244 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// getPrompt gets the prompt name from s and returns the text of its
+// message.  It reports false if the request failed.
+func getPrompt(t *testing.T, s *Server, ctx context.Context, name string, arguments map[string]string) (string, bool) {
+	t.Helper()
+	msg, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "prompts/get",
+		"params":  map[string]any{"name": name, "arguments": arguments},
+	})
+	resp, ok := s.MCPServer.HandleMessage(ctx, msg).(mcp.JSONRPCResponse)
+	if !ok {
+		return "", false
+	}
+	result, ok := resp.Result.(mcp.GetPromptResult)
+	if !ok || len(result.Messages) != 1 {
+		t.Fatalf("get %s: result = %+v", name, resp.Result)
+	}
+	return result.Messages[0].Content.(mcp.TextContent).Text, true
+}
+
+func TestPrompts(t *testing.T) {
+	s := NewServer()
+	s.TimeManager = &mockTmanager{}
+	started := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	s.Sessions.Start("planner", started)
+	for i := 0; i < 3; i++ {
+		s.Sessions.Record("planner", "currentDateTime", started.Add(time.Duration(i)*time.Minute))
+	}
+	session := s.MCPServer.WithContext(context.Background(), newTestSession("planner"))
+
+	testCases := []struct {
+		desc      string
+		ctx       context.Context
+		prompt    string
+		arguments map[string]string
+		want      []string
+		wantErr   bool
+	}{
+		{
+			desc:      "Ground in time",
+			ctx:       session,
+			prompt:    "ground-in-time",
+			arguments: map[string]string{"timeZone": "America/New_York"},
+			want: []string{
+				"Current time: 2023-10-01 08:30:00 -0400 (EDT, UTC-04:00, daylight saving time)\n",
+				"Day: Sunday, day 274 of 2023, ISO week 2023-W39\n",
+				"UTC: 2023-10-01 12:30:00 +0000\n",
+				"Session: started 2023-10-01 08:00:00 -0400, 30 minutes ago; 3 tool calls so far.",
+			},
+		},
+		{
+			desc:   "Ground in time without a session",
+			ctx:    context.Background(),
+			prompt: "ground-in-time",
+			want: []string{
+				"Current time: 2023-10-01 12:30:00 +0000 (UTC, UTC+00:00, standard time)\n",
+				"Session: no history is recorded for this session.",
+			},
+		},
+		{
+			desc:      "Ground in an unknown time zone",
+			ctx:       context.Background(),
+			prompt:    "ground-in-time",
+			arguments: map[string]string{"timeZone": "Mars/Olympus"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Plan with slack",
+			ctx:       context.Background(),
+			prompt:    "plan-schedule",
+			arguments: map[string]string{"deadline": "2023-10-13 17:00", "estimate": "5", "timeZone": "America/New_York", "holidayCalendar": "US"},
+			want: []string{
+				"Deadline: 2023-10-13 17:00:00 -0400 (EDT, UTC-04:00, daylight saving time), Friday 2023-10-13, in about 12 days\n",
+				"Working days from today until the deadline day: 8 working days (12 calendar days, 3 weekend days), using the US holiday calendar with a weekend of Saturday, Sunday\n",
+				"Holidays before the deadline: Columbus Day on 2023-10-09\n",
+				"Work estimate: 5 working days (5)\n",
+				"Slack: 3 working days\n",
+				"Latest start: Thursday 2023-10-05\n",
+			},
+		},
+		{
+			desc:      "Plan with an estimate in hours",
+			ctx:       context.Background(),
+			prompt:    "plan-schedule",
+			arguments: map[string]string{"deadline": "2023-10-04", "estimate": "12h"},
+			want: []string{
+				"Working days from today until the deadline day: 2 working days (3 calendar days, 1 weekend day), using no holiday calendar with a weekend of Saturday, Sunday\n",
+				"Work estimate: 1.5 working days (12h)\n",
+				"Slack: 0.5 working days\n",
+				"Latest start: Monday 2023-10-02\n",
+			},
+		},
+		{
+			desc:      "Plan with a shortfall",
+			ctx:       context.Background(),
+			prompt:    "plan-schedule",
+			arguments: map[string]string{"deadline": "2023-10-13", "estimate": "P10D", "holidayCalendar": "US"},
+			want: []string{
+				"Shortfall: the estimate exceeds the working days left by 2\n",
+				"The work cannot be finished on working days alone.",
+			},
+		},
+		{
+			desc:      "Plan against a passed deadline",
+			ctx:       context.Background(),
+			prompt:    "plan-schedule",
+			arguments: map[string]string{"deadline": "2023-09-01", "estimate": "5"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Plan with an estimate in months",
+			ctx:       context.Background(),
+			prompt:    "plan-schedule",
+			arguments: map[string]string{"deadline": "2024-09-01", "estimate": "2 months"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Plan without an estimate",
+			ctx:       context.Background(),
+			prompt:    "plan-schedule",
+			arguments: map[string]string{"deadline": "2024-09-01"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Plan with an unknown holiday calendar",
+			ctx:       context.Background(),
+			prompt:    "plan-schedule",
+			arguments: map[string]string{"deadline": "2024-09-01", "estimate": "5", "holidayCalendar": "XX"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Time zone difference now",
+			ctx:       context.Background(),
+			prompt:    "explain-timezone-difference",
+			arguments: map[string]string{"fromTimeZone": "America/New_York", "toTimeZone": "Europe/London"},
+			want: []string{
+				"America/New_York: 2023-10-01 08:30:00 -0400 (EDT, UTC-04:00, daylight saving time)\n",
+				"Europe/London: 2023-10-01 13:30:00 +0100 (BST, UTC+01:00, daylight saving time)\n",
+				"Europe/London is 5 hours ahead of America/New_York\n",
+				"Through 2023, at noon UTC each day:\n- Europe/London is 5 hours ahead of America/New_York on 344 days\n- Europe/London is 4 hours ahead of America/New_York on 21 days",
+			},
+		},
+		{
+			desc:      "Time zone difference at a date",
+			ctx:       context.Background(),
+			prompt:    "explain-timezone-difference",
+			arguments: map[string]string{"fromTimeZone": "Asia/Kolkata", "toTimeZone": "UTC", "dateTime": "2024-01-15 09:00"},
+			want: []string{
+				"At 2024-01-15 03:30:00 +0000:\n",
+				"UTC is 5 hours 30 minutes behind Asia/Kolkata\n",
+				"Through 2024, at noon UTC each day:\n- UTC is 5 hours 30 minutes behind Asia/Kolkata on 366 days",
+			},
+		},
+		{
+			desc:      "Time zone difference under an hour",
+			ctx:       context.Background(),
+			prompt:    "explain-timezone-difference",
+			arguments: map[string]string{"fromTimeZone": "Asia/Kolkata", "toTimeZone": "Asia/Kathmandu"},
+			want:      []string{"Asia/Kathmandu is 15 minutes ahead of Asia/Kolkata\n"},
+		},
+		{
+			desc:      "Time zone difference without a second zone",
+			ctx:       context.Background(),
+			prompt:    "explain-timezone-difference",
+			arguments: map[string]string{"fromTimeZone": "Asia/Kolkata"},
+			wantErr:   true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, ok := getPrompt(t, s, tc.ctx, tc.prompt, tc.arguments)
+			if tc.wantErr {
+				if ok {
+					t.Errorf("get %s = %q, want an error", tc.prompt, got)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("get %s failed", tc.prompt)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("prompt text = %q, want it to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPromptCompletion(t *testing.T) {
+	s := NewServer()
+	s.TimeManager = &mockTmanager{}
+
+	testCases := []struct {
+		desc     string
+		prompt   string
+		argument string
+		value    string
+		want     string
+	}{
+		{desc: "Time zone", prompt: "ground-in-time", argument: "timeZone", value: "europe/lond", want: "Europe/London"},
+		{desc: "From time zone by city", prompt: "explain-timezone-difference", argument: "fromTimeZone", value: "kolk", want: "Asia/Kolkata"},
+		{desc: "To time zone", prompt: "explain-timezone-difference", argument: "toTimeZone", value: "America/New_Y", want: "America/New_York"},
+		{desc: "Time zone with a space", prompt: "ground-in-time", argument: "timeZone", value: "America/New Y", want: "America/New_York"},
+		{desc: "Time zone with a typing mistake", prompt: "ground-in-time", argument: "timeZone", value: "Amercia/New_Yo", want: "America/New_York"},
+		{desc: "Time zone by old name", prompt: "ground-in-time", argument: "timeZone", value: "Asia/Calc", want: "Asia/Kolkata"},
+		{desc: "Holiday calendar", prompt: "plan-schedule", argument: "holidayCalendar", value: "u", want: "UK, US"},
+		{desc: "Free-form argument", prompt: "plan-schedule", argument: "estimate", value: "3", want: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			msg, _ := json.Marshal(map[string]any{
+				"jsonrpc": "2.0",
+				"id":      1,
+				"method":  "completion/complete",
+				"params": map[string]any{
+					"ref":      map[string]any{"type": "ref/prompt", "name": tc.prompt},
+					"argument": map[string]any{"name": tc.argument, "value": tc.value},
+				},
+			})
+			resp, ok := s.MCPServer.HandleMessage(context.Background(), msg).(mcp.JSONRPCResponse)
+			if !ok {
+				t.Fatalf("complete %s %s: no result", tc.prompt, tc.argument)
+			}
+			got := resp.Result.(mcp.CompleteResult).Completion
+			if strings.Join(got.Values, ", ") != tc.want {
+				t.Errorf("completion = %v, want %s", got.Values, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,637 @@
+package mcp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// relativeResult is the outcome of interpreting a natural-language date
+// expression such as "next Tuesday at 3pm" or "in 2 weeks".
+type relativeResult struct {
+	// t is either an exact instant or, when exact is false, a wall-clock time
+	// whose fields (read in UTC) still need to be resolved in the target zone.
+	t           time.Time
+	exact       bool
+	explanation string
+}
+
+// relativeParser interprets natural-language expressions relative to a
+// reference instant in a given zone.
+type relativeParser struct {
+	now    time.Time
+	loc    *time.Location
+	policy DSTPolicy
+	steps  []string
+}
+
+// parseRelative interprets input relative to now, in loc.
+func parseRelative(input string, now time.Time, loc *time.Location, policy DSTPolicy) (relativeResult, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	p := &relativeParser{now: now.In(loc), loc: loc, policy: policy}
+	tokens := tokenizeRelative(input)
+	if len(tokens) == 0 {
+		return relativeResult{}, NewInvalidDateExpressionError(input, "expression is empty")
+	}
+
+	dateTokens, hour, min, sec, hasClock, err := splitClock(tokens)
+	if err != nil {
+		return relativeResult{}, NewInvalidDateExpressionError(input, err.Error())
+	}
+
+	var r relativeResult
+	if len(dateTokens) == 0 {
+		r = relativeResult{t: p.today()}
+		p.step("no date given, so today (%s) is used", p.today().Format(dateFormat))
+	} else {
+		r, err = p.parseDate(dateTokens)
+		if err != nil {
+			return relativeResult{}, NewInvalidDateExpressionError(input, err.Error())
+		}
+	}
+
+	if hasClock {
+		if r.exact {
+			return relativeResult{}, NewInvalidDateExpressionError(input, "a time of day cannot be combined with an exact offset such as \"now\" or \"in 2 hours\"")
+		}
+		y, m, d := r.t.Date()
+		r.t = time.Date(y, m, d, hour, min, sec, 0, time.UTC)
+		p.step("time of day set to %02d:%02d:%02d", hour, min, sec)
+	}
+
+	r.explanation = strings.Join(p.steps, "; ")
+	return r, nil
+}
+
+func (p *relativeParser) step(format string, args ...any) {
+	p.steps = append(p.steps, fmt.Sprintf(format, args...))
+}
+
+// wall returns the reference time's wall clock as a naive UTC time.
+func (p *relativeParser) wall() time.Time {
+	y, m, d := p.now.Date()
+	h, min, s := p.now.Clock()
+	return time.Date(y, m, d, h, min, s, p.now.Nanosecond(), time.UTC)
+}
+
+// today returns midnight of the reference date as a naive UTC time.
+func (p *relativeParser) today() time.Time {
+	y, m, d := p.now.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// parseDate interprets the date portion of an expression.
+func (p *relativeParser) parseDate(tokens []string) (relativeResult, error) {
+	if tokens[0] == "on" && len(tokens) > 1 {
+		tokens = tokens[1:]
+	}
+	phrase := strings.Join(tokens, " ")
+
+	switch phrase {
+	case "now", "right now":
+		p.step("\"now\" is %s", p.now.Format(dateTimeFormatTimeZone))
+		return relativeResult{t: p.now, exact: true}, nil
+	case "today":
+		p.step("today is %s", p.today().Format(dateFormat))
+		return relativeResult{t: p.today()}, nil
+	case "tomorrow":
+		t := p.today().AddDate(0, 0, 1)
+		p.step("tomorrow is %s", t.Format(dateFormat))
+		return relativeResult{t: t}, nil
+	case "yesterday":
+		t := p.today().AddDate(0, 0, -1)
+		p.step("yesterday was %s", t.Format(dateFormat))
+		return relativeResult{t: t}, nil
+	case "day after tomorrow", "the day after tomorrow":
+		t := p.today().AddDate(0, 0, 2)
+		p.step("the day after tomorrow is %s", t.Format(dateFormat))
+		return relativeResult{t: t}, nil
+	case "day before yesterday", "the day before yesterday":
+		t := p.today().AddDate(0, 0, -2)
+		p.step("the day before yesterday was %s", t.Format(dateFormat))
+		return relativeResult{t: t}, nil
+	}
+
+	// "<n> <unit> before|after <expression>"
+	for i, tok := range tokens {
+		if (tok == "before" || tok == "after") && i > 0 && i < len(tokens)-1 {
+			offset, err := parseOffsetList(tokens[:i])
+			if err != nil {
+				continue
+			}
+			anchor, err := p.parseDate(tokens[i+1:])
+			if err != nil {
+				return relativeResult{}, err
+			}
+			sign := 1
+			if tok == "before" {
+				sign = -1
+			}
+			return p.applyOffset(anchor, strings.Join(tokens[i+1:], " "), offset, sign)
+		}
+	}
+
+	// "in <n> <unit>", "<n> <unit> ago", "<n> <unit> from now"
+	switch {
+	case tokens[0] == "in" && len(tokens) > 1:
+		if offset, err := parseOffsetList(tokens[1:]); err == nil {
+			return p.applyOffset(relativeResult{t: p.wall()}, "now", offset, 1)
+		}
+	case tokens[len(tokens)-1] == "ago":
+		if offset, err := parseOffsetList(tokens[:len(tokens)-1]); err == nil {
+			return p.applyOffset(relativeResult{t: p.wall()}, "now", offset, -1)
+		}
+	case tokens[len(tokens)-1] == "later" || tokens[len(tokens)-1] == "hence":
+		if offset, err := parseOffsetList(tokens[:len(tokens)-1]); err == nil {
+			return p.applyOffset(relativeResult{t: p.wall()}, "now", offset, 1)
+		}
+	case len(tokens) > 2 && tokens[len(tokens)-2] == "from" && tokens[len(tokens)-1] == "now":
+		if offset, err := parseOffsetList(tokens[:len(tokens)-2]); err == nil {
+			return p.applyOffset(relativeResult{t: p.wall()}, "now", offset, 1)
+		}
+	}
+
+	// "start of next month", "end of the year", "beginning of June 2025"
+	if len(tokens) > 2 && tokens[1] == "of" && (tokens[0] == "start" || tokens[0] == "beginning" || tokens[0] == "end") {
+		start, end, name, err := p.parsePeriod(tokens[2:])
+		if err != nil {
+			return relativeResult{}, err
+		}
+		if tokens[0] == "end" {
+			last := end.AddDate(0, 0, -1)
+			t := time.Date(last.Year(), last.Month(), last.Day(), 23, 59, 59, 0, time.UTC)
+			p.step("the end of %s is the last second of %s", name, last.Format(dateFormat))
+			return relativeResult{t: t}, nil
+		}
+		p.step("the start of %s is %s", name, start.Format(dateFormat))
+		return relativeResult{t: start}, nil
+	}
+
+	// "the first Monday of June", "last Friday of next month"
+	if t, ok, err := p.parseNthWeekday(tokens); ok || err != nil {
+		return relativeResult{t: t}, err
+	}
+
+	// "next Tuesday", "last Friday", "this Wednesday", "Monday"
+	if t, ok := p.parseWeekdayPhrase(tokens); ok {
+		return relativeResult{t: t}, nil
+	}
+
+	// "next week", "last month", "this year"
+	if len(tokens) == 2 && isRelativeQualifier(tokens[0]) {
+		if start, _, name, err := p.parsePeriod(tokens); err == nil {
+			p.step("%s starts on %s", name, start.Format(dateFormat))
+			return relativeResult{t: start}, nil
+		}
+	}
+
+	// "June", "June 2025"
+	if isMonth(tokens[0]) && (len(tokens) == 1 || (len(tokens) == 2 && len(tokens[1]) == 4)) {
+		if start, _, name, err := p.parsePeriod(tokens); err == nil {
+			p.step("%s starts on %s", name, start.Format(dateFormat))
+			return relativeResult{t: start}, nil
+		}
+	}
+
+	// "June 3", "3rd of June 2025", "Jun 3, 2025"
+	if t, ok, err := p.parseMonthDay(tokens); ok || err != nil {
+		return relativeResult{t: t}, err
+	}
+
+	return relativeResult{}, fmt.Errorf("could not interpret %q", phrase)
+}
+
+// relativeOffset is an amount of calendar and clock time.
+type relativeOffset struct {
+	years, months, days int
+	clock               time.Duration
+	text                []string
+}
+
+// parseOffsetList parses "<n> <unit> [and] <n> <unit> ...".
+func parseOffsetList(tokens []string) (relativeOffset, error) {
+	var off relativeOffset
+	if len(tokens) == 0 {
+		return off, fmt.Errorf("missing amount")
+	}
+	for i := 0; i < len(tokens); {
+		if tokens[i] == "and" {
+			i++
+			continue
+		}
+		n, ok := parseCount(tokens[i])
+		if !ok || i+1 >= len(tokens) {
+			return off, fmt.Errorf("expected an amount and a unit")
+		}
+		unit := strings.TrimSuffix(tokens[i+1], "s")
+		switch unit {
+		case "second", "sec":
+			off.clock += time.Duration(n) * time.Second
+		case "minute", "min":
+			off.clock += time.Duration(n) * time.Minute
+		case "hour", "hr":
+			off.clock += time.Duration(n) * time.Hour
+		case "day":
+			off.days += n
+		case "week":
+			off.days += 7 * n
+		case "fortnight":
+			off.days += 14 * n
+		case "month":
+			off.months += n
+		case "year":
+			off.years += n
+		case "decade":
+			off.years += 10 * n
+		default:
+			return off, fmt.Errorf("unknown unit %q", tokens[i+1])
+		}
+		off.text = append(off.text, tokens[i]+" "+tokens[i+1])
+		i += 2
+	}
+	return off, nil
+}
+
+// applyOffset shifts anchor by sign*off.  Calendar units move the wall clock
+// so that "in 2 weeks" keeps the time of day across DST changes; clock units
+// move the instant.
+func (p *relativeParser) applyOffset(anchor relativeResult, anchorName string, off relativeOffset, sign int) (relativeResult, error) {
+	direction := "after"
+	if sign < 0 {
+		direction = "before"
+	}
+	amount := strings.Join(off.text, " and ")
+
+	t := anchor.t
+	if anchor.exact && (off.years != 0 || off.months != 0 || off.days != 0) {
+		t = t.In(p.loc)
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+		anchor.exact = false
+	}
+	t = t.AddDate(sign*off.years, sign*off.months, sign*off.days)
+	if off.clock == 0 {
+		p.step("%s %s %s is %s", amount, direction, anchorName, t.Format(dateTimeFormat))
+		return relativeResult{t: t, exact: anchor.exact}, nil
+	}
+
+	if !anchor.exact {
+		wc, err := resolveWallClock(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), p.loc, p.policy)
+		if err != nil {
+			return relativeResult{}, err
+		}
+		t = wc.Time
+	}
+	t = t.Add(time.Duration(sign) * off.clock)
+	p.step("%s %s %s is %s", amount, direction, anchorName, t.In(p.loc).Format(dateTimeFormatTimeZone))
+	return relativeResult{t: t, exact: true}, nil
+}
+
+// parsePeriod interprets "this week", "next month", "the year", "June 2025",
+// "2025" and similar, returning the half-open range [start, end) as naive
+// UTC dates.
+func (p *relativeParser) parsePeriod(tokens []string) (time.Time, time.Time, string, error) {
+	name := strings.Join(tokens, " ")
+	qualifier := "this"
+	if isRelativeQualifier(tokens[0]) || tokens[0] == "the" {
+		if tokens[0] != "the" {
+			qualifier = tokens[0]
+		}
+		tokens = tokens[1:]
+	}
+	if len(tokens) == 0 {
+		return time.Time{}, time.Time{}, "", fmt.Errorf("missing period in %q", name)
+	}
+	shift := map[string]int{"this": 0, "next": 1, "last": -1, "previous": -1}[qualifier]
+
+	today := p.today()
+	if len(tokens) == 1 {
+		switch tokens[0] {
+		case "day":
+			start := today.AddDate(0, 0, shift)
+			return start, start.AddDate(0, 0, 1), name, nil
+		case "week":
+			start := today.AddDate(0, 0, -((int(today.Weekday())+6)%7)+7*shift)
+			return start, start.AddDate(0, 0, 7), name, nil
+		case "month":
+			start := time.Date(today.Year(), today.Month()+time.Month(shift), 1, 0, 0, 0, 0, time.UTC)
+			return start, start.AddDate(0, 1, 0), name, nil
+		case "quarter":
+			q := (int(today.Month()) - 1) / 3
+			start := time.Date(today.Year(), time.Month(3*(q+shift)+1), 1, 0, 0, 0, 0, time.UTC)
+			return start, start.AddDate(0, 3, 0), name, nil
+		case "year":
+			start := time.Date(today.Year()+shift, time.January, 1, 0, 0, 0, 0, time.UTC)
+			return start, start.AddDate(1, 0, 0), name, nil
+		}
+		if year, ok := parseYear(tokens[0]); ok && qualifier == "this" {
+			start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+			return start, start.AddDate(1, 0, 0), name, nil
+		}
+	}
+
+	if month, ok := lookupMonth(tokens[0]); ok && len(tokens) <= 2 {
+		year := today.Year() + shift
+		if len(tokens) == 2 {
+			y, ok := parseYear(tokens[1])
+			if !ok {
+				return time.Time{}, time.Time{}, "", fmt.Errorf("invalid year %q", tokens[1])
+			}
+			year = y
+		}
+		start := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0), name, nil
+	}
+	return time.Time{}, time.Time{}, "", fmt.Errorf("unknown period %q", name)
+}
+
+// parseNthWeekday interprets "[the] <ordinal> <weekday> of <month>".
+func (p *relativeParser) parseNthWeekday(tokens []string) (time.Time, bool, error) {
+	if tokens[0] == "the" {
+		tokens = tokens[1:]
+	}
+	if len(tokens) < 4 || tokens[2] != "of" {
+		return time.Time{}, false, nil
+	}
+	n, ok := parseOrdinal(tokens[0])
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	weekday, ok := lookupWeekday(tokens[1])
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	start, end, name, err := p.parsePeriod(tokens[3:])
+	if err != nil {
+		return time.Time{}, true, err
+	}
+
+	var t time.Time
+	if n < 0 {
+		last := end.AddDate(0, 0, -1)
+		t = last.AddDate(0, 0, -((int(last.Weekday()) - int(weekday) + 7) % 7))
+	} else {
+		first := start.AddDate(0, 0, (int(weekday)-int(start.Weekday())+7)%7)
+		t = first.AddDate(0, 0, 7*(n-1))
+	}
+	if t.Before(start) || !t.Before(end) {
+		return time.Time{}, true, fmt.Errorf("%s has no %s %s", name, tokens[0], weekday)
+	}
+	p.step("the %s %s of %s is %s", tokens[0], weekday, name, t.Format(dateFormat))
+	return t, true, nil
+}
+
+// parseWeekdayPhrase interprets "[next|last|this] <weekday>".
+func (p *relativeParser) parseWeekdayPhrase(tokens []string) (time.Time, bool) {
+	qualifier := "this"
+	if len(tokens) == 2 && isRelativeQualifier(tokens[0]) {
+		qualifier = tokens[0]
+		tokens = tokens[1:]
+	}
+	if len(tokens) != 1 {
+		return time.Time{}, false
+	}
+	weekday, ok := lookupWeekday(tokens[0])
+	if !ok {
+		return time.Time{}, false
+	}
+
+	today := p.today()
+	var t time.Time
+	switch qualifier {
+	case "next":
+		days := (int(weekday) - int(today.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		t = today.AddDate(0, 0, days)
+		p.step("the next %s after %s %s is %s", weekday, today.Weekday(), today.Format(dateFormat), t.Format(dateFormat))
+	case "last", "previous":
+		days := (int(today.Weekday()) - int(weekday) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		t = today.AddDate(0, 0, -days)
+		p.step("the last %s before %s %s was %s", weekday, today.Weekday(), today.Format(dateFormat), t.Format(dateFormat))
+	default:
+		t = today.AddDate(0, 0, (int(weekday)-int(today.Weekday())+7)%7)
+		p.step("the coming %s (counting today, %s %s) is %s", weekday, today.Weekday(), today.Format(dateFormat), t.Format(dateFormat))
+	}
+	return t, true
+}
+
+// parseMonthDay interprets "June 3", "June 3rd 2025", "3 June" and
+// "3rd of June 2025".  Without a year the current year is used.
+func (p *relativeParser) parseMonthDay(tokens []string) (time.Time, bool, error) {
+	if tokens[0] == "the" {
+		tokens = tokens[1:]
+	}
+	var monthTok, dayTok string
+	var rest []string
+	switch {
+	case len(tokens) >= 2 && isMonth(tokens[0]):
+		monthTok, dayTok, rest = tokens[0], tokens[1], tokens[2:]
+	case len(tokens) >= 3 && tokens[1] == "of" && isMonth(tokens[2]):
+		dayTok, monthTok, rest = tokens[0], tokens[2], tokens[3:]
+	case len(tokens) >= 2 && isMonth(tokens[1]):
+		dayTok, monthTok, rest = tokens[0], tokens[1], tokens[2:]
+	default:
+		return time.Time{}, false, nil
+	}
+	day, ok := parseOrdinal(dayTok)
+	if !ok || day < 1 {
+		return time.Time{}, false, nil
+	}
+	month, _ := lookupMonth(monthTok)
+
+	year := p.today().Year()
+	yearNote := " (the current year)"
+	switch len(rest) {
+	case 0:
+	case 1:
+		y, ok := parseYear(rest[0])
+		if !ok {
+			return time.Time{}, true, fmt.Errorf("invalid year %q", rest[0])
+		}
+		year, yearNote = y, ""
+	default:
+		return time.Time{}, false, nil
+	}
+	if day > daysIn(month, year) {
+		return time.Time{}, true, fmt.Errorf("%s %d has no day %d", month, year, day)
+	}
+	t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	p.step("%s %d, %d%s is %s", month, day, year, yearNote, t.Format(dateFormat))
+	return t, true, nil
+}
+
+// splitClock removes a time of day ("at 3pm", "15:30", "noon") from tokens.
+func splitClock(tokens []string) ([]string, int, int, int, bool, error) {
+	for i, tok := range tokens {
+		if tok == "at" && i < len(tokens)-1 {
+			for _, n := range []int{2, 1} {
+				if i+1+n > len(tokens) {
+					continue
+				}
+				if h, m, s, ok := parseClock(tokens[i+1:i+1+n], true); ok {
+					rest := append(append([]string{}, tokens[:i]...), tokens[i+1+n:]...)
+					return rest, h, m, s, true, nil
+				}
+			}
+			return nil, 0, 0, 0, false, fmt.Errorf("invalid time of day after \"at\" in %q", strings.Join(tokens, " "))
+		}
+	}
+	for _, n := range []int{2, 1} {
+		if len(tokens) >= n {
+			if h, m, s, ok := parseClock(tokens[len(tokens)-n:], false); ok {
+				return tokens[:len(tokens)-n], h, m, s, true, nil
+			}
+			if h, m, s, ok := parseClock(tokens[:n], false); ok {
+				return tokens[n:], h, m, s, true, nil
+			}
+		}
+	}
+	return tokens, 0, 0, 0, false, nil
+}
+
+// parseClock parses "3pm", "3 pm", "3:30pm", "15:30", "15:30:45", "noon" and
+// "midnight".  A bare hour such as "15" is only accepted when bare is set.
+func parseClock(tokens []string, bare bool) (int, int, int, bool) {
+	s := strings.Join(tokens, "")
+	switch s {
+	case "noon", "midday":
+		return 12, 0, 0, true
+	case "midnight":
+		return 0, 0, 0, true
+	}
+
+	meridiem := ""
+	for _, suffix := range []string{"am", "pm", "a.m.", "p.m."} {
+		if strings.HasSuffix(s, suffix) {
+			meridiem = suffix[:1]
+			s = strings.TrimSuffix(s, suffix)
+			break
+		}
+	}
+	if meridiem == "" && !bare && !strings.Contains(s, ":") {
+		return 0, 0, 0, false
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, 0, 0, false
+	}
+	var fields [3]int
+	for i, part := range parts {
+		v, err := strconv.Atoi(part)
+		if err != nil || v < 0 || (i > 0 && len(part) != 2) {
+			return 0, 0, 0, false
+		}
+		fields[i] = v
+	}
+	h, m, sec := fields[0], fields[1], fields[2]
+	if m > 59 || sec > 59 {
+		return 0, 0, 0, false
+	}
+	switch meridiem {
+	case "a":
+		if h < 1 || h > 12 {
+			return 0, 0, 0, false
+		}
+		h %= 12
+	case "p":
+		if h < 1 || h > 12 {
+			return 0, 0, 0, false
+		}
+		h = h%12 + 12
+	default:
+		if h > 23 {
+			return 0, 0, 0, false
+		}
+	}
+	return h, m, sec, true
+}
+
+func tokenizeRelative(input string) []string {
+	s := strings.ToLower(strings.TrimSpace(input))
+	s = strings.NewReplacer(",", " ", "-", " ").Replace(s)
+	return strings.Fields(s)
+}
+
+func isRelativeQualifier(s string) bool {
+	return s == "this" || s == "next" || s == "last" || s == "previous"
+}
+
+var countWords = map[string]int{
+	"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11,
+	"twelve": 12,
+}
+
+func parseCount(s string) (int, bool) {
+	if n, ok := countWords[s]; ok {
+		return n, true
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil && n >= 0
+}
+
+var ordinalWords = map[string]int{
+	"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5, "last": -1,
+}
+
+// parseOrdinal parses "first", "2nd", "3rd", "last" (as -1) and plain
+// numbers.
+func parseOrdinal(s string) (int, bool) {
+	if n, ok := ordinalWords[s]; ok {
+		return n, true
+	}
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		s = strings.TrimSuffix(s, suffix)
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil && n > 0
+}
+
+func parseYear(s string) (int, bool) {
+	if len(s) != 4 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil
+}
+
+var weekdayAbbreviations = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday,
+	"tues": time.Tuesday, "wed": time.Wednesday, "thu": time.Thursday,
+	"thur": time.Thursday, "thurs": time.Thursday, "fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+func lookupWeekday(s string) (time.Weekday, bool) {
+	if d, err := parseWeekday(s); err == nil {
+		return d, true
+	}
+	d, ok := weekdayAbbreviations[s]
+	return d, ok
+}
+
+func lookupMonth(s string) (time.Month, bool) {
+	if s == "sept" {
+		return time.September, true
+	}
+	for m := time.January; m <= time.December; m++ {
+		name := strings.ToLower(m.String())
+		if s == name || s == name[:3] {
+			return m, true
+		}
+	}
+	return 0, false
+}
+
+func isMonth(s string) bool {
+	_, ok := lookupMonth(s)
+	return ok
+}
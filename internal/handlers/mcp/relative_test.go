@@ -0,0 +1,69 @@
+package mcp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRelative(t *testing.T) {
+	// Sunday
+	now := time.Date(2023, 10, 1, 12, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc    string
+		input   string
+		want    time.Time
+		exact   bool
+		wantErr bool
+	}{
+		{desc: "Now", input: "now", want: now, exact: true},
+		{desc: "Yesterday", input: "yesterday", want: time.Date(2023, 9, 30, 0, 0, 0, 0, time.UTC)},
+		{desc: "Tomorrow at noon", input: "tomorrow at noon", want: time.Date(2023, 10, 2, 12, 0, 0, 0, time.UTC)},
+		{desc: "Time before date", input: "3pm tomorrow", want: time.Date(2023, 10, 2, 15, 0, 0, 0, time.UTC)},
+		{desc: "Time only", input: "at 17:45", want: time.Date(2023, 10, 1, 17, 45, 0, 0, time.UTC)},
+		{desc: "Days ago keeps time of day", input: "3 days ago", want: time.Date(2023, 9, 28, 12, 30, 0, 0, time.UTC)},
+		{desc: "In two weeks", input: "in 2 weeks", want: time.Date(2023, 10, 15, 12, 30, 0, 0, time.UTC)},
+		{desc: "Number words", input: "in a fortnight", want: time.Date(2023, 10, 15, 12, 30, 0, 0, time.UTC)},
+		{desc: "In hours is exact", input: "in 2 hours", want: time.Date(2023, 10, 1, 14, 30, 0, 0, time.UTC), exact: true},
+		{desc: "Compound offset", input: "1 day and 3 hours from now", want: time.Date(2023, 10, 2, 15, 30, 0, 0, time.UTC), exact: true},
+		{desc: "Next Tuesday at 3pm", input: "next Tuesday at 3pm", want: time.Date(2023, 10, 3, 15, 0, 0, 0, time.UTC)},
+		{desc: "Next Sunday skips today", input: "next Sunday", want: time.Date(2023, 10, 8, 0, 0, 0, 0, time.UTC)},
+		{desc: "Bare weekday counts today", input: "Sunday", want: time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{desc: "Last Friday", input: "last fri", want: time.Date(2023, 9, 29, 0, 0, 0, 0, time.UTC)},
+		{desc: "Next week starts Monday", input: "next week", want: time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC)},
+		{desc: "End of next month", input: "end of next month", want: time.Date(2023, 11, 30, 23, 59, 59, 0, time.UTC)},
+		{desc: "Start of this quarter", input: "start of this quarter", want: time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{desc: "Beginning of a named month", input: "beginning of February 2024", want: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{desc: "First Monday of June", input: "the first Monday of June", want: time.Date(2023, 6, 5, 0, 0, 0, 0, time.UTC)},
+		{desc: "Last Friday of next month", input: "last Friday of next month", want: time.Date(2023, 11, 24, 0, 0, 0, 0, time.UTC)},
+		{desc: "Fifth Monday of October", input: "5th Monday of October", want: time.Date(2023, 10, 30, 0, 0, 0, 0, time.UTC)},
+		{desc: "Month and day", input: "June 3", want: time.Date(2023, 6, 3, 0, 0, 0, 0, time.UTC)},
+		{desc: "Day of month and year", input: "3rd of June, 2025", want: time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC)},
+		{desc: "Offset before another expression", input: "3 days before end of next month", want: time.Date(2023, 11, 27, 23, 59, 59, 0, time.UTC)},
+		{desc: "No fifth Monday in November", input: "fifth Monday of November", wantErr: true},
+		{desc: "Time of day with exact offset", input: "in 2 hours at 3pm", wantErr: true},
+		{desc: "Invalid day of month", input: "February 30", wantErr: true},
+		{desc: "Gibberish", input: "when pigs fly", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := parseRelative(tc.input, now, time.UTC, DefaultDSTPolicy)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parseRelative() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if got.exact != tc.exact {
+				t.Errorf("parseRelative() exact = %v, want %v", got.exact, tc.exact)
+			}
+			if !got.t.Equal(tc.want) {
+				t.Errorf("parseRelative() got = %v, want %v", got.t, tc.want)
+			}
+			if got.explanation == "" {
+				t.Errorf("parseRelative() explanation is empty")
+			}
+		})
+	}
+}
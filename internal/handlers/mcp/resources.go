@@ -0,0 +1,152 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	mcp_go "github.com/mark3labs/mcp-go/mcp"
+)
+
+// NowResource is the current time in the time zone named in the URI, or in
+// UTC for time://now.
+func (s *Server) NowResource(ctx context.Context, request mcp_go.ReadResourceRequest) ([]mcp_go.ResourceContents, error) {
+	tz := resourceArgument(request, "timezone")
+	if tz == "" {
+		tz = "UTC"
+	}
+	loc, err := s.loadLocation(tz)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResource(request.Params.URI, newZonedTime(s.TimeManager.Now().In(loc)))
+}
+
+// TimeZonesResource lists the known time zones with their current offsets.
+func (s *Server) TimeZonesResource(ctx context.Context, request mcp_go.ReadResourceRequest) ([]mcp_go.ResourceContents, error) {
+	now := s.TimeManager.Now()
+	names := TimeZoneNames()
+	output := TimeZonesOutput{Now: now.UTC().Format(time.RFC3339), TimeZones: make([]TimeZoneOutput, 0, len(names))}
+	for _, name := range names {
+		loc, err := s.TimeManager.LoadLocation(name)
+		if err != nil {
+			continue
+		}
+		t := now.In(loc)
+		abbreviation, _ := t.Zone()
+		output.TimeZones = append(output.TimeZones, TimeZoneOutput{
+			Name:         name,
+			Abbreviation: abbreviation,
+			UTCOffset:    t.Format("-07:00"),
+			IsDST:        t.IsDST(),
+		})
+	}
+	return jsonResource(request.Params.URI, output)
+}
+
+// CalendarResource lays out the month named in the URI.
+func (s *Server) CalendarResource(ctx context.Context, request mcp_go.ReadResourceRequest) ([]mcp_go.ResourceContents, error) {
+	year, err := resourceYear(request)
+	if err != nil {
+		return nil, err
+	}
+	month, err := resourceMonth(request)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResource(request.Params.URI, newMonthCalendarOutput(year, month, time.Monday))
+}
+
+// HolidaysResource lists the holidays of the calendar and year named in the
+// URI.
+func (s *Server) HolidaysResource(ctx context.Context, request mcp_go.ReadResourceRequest) ([]mcp_go.ResourceContents, error) {
+	calendar, err := s.Holidays.Lookup(resourceArgument(request, "region"))
+	if err != nil {
+		return nil, err
+	}
+	year, err := resourceYear(request)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResource(request.Params.URI, ListHolidaysOutput{
+		Calendar:     calendar.ID(),
+		CalendarName: calendar.Name(),
+		Year:         year,
+		Holidays:     newHolidayOutputs(calendar.Holidays(year)),
+	})
+}
+
+// EventsResource lists every registered event, including those that have
+// passed.
+func (s *Server) EventsResource(ctx context.Context, request mcp_go.ReadResourceRequest) ([]mcp_go.ResourceContents, error) {
+	output, _, err := s.eventsOutput(true, OutputStyleCalendar)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResource(request.Params.URI, output)
+}
+
+// EventResource describes the event named in the URI.
+func (s *Server) EventResource(ctx context.Context, request mcp_go.ReadResourceRequest) ([]mcp_go.ResourceContents, error) {
+	e, err := s.Events.Get(resourceArgument(request, "name"))
+	if err != nil {
+		return nil, err
+	}
+	return jsonResource(request.Params.URI, newEventOutput(e, s.TimeManager.Now(), s.eventLocation(e), OutputStyleCalendar))
+}
+
+// jsonResource returns v as the JSON contents of the resource at uri.
+func jsonResource(uri string, v any) ([]mcp_go.ResourceContents, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", uri, err)
+	}
+	return []mcp_go.ResourceContents{
+		mcp_go.TextResourceContents{
+			URI:      uri,
+			MIMEType: "application/json",
+			Text:     string(data),
+		},
+	}, nil
+}
+
+// resourceYear reads the year variable of a resource template.
+func resourceYear(request mcp_go.ReadResourceRequest) (int, error) {
+	arg := resourceArgument(request, "year")
+	year, err := strconv.Atoi(arg)
+	if err != nil || year < 1 || year > 9999 {
+		return 0, fmt.Errorf("invalid year %q; expected 1 to 9999", arg)
+	}
+	return year, nil
+}
+
+// resourceMonth reads the month variable of a resource template, as a
+// number or an English name.
+func resourceMonth(request mcp_go.ReadResourceRequest) (time.Month, error) {
+	arg := resourceArgument(request, "month")
+	if month, ok := lookupMonth(strings.ToLower(arg)); ok {
+		return month, nil
+	}
+	month, err := strconv.Atoi(arg)
+	if err != nil || month < 1 || month > 12 {
+		return 0, fmt.Errorf("invalid month %q; expected 1 to 12 or a month name", arg)
+	}
+	return time.Month(month), nil
+}
+
+// resourceArgument returns a variable matched by a resource template, or ""
+// if it was not matched.
+func resourceArgument(request mcp_go.ReadResourceRequest, name string) string {
+	switch v := request.Params.Arguments[name].(type) {
+	case string:
+		return v
+	case []string:
+		if len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,185 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// readResource reads uri from s and decodes its JSON contents.  It reports
+// false if the read failed.
+func readResource(t *testing.T, s *Server, uri string) (map[string]any, bool) {
+	t.Helper()
+	msg, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "resources/read",
+		"params":  map[string]any{"uri": uri},
+	})
+	resp, ok := s.MCPServer.HandleMessage(context.Background(), msg).(mcp.JSONRPCResponse)
+	if !ok {
+		return nil, false
+	}
+	result, ok := resp.Result.(mcp.ReadResourceResult)
+	if !ok || len(result.Contents) != 1 {
+		t.Fatalf("read %s: result = %+v", uri, resp.Result)
+	}
+	var got map[string]any
+	if err := json.Unmarshal([]byte(result.Contents[0].(mcp.TextResourceContents).Text), &got); err != nil {
+		t.Fatal(err)
+	}
+	return got, true
+}
+
+// complete asks s to complete argument of the resource template uri.
+func complete(t *testing.T, s *Server, uri, argument, value string) mcp.Completion {
+	t.Helper()
+	msg, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "completion/complete",
+		"params": map[string]any{
+			"ref":      map[string]any{"type": "ref/resource", "uri": uri},
+			"argument": map[string]any{"name": argument, "value": value},
+		},
+	})
+	resp, ok := s.MCPServer.HandleMessage(context.Background(), msg).(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("complete %s %s: no result", uri, argument)
+	}
+	return resp.Result.(mcp.CompleteResult).Completion
+}
+
+func TestResources(t *testing.T) {
+	s := NewServer()
+	s.TimeManager = &mockTmanager{}
+
+	testCases := []struct {
+		desc    string
+		uri     string
+		want    map[string]any
+		wantErr bool
+	}{
+		{
+			desc: "Current time in UTC",
+			uri:  "time://now",
+			want: map[string]any{"dateTime": "2023-10-01T12:30:00Z", "dayOfWeek": "Sunday"},
+		},
+		{
+			desc: "Current time in a time zone",
+			uri:  "time://now/America/New_York",
+			want: map[string]any{"dateTime": "2023-10-01T08:30:00-04:00", "abbreviation": "EDT"},
+		},
+		{
+			desc: "Current time in an escaped time zone",
+			uri:  "time://now/Asia%2FKolkata",
+			want: map[string]any{"utcOffset": "+05:30"},
+		},
+		{
+			desc:    "Current time in an unknown time zone",
+			uri:     "time://now/Mars/Olympus",
+			wantErr: true,
+		},
+		{
+			desc: "Month calendar",
+			uri:  "time://calendar/2024/2",
+			want: map[string]any{"monthName": "February", "daysInMonth": float64(29), "grid": "February 2024\nMo Tu We Th Fr Sa Su\n          1  2  3  4\n 5  6  7  8  9 10 11\n12 13 14 15 16 17 18\n19 20 21 22 23 24 25\n26 27 28 29"},
+		},
+		{
+			desc: "Month calendar by name",
+			uri:  "time://calendar/2025/sep",
+			want: map[string]any{"month": float64(9), "daysInMonth": float64(30)},
+		},
+		{
+			desc:    "Invalid month",
+			uri:     "time://calendar/2025/13",
+			wantErr: true,
+		},
+		{
+			desc: "Holidays",
+			uri:  "time://holidays/us/2025",
+			want: map[string]any{"calendar": "US", "year": float64(2025)},
+		},
+		{
+			desc:    "Unknown holiday calendar",
+			uri:     "time://holidays/XX/2025",
+			wantErr: true,
+		},
+		{
+			desc:    "Invalid year",
+			uri:     "time://holidays/US/next",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, ok := readResource(t, s, tc.uri)
+			if tc.wantErr {
+				if ok {
+					t.Errorf("read %s = %v, want an error", tc.uri, got)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("read %s failed", tc.uri)
+			}
+			for key, want := range tc.want {
+				if got[key] != want {
+					t.Errorf("%s = %#v, want %#v", key, got[key], want)
+				}
+			}
+		})
+	}
+
+	zones, ok := readResource(t, s, "time://timezones")
+	if !ok {
+		t.Fatal("read time://timezones failed")
+	}
+	found := false
+	for _, z := range zones["timeZones"].([]any) {
+		if z := z.(map[string]any); z["name"] == "Europe/London" {
+			found = z["abbreviation"] == "BST" && z["isDST"] == true
+		}
+	}
+	if !found {
+		t.Error("time://timezones does not list Europe/London in BST")
+	}
+}
+
+func TestResourceCompletion(t *testing.T) {
+	s := NewServer()
+	s.TimeManager = &mockTmanager{}
+	s.Events.Put(Event{Name: "Q3 report"})
+
+	testCases := []struct {
+		desc     string
+		uri      string
+		argument string
+		value    string
+		want     string
+	}{
+		{desc: "Time zone by region", uri: "time://now/{+timezone}", argument: "timezone", value: "america/new_", want: "America/New_York"},
+		{desc: "Time zone by city", uri: "time://now/{+timezone}", argument: "timezone", value: "kolk", want: "Asia/Kolkata"},
+		{desc: "Year", uri: "time://calendar/{year}/{month}", argument: "year", value: "202", want: "2023, 2024, 2022, 2025, 2021, 2026, 2020, 2027, 2028"},
+		{desc: "Month number", uri: "time://calendar/{year}/{month}", argument: "month", value: "1", want: "1, 10, 11, 12"},
+		{desc: "Month name", uri: "time://calendar/{year}/{month}", argument: "month", value: "ju", want: "June, July"},
+		{desc: "Holiday calendar", uri: "time://holidays/{region}/{year}", argument: "region", value: "u", want: "UK, US"},
+		{desc: "Event name", uri: "time://events/{name}", argument: "name", value: "q", want: "Q3 report"},
+		{desc: "Unknown argument", uri: "time://events/{name}", argument: "color", value: "", want: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := complete(t, s, tc.uri, tc.argument, tc.value)
+			if strings.Join(got.Values, ", ") != tc.want {
+				t.Errorf("completion = %v, want %s", got.Values, tc.want)
+			}
+		})
+	}
+
+	if got := complete(t, s, "time://now/{+timezone}", "timezone", ""); len(got.Values) != maxCompletionValues || !got.HasMore || got.Total <= maxCompletionValues {
+		t.Errorf("completing every time zone returned %d values, total %d, hasMore %v", len(got.Values), got.Total, got.HasMore)
+	}
+}
@@ -0,0 +1,911 @@
+package mcp
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the FREQ of an RFC 5545 recurrence rule.
+type Frequency int
+
+const (
+	FreqSecondly Frequency = iota
+	FreqMinutely
+	FreqHourly
+	FreqDaily
+	FreqWeekly
+	FreqMonthly
+	FreqYearly
+)
+
+var frequencyNames = []string{"SECONDLY", "MINUTELY", "HOURLY", "DAILY", "WEEKLY", "MONTHLY", "YEARLY"}
+
+func (f Frequency) String() string {
+	return frequencyNames[f]
+}
+
+// unit is the singular English name of one period of f.
+func (f Frequency) unit() string {
+	return [...]string{"second", "minute", "hour", "day", "week", "month", "year"}[f]
+}
+
+// WeekdayNum is a BYDAY entry such as "MO", "2TU" or "-1FR".  N is zero for
+// every such weekday in the period.
+type WeekdayNum struct {
+	N       int
+	Weekday time.Weekday
+}
+
+var icalWeekdays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+func (w WeekdayNum) String() string {
+	if w.N == 0 {
+		return icalWeekdays[w.Weekday]
+	}
+	return strconv.Itoa(w.N) + icalWeekdays[w.Weekday]
+}
+
+// RecurrenceRule is a parsed RFC 5545 RRULE.  BYWEEKNO is not supported.
+type RecurrenceRule struct {
+	Freq     Frequency
+	Interval int
+	// Count and Until bound the recurrence; at most one is set.
+	Count      int
+	Until      time.Time
+	ByMonth    []int
+	ByMonthDay []int
+	ByYearDay  []int
+	ByDay      []WeekdayNum
+	ByHour     []int
+	ByMinute   []int
+	BySecond   []int
+	BySetPos   []int
+	WeekStart  time.Weekday
+}
+
+// ParseRRule parses an RRULE value such as "FREQ=MONTHLY;BYDAY=-1FR;COUNT=6",
+// with or without an "RRULE:" prefix.  A floating or date-only UNTIL is read
+// in loc.
+func ParseRRule(input string, loc *time.Location) (*RecurrenceRule, error) {
+	s := strings.TrimSpace(input)
+	if len(s) >= 6 && strings.EqualFold(s[:6], "RRULE:") {
+		s = s[6:]
+	}
+	if s == "" {
+		return nil, NewInvalidRecurrenceRuleError(input, "rule is empty")
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	r := &RecurrenceRule{Freq: -1, Interval: 1, WeekStart: time.Monday}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if !ok || value == "" {
+			return nil, NewInvalidRecurrenceRuleError(input, fmt.Sprintf("expected NAME=VALUE but got %q", part))
+		}
+		if seen[name] {
+			return nil, NewInvalidRecurrenceRuleError(input, name+" is given more than once")
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			r.Freq = -1
+			for i, f := range frequencyNames {
+				if value == f {
+					r.Freq = Frequency(i)
+				}
+			}
+			if r.Freq < 0 {
+				err = fmt.Errorf("unknown frequency %q", value)
+			}
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+			if err == nil && r.Interval < 1 {
+				err = fmt.Errorf("INTERVAL must be a positive integer")
+			}
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+			if err == nil && r.Count < 1 {
+				err = fmt.Errorf("COUNT must be a positive integer")
+			}
+		case "UNTIL":
+			r.Until, err = parseICalUntil(value, loc)
+		case "BYMONTH":
+			r.ByMonth, err = parseIntList(value, 1, 12, false)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseIntList(value, 1, 31, true)
+		case "BYYEARDAY":
+			r.ByYearDay, err = parseIntList(value, 1, 366, true)
+		case "BYHOUR":
+			r.ByHour, err = parseIntList(value, 0, 23, false)
+		case "BYMINUTE":
+			r.ByMinute, err = parseIntList(value, 0, 59, false)
+		case "BYSECOND":
+			r.BySecond, err = parseIntList(value, 0, 59, false)
+		case "BYSETPOS":
+			r.BySetPos, err = parseIntList(value, 1, 366, true)
+		case "BYDAY":
+			r.ByDay, err = parseByDay(value)
+		case "WKST":
+			r.WeekStart, err = parseICalWeekday(value)
+		case "BYWEEKNO":
+			err = fmt.Errorf("BYWEEKNO is not supported")
+		default:
+			err = fmt.Errorf("unknown rule part %s", name)
+		}
+		if err != nil {
+			return nil, NewInvalidRecurrenceRuleError(input, err.Error())
+		}
+	}
+
+	if err := r.validate(); err != nil {
+		return nil, NewInvalidRecurrenceRuleError(input, err.Error())
+	}
+	return r, nil
+}
+
+func (r *RecurrenceRule) validate() error {
+	switch {
+	case r.Freq < 0:
+		return fmt.Errorf("FREQ is required")
+	case r.Count > 0 && !r.Until.IsZero():
+		return fmt.Errorf("COUNT and UNTIL cannot both be given")
+	case len(r.ByMonthDay) > 0 && r.Freq == FreqWeekly:
+		return fmt.Errorf("BYMONTHDAY cannot be used with FREQ=WEEKLY")
+	case len(r.ByYearDay) > 0 && (r.Freq == FreqDaily || r.Freq == FreqWeekly || r.Freq == FreqMonthly):
+		return fmt.Errorf("BYYEARDAY cannot be used with FREQ=%s", r.Freq)
+	case len(r.BySetPos) > 0 && len(r.ByMonth)+len(r.ByMonthDay)+len(r.ByYearDay)+len(r.ByDay)+len(r.ByHour)+len(r.ByMinute)+len(r.BySecond) == 0:
+		return fmt.Errorf("BYSETPOS needs another BYxxx rule part")
+	}
+	for _, d := range r.ByDay {
+		if d.N == 0 {
+			continue
+		}
+		if r.Freq != FreqMonthly && r.Freq != FreqYearly {
+			return fmt.Errorf("BYDAY ordinals such as %s need FREQ=MONTHLY or FREQ=YEARLY", d)
+		}
+		if r.Freq == FreqMonthly && (d.N > 5 || d.N < -5) {
+			return fmt.Errorf("BYDAY ordinal %s is out of range for a month", d)
+		}
+	}
+	return nil
+}
+
+func parseIntList(value string, min, max int, allowNegative bool) ([]int, error) {
+	var out []int
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(item))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", item)
+		}
+		abs := n
+		if n < 0 && allowNegative {
+			abs = -n
+		}
+		if abs < min || abs > max {
+			return nil, fmt.Errorf("%d is out of range", n)
+		}
+		out = append(out, n)
+	}
+	return out, nil
+}
+
+func parseICalWeekday(value string) (time.Weekday, error) {
+	for i, name := range icalWeekdays {
+		if value == name {
+			return time.Weekday(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday %q", value)
+}
+
+func parseByDay(value string) ([]WeekdayNum, error) {
+	var out []WeekdayNum
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) < 2 {
+			return nil, fmt.Errorf("unknown weekday %q", item)
+		}
+		weekday, err := parseICalWeekday(item[len(item)-2:])
+		if err != nil {
+			return nil, err
+		}
+		var n int
+		if prefix := item[:len(item)-2]; prefix != "" {
+			n, err = strconv.Atoi(strings.TrimPrefix(prefix, "+"))
+			if err != nil || n == 0 || n > 53 || n < -53 {
+				return nil, fmt.Errorf("invalid BYDAY ordinal in %q", item)
+			}
+		}
+		out = append(out, WeekdayNum{N: n, Weekday: weekday})
+	}
+	return out, nil
+}
+
+// parseICalUntil parses an UNTIL value.  A date-only value includes the whole
+// day.
+func parseICalUntil(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("20060102T150405", value); err == nil {
+		wc, err := resolveWallClock(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc, DSTPolicyLater)
+		return wc.Time, err
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		wc, err := resolveWallClock(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc, DSTPolicyEarlier)
+		return wc.Time.Add(-time.Nanosecond), err
+	}
+	return time.Time{}, fmt.Errorf("UNTIL must be YYYYMMDD, YYYYMMDDTHHMMSS or YYYYMMDDTHHMMSSZ")
+}
+
+// String formats r as an RRULE value.
+func (r *RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + r.Freq.String()}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	for _, p := range []struct {
+		name   string
+		values []int
+	}{
+		{"BYMONTH", r.ByMonth}, {"BYMONTHDAY", r.ByMonthDay}, {"BYYEARDAY", r.ByYearDay},
+	} {
+		if len(p.values) > 0 {
+			parts = append(parts, p.name+"="+joinInts(p.values))
+		}
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			days[i] = d.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	for _, p := range []struct {
+		name   string
+		values []int
+	}{
+		{"BYHOUR", r.ByHour}, {"BYMINUTE", r.ByMinute}, {"BYSECOND", r.BySecond}, {"BYSETPOS", r.BySetPos},
+	} {
+		if len(p.values) > 0 {
+			parts = append(parts, p.name+"="+joinInts(p.values))
+		}
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+icalWeekdays[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+func joinInts(values []int) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = strconv.Itoa(v)
+	}
+	return strings.Join(s, ",")
+}
+
+// maxRecurrencePeriods bounds how many periods are searched, so that rules
+// which never or only rarely match still terminate.
+const maxRecurrencePeriods = 200000
+
+// gregorianCyclePeriods is how many periods of each frequency span the 400
+// years after which the Gregorian calendar repeats.  Whatever the interval,
+// that many periods move a rule back to the same place in the cycle, so a
+// rule that has matched nothing in that many periods in a row never will,
+// e.g. FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30.
+var gregorianCyclePeriods = map[Frequency]int{
+	FreqYearly:  400,
+	FreqMonthly: 400 * 12,
+	FreqWeekly:  146097 / 7,
+	FreqDaily:   146097,
+}
+
+// each calls yield with every occurrence of r starting from start, in order,
+// until yield returns false or the recurrence ends.  Occurrences are
+// computed on start's wall clock; a local time skipped by a DST transition
+// moves forward by the length of the gap and one repeated by a transition
+// uses its first occurrence, as RFC 5545 requires.  start itself is only an
+// occurrence if it matches the rule.
+func (r *RecurrenceRule) each(start time.Time, yield func(time.Time) bool) {
+	loc := start.Location()
+	// Rule arithmetic is done on wall-clock fields held in UTC.
+	naive := time.Date(start.Year(), start.Month(), start.Day(), start.Hour(), start.Minute(), start.Second(), 0, time.UTC)
+	p := r.plan(naive)
+
+	idleLimit, ok := gregorianCyclePeriods[r.Freq]
+	if !ok {
+		idleLimit = maxRecurrencePeriods
+	}
+	emitted, idle := 0, 0
+	cursor := r.periodStart(naive)
+	for i := 0; i < maxRecurrencePeriods && idle < idleLimit; i++ {
+		candidates := p.candidates(cursor)
+		if len(candidates) == 0 {
+			idle++
+		} else {
+			idle = 0
+		}
+		for _, c := range candidates {
+			if c.Before(naive) {
+				continue
+			}
+			wc, err := resolveWallClock(c.Year(), c.Month(), c.Day(), c.Hour(), c.Minute(), c.Second(), 0, loc, DSTPolicyEarlier)
+			if err != nil {
+				continue
+			}
+			t := wc.Time
+			if wc.Kind == WallClockGap {
+				t = wc.Later
+			}
+			if !r.Until.IsZero() && t.After(r.Until) {
+				return
+			}
+			if !yield(t) {
+				return
+			}
+			emitted++
+			if r.Count > 0 && emitted >= r.Count {
+				return
+			}
+		}
+		cursor = p.next(cursor, len(candidates) == 0)
+	}
+}
+
+// recurrencePlan holds a rule with its defaults filled in from DTSTART.
+type recurrencePlan struct {
+	*RecurrenceRule
+	start      time.Time
+	byMonth    []int
+	byMonthDay []int
+	byDay      []WeekdayNum
+}
+
+func (r *RecurrenceRule) plan(start time.Time) *recurrencePlan {
+	p := &recurrencePlan{RecurrenceRule: r, start: start, byMonth: r.ByMonth, byMonthDay: r.ByMonthDay, byDay: r.ByDay}
+	switch r.Freq {
+	case FreqYearly:
+		if len(r.ByYearDay) == 0 && len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+			p.byMonthDay = []int{start.Day()}
+			if len(r.ByMonth) == 0 {
+				p.byMonth = []int{int(start.Month())}
+			}
+		}
+	case FreqMonthly:
+		if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+			p.byMonthDay = []int{start.Day()}
+		}
+	case FreqWeekly:
+		if len(r.ByDay) == 0 {
+			p.byDay = []WeekdayNum{{Weekday: start.Weekday()}}
+		}
+	}
+	return p
+}
+
+// periodStart truncates t to the start of its period.
+func (r *RecurrenceRule) periodStart(t time.Time) time.Time {
+	switch r.Freq {
+	case FreqYearly:
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	case FreqMonthly:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case FreqWeekly:
+		back := (int(t.Weekday()) - int(r.WeekStart) + 7) % 7
+		return time.Date(t.Year(), t.Month(), t.Day()-back, 0, 0, 0, 0, time.UTC)
+	case FreqDaily:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	case FreqHourly:
+		return t.Truncate(time.Hour)
+	case FreqMinutely:
+		return t.Truncate(time.Minute)
+	default:
+		return t
+	}
+}
+
+// next advances cursor by the interval.  Sub-daily rules whose day did not
+// match skip straight to the first period of a later day.
+func (p *recurrencePlan) next(cursor time.Time, empty bool) time.Time {
+	switch p.Freq {
+	case FreqYearly:
+		return cursor.AddDate(p.Interval, 0, 0)
+	case FreqMonthly:
+		return cursor.AddDate(0, p.Interval, 0)
+	case FreqWeekly:
+		return cursor.AddDate(0, 0, 7*p.Interval)
+	case FreqDaily:
+		return cursor.AddDate(0, 0, p.Interval)
+	}
+
+	step := time.Duration(p.Interval) * map[Frequency]time.Duration{FreqHourly: time.Hour, FreqMinutely: time.Minute, FreqSecondly: time.Second}[p.Freq]
+	if empty && !p.dayMatches(cursor) {
+		nextDay := time.Date(cursor.Year(), cursor.Month(), cursor.Day()+1, 0, 0, 0, 0, time.UTC)
+		steps := (nextDay.Sub(cursor) + step - 1) / step
+		return cursor.Add(steps * step)
+	}
+	return cursor.Add(step)
+}
+
+// candidates returns the sorted wall-clock times generated by the period
+// starting at cursor, after BYSETPOS.
+func (p *recurrencePlan) candidates(cursor time.Time) []time.Time {
+	var days []time.Time
+	switch p.Freq {
+	case FreqYearly:
+		for d := cursor; d.Year() == cursor.Year(); d = d.AddDate(0, 0, 1) {
+			days = append(days, d)
+		}
+	case FreqMonthly:
+		for d := cursor; d.Month() == cursor.Month(); d = d.AddDate(0, 0, 1) {
+			days = append(days, d)
+		}
+	case FreqWeekly:
+		for i := 0; i < 7; i++ {
+			days = append(days, cursor.AddDate(0, 0, i))
+		}
+	default:
+		days = []time.Time{time.Date(cursor.Year(), cursor.Month(), cursor.Day(), 0, 0, 0, 0, time.UTC)}
+	}
+
+	hours, minutes, seconds := p.ByHour, p.ByMinute, p.BySecond
+	switch p.Freq {
+	case FreqHourly:
+		if len(hours) > 0 && !containsInt(hours, cursor.Hour()) {
+			return nil
+		}
+		hours = []int{cursor.Hour()}
+	case FreqMinutely:
+		if (len(hours) > 0 && !containsInt(hours, cursor.Hour())) || (len(minutes) > 0 && !containsInt(minutes, cursor.Minute())) {
+			return nil
+		}
+		hours, minutes = []int{cursor.Hour()}, []int{cursor.Minute()}
+	case FreqSecondly:
+		if (len(hours) > 0 && !containsInt(hours, cursor.Hour())) || (len(minutes) > 0 && !containsInt(minutes, cursor.Minute())) || (len(seconds) > 0 && !containsInt(seconds, cursor.Second())) {
+			return nil
+		}
+		hours, minutes, seconds = []int{cursor.Hour()}, []int{cursor.Minute()}, []int{cursor.Second()}
+	}
+	if len(hours) == 0 {
+		hours = []int{p.start.Hour()}
+	}
+	if len(minutes) == 0 {
+		minutes = []int{p.start.Minute()}
+	}
+	if len(seconds) == 0 {
+		seconds = []int{p.start.Second()}
+	}
+	hours, minutes, seconds = sortedInts(hours), sortedInts(minutes), sortedInts(seconds)
+
+	var out []time.Time
+	for _, d := range days {
+		if !p.dayMatches(d) {
+			continue
+		}
+		for _, h := range hours {
+			for _, m := range minutes {
+				for _, s := range seconds {
+					out = append(out, time.Date(d.Year(), d.Month(), d.Day(), h, m, s, 0, time.UTC))
+				}
+			}
+		}
+	}
+	return p.applySetPos(out)
+}
+
+// dayMatches applies the BYMONTH, BYYEARDAY, BYMONTHDAY and BYDAY parts to the
+// calendar date of d.
+func (p *recurrencePlan) dayMatches(d time.Time) bool {
+	year, month, day := d.Date()
+	if len(p.byMonth) > 0 && !containsInt(p.byMonth, int(month)) {
+		return false
+	}
+	if len(p.ByYearDay) > 0 {
+		daysInYear := 365
+		if isLeap(year) {
+			daysInYear = 366
+		}
+		if !containsInt(p.ByYearDay, d.YearDay()) && !containsInt(p.ByYearDay, d.YearDay()-daysInYear-1) {
+			return false
+		}
+	}
+	if len(p.byMonthDay) > 0 {
+		last := daysIn(month, year)
+		if !containsInt(p.byMonthDay, day) && !containsInt(p.byMonthDay, day-last-1) {
+			return false
+		}
+	}
+	if len(p.byDay) > 0 {
+		matched := false
+		for _, w := range p.byDay {
+			if w.Weekday == d.Weekday() && (w.N == 0 || p.nthInScope(d) == w.N || p.nthFromEndInScope(d) == w.N) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// nthInScope counts which occurrence of its weekday d is within its month,
+// for monthly rules and yearly rules with BYMONTH, or otherwise its year.
+func (p *recurrencePlan) nthInScope(d time.Time) int {
+	if p.Freq == FreqYearly && len(p.ByMonth) == 0 {
+		return (d.YearDay()-1)/7 + 1
+	}
+	return (d.Day()-1)/7 + 1
+}
+
+func (p *recurrencePlan) nthFromEndInScope(d time.Time) int {
+	if p.Freq == FreqYearly && len(p.ByMonth) == 0 {
+		daysInYear := 365
+		if isLeap(d.Year()) {
+			daysInYear = 366
+		}
+		return -((daysInYear-d.YearDay())/7 + 1)
+	}
+	return -((daysIn(d.Month(), d.Year())-d.Day())/7 + 1)
+}
+
+func (p *recurrencePlan) applySetPos(candidates []time.Time) []time.Time {
+	if len(p.BySetPos) == 0 || len(candidates) == 0 {
+		return candidates
+	}
+	var out []time.Time
+	for _, pos := range p.BySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(candidates) + pos
+		}
+		if i >= 0 && i < len(candidates) {
+			out = append(out, candidates[i])
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Before(out[j]) })
+	// Drop duplicates from positions that name the same candidate.
+	unique := out[:0]
+	for i, t := range out {
+		if i == 0 || !t.Equal(out[i-1]) {
+			unique = append(unique, t)
+		}
+	}
+	return unique
+}
+
+func containsInt(values []int, v int) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedInts(values []int) []int {
+	out := append([]int(nil), values...)
+	sort.Ints(out)
+	return out
+}
+
+// Recurrence is a recurrence rule anchored at DTSTART together with extra
+// (RDATE) and excluded (EXDATE) occurrences.
+type Recurrence struct {
+	Rule  *RecurrenceRule
+	Start time.Time
+	// RDates are added to, and ExDates removed from, the rule's occurrences.
+	// ExDays excludes every occurrence on a calendar date (YYYY-MM-DD).
+	RDates  []time.Time
+	ExDates []time.Time
+	ExDays  map[string]bool
+}
+
+// Occurrences returns up to limit occurrences that are not before after
+// and, unless before is zero, not after before.
+func (rec *Recurrence) Occurrences(after, before time.Time, limit int) []time.Time {
+	rdates := append([]time.Time(nil), rec.RDates...)
+	sort.Slice(rdates, func(i, j int) bool { return rdates[i].Before(rdates[j]) })
+
+	var out []time.Time
+	// emit adds t unless it is excluded or outside the window and reports
+	// whether more occurrences are wanted.
+	emit := func(t time.Time) bool {
+		if !before.IsZero() && t.After(before) {
+			return false
+		}
+		if t.Before(after) || rec.excluded(t) {
+			return true
+		}
+		if n := len(out); n > 0 && out[n-1].Equal(t) {
+			return true
+		}
+		out = append(out, t)
+		return len(out) < limit
+	}
+
+	more := true
+	if rec.Rule != nil {
+		rec.Rule.each(rec.Start, func(t time.Time) bool {
+			for len(rdates) > 0 && !rdates[0].After(t) {
+				if more = emit(rdates[0]); !more {
+					return false
+				}
+				rdates = rdates[1:]
+			}
+			more = emit(t)
+			return more
+		})
+	}
+	for _, t := range rdates {
+		if !more {
+			break
+		}
+		more = emit(t)
+	}
+	return out
+}
+
+func (rec *Recurrence) excluded(t time.Time) bool {
+	for _, ex := range rec.ExDates {
+		if ex.Equal(t) {
+			return true
+		}
+	}
+	return rec.ExDays[t.In(rec.Start.Location()).Format(dateFormat)]
+}
+
+// Describe explains the rule in English, e.g. "every 2 weeks on Monday and
+// Wednesday at 09:00, 10 times".
+func (r *RecurrenceRule) Describe(start time.Time) string {
+	// Parts left out of the rule default to DTSTART's, so describe those too.
+	p := r.plan(start)
+	var b strings.Builder
+	if r.Interval == 1 {
+		b.WriteString("every " + r.Freq.unit())
+	} else {
+		b.WriteString("every " + plural(r.Interval, r.Freq.unit()))
+	}
+
+	if len(p.byMonth) > 0 {
+		months := make([]string, len(p.byMonth))
+		for i, m := range sortedInts(p.byMonth) {
+			months[i] = time.Month(m).String()
+		}
+		b.WriteString(" in " + joinEnglish(months))
+	}
+	if len(r.ByYearDay) > 0 {
+		days := make([]string, len(r.ByYearDay))
+		for i, d := range r.ByYearDay {
+			days[i] = ordinalPhrase(d)
+		}
+		b.WriteString(" on the " + joinEnglish(days) + " day of the year")
+	}
+	if len(p.byMonthDay) > 0 {
+		days := make([]string, len(p.byMonthDay))
+		for i, d := range p.byMonthDay {
+			days[i] = ordinalPhrase(d)
+		}
+		b.WriteString(" on the " + joinEnglish(days) + " day of the month")
+	}
+	if len(p.byDay) > 0 {
+		days := make([]string, len(p.byDay))
+		scope := "month"
+		if r.Freq == FreqYearly && len(r.ByMonth) == 0 {
+			scope = "year"
+		}
+		for i, d := range p.byDay {
+			if d.N == 0 {
+				days[i] = d.Weekday.String()
+			} else {
+				days[i] = "the " + ordinalPhrase(d.N) + " " + d.Weekday.String() + " of the " + scope
+			}
+		}
+		if len(p.byMonthDay) > 0 {
+			b.WriteString(" if it is " + joinEnglish(days))
+		} else {
+			b.WriteString(" on " + joinEnglish(days))
+		}
+	}
+	if len(r.BySetPos) > 0 {
+		positions := make([]string, len(r.BySetPos))
+		for i, pos := range r.BySetPos {
+			positions[i] = ordinalPhrase(pos)
+		}
+		b.WriteString(", taking the " + joinEnglish(positions) + " such time in each " + r.Freq.unit())
+	}
+
+	if r.Freq >= FreqDaily || len(r.ByHour)+len(r.ByMinute)+len(r.BySecond) > 0 {
+		b.WriteString(r.describeTimes(start))
+	}
+
+	switch {
+	case r.Count == 1:
+		b.WriteString(", once")
+	case r.Count == 2:
+		b.WriteString(", twice")
+	case r.Count > 0:
+		fmt.Fprintf(&b, ", %d times", r.Count)
+	case !r.Until.IsZero():
+		b.WriteString(", until " + r.Until.In(start.Location()).Format(dateTimeFormatTimeZone))
+	}
+	return b.String()
+}
+
+// describeTimes lists the times of day the rule occurs at.  Sub-daily rules
+// only mention the parts they restrict.
+func (r *RecurrenceRule) describeTimes(start time.Time) string {
+	switch r.Freq {
+	case FreqHourly, FreqMinutely, FreqSecondly:
+		var parts []string
+		if len(r.ByHour) > 0 {
+			parts = append(parts, "during hours "+joinEnglish(strings.Split(joinInts(sortedInts(r.ByHour)), ",")))
+		}
+		if len(r.ByMinute) > 0 {
+			parts = append(parts, "at minutes "+joinEnglish(strings.Split(joinInts(sortedInts(r.ByMinute)), ",")))
+		}
+		if len(r.BySecond) > 0 {
+			parts = append(parts, "at seconds "+joinEnglish(strings.Split(joinInts(sortedInts(r.BySecond)), ",")))
+		}
+		if len(parts) == 0 {
+			return ""
+		}
+		return " " + strings.Join(parts, ", ")
+	}
+
+	hours, minutes, seconds := r.ByHour, r.ByMinute, r.BySecond
+	if len(hours) == 0 {
+		hours = []int{start.Hour()}
+	}
+	if len(minutes) == 0 {
+		minutes = []int{start.Minute()}
+	}
+	if len(seconds) == 0 {
+		seconds = []int{start.Second()}
+	}
+	var times []string
+	for _, h := range sortedInts(hours) {
+		for _, m := range sortedInts(minutes) {
+			for _, s := range sortedInts(seconds) {
+				if s == 0 {
+					times = append(times, fmt.Sprintf("%02d:%02d", h, m))
+				} else {
+					times = append(times, fmt.Sprintf("%02d:%02d:%02d", h, m, s))
+				}
+			}
+		}
+	}
+	return " at " + joinEnglish(times)
+}
+
+// ordinalPhrase formats 1 as "1st", -1 as "last" and -2 as "2nd to last".
+func ordinalPhrase(n int) string {
+	if n == -1 {
+		return "last"
+	}
+	if n < 0 {
+		return ordinalPhrase(-n) + " to last"
+	}
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// joinEnglish joins items as "a", "a and b" or "a, b and c".
+func joinEnglish(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	default:
+		return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+	}
+}
+
+// icalRecurrence holds the recurrence properties of iCalendar text such as
+//
+//	DTSTART;TZID=Europe/Paris:20240105T090000
+//	RRULE:FREQ=MONTHLY;BYDAY=1FR
+//	EXDATE;TZID=Europe/Paris:20240301T090000
+//
+// before they are interpreted in a time zone.  A bare rule without a
+// property name is read as RRULE.
+type icalRecurrence struct {
+	DTStart string
+	TZID    string
+	RRule   string
+	RDates  []string
+	ExDates []string
+}
+
+func parseICalRecurrence(text string) (icalRecurrence, error) {
+	var rec icalRecurrence
+	// Continuation lines start with a space or tab.
+	text = strings.NewReplacer("\r\n ", "", "\r\n\t", "", "\n ", "", "\n\t", "").Replace(text)
+	for _, line := range strings.FieldsFunc(text, func(r rune) bool { return r == '\n' || r == '\r' }) {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		head, value, ok := strings.Cut(line, ":")
+		if !ok {
+			head, value = "RRULE", line
+		}
+		params := strings.Split(head, ";")
+		name := strings.ToUpper(params[0])
+		if strings.Contains(name, "=") {
+			// "FREQ=DAILY;..." without the RRULE: prefix.
+			name, value = "RRULE", line
+		}
+		switch name {
+		case "DTSTART":
+			rec.DTStart = value
+			for _, p := range params[1:] {
+				if k, v, _ := strings.Cut(p, "="); strings.EqualFold(k, "TZID") {
+					rec.TZID = strings.Trim(v, "\"")
+				}
+			}
+		case "RRULE":
+			if rec.RRule != "" {
+				return rec, NewInvalidRecurrenceRuleError(text, "only one RRULE is supported")
+			}
+			rec.RRule = value
+		case "RDATE":
+			rec.RDates = append(rec.RDates, strings.Split(value, ",")...)
+		case "EXDATE":
+			rec.ExDates = append(rec.ExDates, strings.Split(value, ",")...)
+		default:
+			return rec, NewInvalidRecurrenceRuleError(text, "unsupported property "+name)
+		}
+	}
+	if rec.RRule == "" {
+		return rec, NewInvalidRecurrenceRuleError(text, "no RRULE given")
+	}
+	return rec, nil
+}
+
+// isICalDate reports whether value is a date without a time of day, as
+// YYYYMMDD or YYYY-MM-DD.
+func isICalDate(value string) bool {
+	value = strings.TrimSpace(value)
+	if len(value) == 10 && value[4] == '-' && value[7] == '-' {
+		value = value[:4] + value[5:7] + value[8:]
+	}
+	if len(value) != 8 {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,286 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecurrenceRuleOccurrences(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rule     string
+		timeZone string
+		start    string
+		limit    int
+		want     []string
+		wantLen  int
+	}{
+		{
+			desc:     "Daily for 10 occurrences",
+			rule:     "FREQ=DAILY;COUNT=10",
+			timeZone: "America/New_York",
+			start:    "1997-09-02T09:00:00",
+			want:     []string{"1997-09-02 09:00", "1997-09-03 09:00", "1997-09-04 09:00", "1997-09-05 09:00", "1997-09-06 09:00", "1997-09-07 09:00", "1997-09-08 09:00", "1997-09-09 09:00", "1997-09-10 09:00", "1997-09-11 09:00"},
+		},
+		{
+			desc:     "Monthly on the first Friday",
+			rule:     "FREQ=MONTHLY;COUNT=10;BYDAY=1FR",
+			timeZone: "America/New_York",
+			start:    "1997-09-05T09:00:00",
+			want:     []string{"1997-09-05 09:00", "1997-10-03 09:00", "1997-11-07 09:00", "1997-12-05 09:00", "1998-01-02 09:00", "1998-02-06 09:00", "1998-03-06 09:00", "1998-04-03 09:00", "1998-05-01 09:00", "1998-06-05 09:00"},
+		},
+		{
+			desc:     "Every other week on Monday, Wednesday and Friday until a UTC instant",
+			rule:     "FREQ=WEEKLY;INTERVAL=2;UNTIL=19971224T000000Z;WKST=SU;BYDAY=MO,WE,FR",
+			timeZone: "America/New_York",
+			start:    "1997-09-01T09:00:00",
+			limit:    100,
+			want:     []string{"1997-09-01 09:00", "1997-09-03 09:00", "1997-09-05 09:00", "1997-09-15 09:00"},
+			wantLen:  25,
+		},
+		{
+			desc:     "Last weekday of the month",
+			rule:     "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;COUNT=3",
+			timeZone: "UTC",
+			start:    "1997-09-29T09:00:00",
+			want:     []string{"1997-09-30 09:00", "1997-10-31 09:00", "1997-11-28 09:00"},
+		},
+		{
+			desc:     "Friday the 13th",
+			rule:     "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13",
+			timeZone: "America/New_York",
+			start:    "1997-09-02T09:00:00",
+			limit:    5,
+			want:     []string{"1998-02-13 09:00", "1998-03-13 09:00", "1998-11-13 09:00", "1999-08-13 09:00", "2000-10-13 09:00"},
+		},
+		{
+			desc:     "Twentieth Monday of the year",
+			rule:     "FREQ=YEARLY;BYDAY=20MO",
+			timeZone: "America/New_York",
+			start:    "1997-05-19T09:00:00",
+			limit:    3,
+			want:     []string{"1997-05-19 09:00", "1998-05-18 09:00", "1999-05-17 09:00"},
+		},
+		{
+			desc:     "Days of the year",
+			rule:     "FREQ=YEARLY;BYYEARDAY=1,100,200;COUNT=4",
+			timeZone: "UTC",
+			start:    "1997-01-01T09:00:00",
+			want:     []string{"1997-01-01 09:00", "1997-04-10 09:00", "1997-07-19 09:00", "1998-01-01 09:00"},
+		},
+		{
+			desc:     "Monthly on the 31st skips shorter months",
+			rule:     "FREQ=MONTHLY;COUNT=4",
+			timeZone: "UTC",
+			start:    "2024-01-31T12:00:00",
+			want:     []string{"2024-01-31 12:00", "2024-03-31 12:00", "2024-05-31 12:00", "2024-07-31 12:00"},
+		},
+		{
+			desc:     "Last day of the month",
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3",
+			timeZone: "UTC",
+			start:    "2024-01-15T08:00:00",
+			want:     []string{"2024-01-31 08:00", "2024-02-29 08:00", "2024-03-31 08:00"},
+		},
+		{
+			desc:     "Yearly on a leap day",
+			rule:     "FREQ=YEARLY;COUNT=2",
+			timeZone: "UTC",
+			start:    "2024-02-29T00:00:00",
+			want:     []string{"2024-02-29 00:00", "2028-02-29 00:00"},
+		},
+		{
+			desc:     "Daily across a daylight saving gap",
+			rule:     "FREQ=DAILY;COUNT=3",
+			timeZone: "America/New_York",
+			start:    "2024-03-09T02:30:00",
+			want:     []string{"2024-03-09 02:30", "2024-03-10 03:30", "2024-03-11 02:30"},
+		},
+		{
+			desc:     "Every three hours until a UTC instant",
+			rule:     "FREQ=HOURLY;INTERVAL=3;UNTIL=19970902T170000Z",
+			timeZone: "UTC",
+			start:    "1997-09-02T09:00:00",
+			want:     []string{"1997-09-02 09:00", "1997-09-02 12:00", "1997-09-02 15:00"},
+		},
+		{
+			desc:     "Every 20 minutes in the morning",
+			rule:     "FREQ=MINUTELY;INTERVAL=20;BYHOUR=9,10",
+			timeZone: "UTC",
+			start:    "1997-09-02T09:00:00",
+			limit:    7,
+			want:     []string{"1997-09-02 09:00", "1997-09-02 09:20", "1997-09-02 09:40", "1997-09-02 10:00", "1997-09-02 10:20", "1997-09-02 10:40", "1997-09-03 09:00"},
+		},
+		{
+			desc:     "Daily at several times",
+			rule:     "FREQ=DAILY;BYHOUR=9,17;BYMINUTE=30;COUNT=3",
+			timeZone: "UTC",
+			start:    "2024-06-03T10:00:00",
+			want:     []string{"2024-06-03 17:30", "2024-06-04 09:30", "2024-06-04 17:30"},
+		},
+		{
+			desc:     "Last Friday in March and September",
+			rule:     "FREQ=YEARLY;BYMONTH=3,9;BYDAY=-1FR;COUNT=3",
+			timeZone: "UTC",
+			start:    "2024-01-01T09:00:00",
+			want:     []string{"2024-03-29 09:00", "2024-09-27 09:00", "2025-03-28 09:00"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			loc, err := time.LoadLocation(tc.timeZone)
+			if err != nil {
+				t.Fatal(err)
+			}
+			start, err := time.ParseInLocation("2006-01-02T15:04:05", tc.start, loc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rule, err := ParseRRule(tc.rule, loc)
+			if err != nil {
+				t.Fatalf("ParseRRule(%q) returned error: %v", tc.rule, err)
+			}
+			limit := tc.limit
+			if limit == 0 {
+				limit = 50
+			}
+			got := (&Recurrence{Rule: rule, Start: start}).Occurrences(time.Time{}, time.Time{}, limit)
+			wantLen := tc.wantLen
+			if wantLen == 0 {
+				wantLen = len(tc.want)
+			}
+			if len(got) != wantLen {
+				t.Fatalf("got %d occurrences, want %d: %v", len(got), wantLen, got)
+			}
+			for i, want := range tc.want {
+				if s := got[i].Format("2006-01-02 15:04"); s != want {
+					t.Errorf("occurrence %d = %s, want %s", i, s, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRecurrenceExclusions(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	rule, err := ParseRRule("RRULE:FREQ=DAILY;COUNT=5", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := &Recurrence{
+		Rule:    rule,
+		Start:   start,
+		RDates:  []time.Time{start.AddDate(0, 0, 10), start.Add(time.Hour)},
+		ExDates: []time.Time{start.AddDate(0, 0, 1)},
+		ExDays:  map[string]bool{"2024-01-04": true},
+	}
+	var got []string
+	for _, o := range rec.Occurrences(start.Add(time.Minute), time.Time{}, 10) {
+		got = append(got, o.Format("01-02 15:04"))
+	}
+	want := "01-01 10:00 01-03 09:00 01-05 09:00 01-11 09:00"
+	if strings.Join(got, " ") != want {
+		t.Errorf("got %v, want %s", got, want)
+	}
+}
+
+func TestRecurrenceRuleNeverMatching(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for _, text := range []string{
+		"FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+		"FREQ=YEARLY;BYYEARDAY=366;BYMONTH=1",
+		"FREQ=YEARLY;INTERVAL=3;BYMONTH=4;BYMONTHDAY=31",
+		"FREQ=MONTHLY;BYMONTH=11;BYMONTHDAY=31",
+		"FREQ=DAILY;BYMONTH=6;BYMONTHDAY=31",
+	} {
+		t.Run(text, func(t *testing.T) {
+			rule, err := ParseRRule(text, time.UTC)
+			if err != nil {
+				t.Fatal(err)
+			}
+			began := time.Now()
+			got := (&Recurrence{Rule: rule, Start: start}).Occurrences(time.Time{}, time.Time{}, 10)
+			if len(got) != 0 {
+				t.Errorf("got occurrences %v, want none", got)
+			}
+			if elapsed := time.Since(began); elapsed > time.Second {
+				t.Errorf("took %v to find no occurrences", elapsed)
+			}
+		})
+	}
+
+	// A rule that matches rarely is still found: 29 February in a year
+	// divisible by eight.
+	rule, err := ParseRRule("FREQ=YEARLY;INTERVAL=8;BYMONTH=2;BYMONTHDAY=29;COUNT=2", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := (&Recurrence{Rule: rule, Start: time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)}).Occurrences(time.Time{}, time.Time{}, 10)
+	if len(got) != 2 || got[0].Year() != 2108 || got[1].Year() != 2116 {
+		t.Errorf("got %v, want 29 February 2108 and 2116", got)
+	}
+}
+
+func TestParseRRuleErrors(t *testing.T) {
+	testCases := []struct {
+		desc string
+		rule string
+		want string
+	}{
+		{desc: "Missing FREQ", rule: "COUNT=3", want: "FREQ is required"},
+		{desc: "COUNT and UNTIL", rule: "FREQ=DAILY;COUNT=3;UNTIL=20240101", want: "COUNT and UNTIL cannot both be given"},
+		{desc: "Unsupported BYWEEKNO", rule: "FREQ=YEARLY;BYWEEKNO=20", want: "BYWEEKNO is not supported"},
+		{desc: "Ordinal in a weekly rule", rule: "FREQ=WEEKLY;BYDAY=2MO", want: "need FREQ=MONTHLY or FREQ=YEARLY"},
+		{desc: "Unknown part", rule: "FREQ=DAILY;FOO=1", want: "unknown rule part FOO"},
+		{desc: "Out of range", rule: "FREQ=MONTHLY;BYMONTHDAY=32", want: "32 is out of range"},
+		{desc: "Repeated part", rule: "FREQ=DAILY;FREQ=WEEKLY", want: "FREQ is given more than once"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := ParseRRule(tc.rule, time.UTC)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("ParseRRule(%q) error = %v, want it to contain %q", tc.rule, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestDescribeRecurrenceRule(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		rule string
+		want string
+	}{
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10", "every 2 weeks on Monday and Wednesday at 09:00, 10 times"},
+		{"FREQ=MONTHLY;BYDAY=-1FR", "every month on the last Friday of the month at 09:00"},
+		{"FREQ=MONTHLY", "every month on the 1st day of the month at 09:00"},
+		{"FREQ=YEARLY;BYDAY=20MO", "every year on the 20th Monday of the year at 09:00"},
+		{"FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13", "every month on the 13th day of the month if it is Friday at 09:00"},
+		{"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-2", "every month on Monday, Tuesday, Wednesday, Thursday and Friday, taking the 2nd to last such time in each month at 09:00"},
+		{"FREQ=HOURLY;INTERVAL=3;UNTIL=20240102T000000Z", "every 3 hours, until 2024-01-02 00:00:00 +0000"},
+		{"FREQ=DAILY;BYHOUR=9,17;BYMINUTE=30;COUNT=2", "every day at 09:30 and 17:30, twice"},
+	}
+	for _, tc := range testCases {
+		rule, err := ParseRRule(tc.rule, time.UTC)
+		if err != nil {
+			t.Fatalf("ParseRRule(%q) returned error: %v", tc.rule, err)
+		}
+		if got := rule.Describe(start); got != tc.want {
+			t.Errorf("Describe(%q) = %q, want %q", tc.rule, got, tc.want)
+		}
+	}
+}
+
+func TestParseICalRecurrence(t *testing.T) {
+	got, err := parseICalRecurrence("DTSTART;TZID=Europe/Paris:20240105T090000\nRRULE:FREQ=MONTHLY;\n BYDAY=1FR\nEXDATE;TZID=Europe/Paris:20240301T090000,20240405T090000\nRDATE:20240110")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.DTStart != "20240105T090000" || got.TZID != "Europe/Paris" || got.RRule != "FREQ=MONTHLY;BYDAY=1FR" || len(got.ExDates) != 2 || len(got.RDates) != 1 {
+		t.Errorf("parseICalRecurrence = %+v", got)
+	}
+	if _, err := parseICalRecurrence("DTSTART:20240105T090000"); err == nil {
+		t.Error("expected an error without an RRULE")
+	}
+}
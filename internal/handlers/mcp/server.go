@@ -8,53 +8,91 @@ import (
 type Server struct {
 	*mcp_go_server.MCPServer
 	TimeManager TimeManager
-	ready       bool
+	// Holidays holds the calendars available to the business-day tools.
+	Holidays *HolidayRegistry
+	// Fiscal holds the calendars available to the fiscal calendar tools.
+	Fiscal *FiscalCalendarRegistry
+	// Sessions records the tool calls of each MCP session.
+	Sessions *SessionTracker
+	// Timers holds the named timers of each session and the global ones.
+	Timers *TimerStore
+	// Events holds the named deadlines and events registered by clients.
+	Events EventStore
+	// Subscriptions records the resources each MCP session subscribed to.
+	Subscriptions *SubscriptionTracker
+	ready         bool
 }
 
 func NewServer() *Server {
 	s := &Server{
-		MCPServer: mcp_go_server.NewMCPServer(
-			"example-servers/everything",
-			"1.0.0",
-			mcp_go_server.WithToolCapabilities(true),
-			mcp_go_server.WithLogging(),
-		),
-		TimeManager: &LiveTimeManager{},
+		TimeManager:   &LiveTimeManager{},
+		Holidays:      NewHolidayRegistry(),
+		Fiscal:        NewFiscalCalendarRegistry(),
+		Sessions:      NewSessionTracker(),
+		Timers:        NewTimerStore(),
+		Events:        NewMemoryEventStore(),
+		Subscriptions: NewSubscriptionTracker(),
 	}
+	hooks := s.sessionHooks()
+	hooks.AddAfterInitialize(addTimeZoneDatabaseMeta)
+	s.MCPServer = mcp_go_server.NewMCPServer(
+		"example-servers/everything",
+		"1.0.0",
+		mcp_go_server.WithToolCapabilities(true),
+		mcp_go_server.WithResourceCapabilities(true, false),
+		mcp_go_server.WithCompletions(),
+		mcp_go_server.WithResourceCompletionProvider(s),
+		mcp_go_server.WithPromptCapabilities(false),
+		mcp_go_server.WithPromptCompletionProvider(s),
+		mcp_go_server.WithLogging(),
+		mcp_go_server.WithHooks(hooks),
+		mcp_go_server.WithToolHandlerMiddleware(s.trackSession),
+	)
 	s.MCPServer.AddTool(
 		mcp_go.NewTool(
 			"currentDateTime",
-			mcp_go.WithDescription("Get the current date and time in a specified timezone."),
+			mcp_go.WithDescription("Get the current date and time in a specified timezone.  An IANA formatted timezone can be specified (e.g. America/New_York), otherwise UTC is used."),
+			mcp_go.WithOutputSchema[ZonedTime](),
+			mcp_go.WithString("timeZone"),
 		),
 		s.CurrentDateTime)
 	s.MCPServer.AddTool(
 		mcp_go.NewTool(
 			"timeSince",
-			mcp_go.WithDescription("Calculate the time since a given date and time.  The date/time must be in the format YYYY-MM-DD HH:MM:SS or YYYY-MM-DD.  An IANA formatted timezone can be specified (e.g. America/New_York), otherwise UTC is used."),
+			mcp_go.WithDescription("Calculate the time since a given date and time.  The date/time must be an ISO 8601 date or date/time (e.g. 2024-05-01, 2024-05-01 13:45:00, 2024-05-01T13:45:00.123Z, 2024-W18-3 or 2024-122); an inline offset takes precedence over the timezone.  Natural-language phrases such as 'yesterday' or 'next Tuesday at 3pm' are also accepted.  An IANA formatted timezone can be specified (e.g. America/New_York), otherwise UTC is used.  The date/time is read as local wall-clock time in that timezone."),
+			mcp_go.WithOutputSchema[ElapsedTimeOutput](),
 			mcp_go.WithReadOnlyHintAnnotation(true),
 			mcp_go.WithString("dateTime"),
 			mcp_go.WithString("timeZone"),
+			withDSTPolicy(),
+			withOutputStyle(),
 		),
 		s.TimeSince)
 
 	s.MCPServer.AddTool(
 		mcp_go.NewTool(
 			"timeUntil",
-			mcp_go.WithDescription("Calculate the time until a given date and time.  The date/time must be in the format YYYY-MM-DD HH:MM:SS or YYYY-MM-DD.	An IANA formatted timezone can be specified (e.g. America/New_York), otherwise UTC is used."),
+			mcp_go.WithDescription("Calculate the time until a given date and time.  The date/time must be an ISO 8601 date or date/time (e.g. 2024-05-01, 2024-05-01 13:45:00, 2024-05-01T13:45:00.123Z, 2024-W18-3 or 2024-122); an inline offset takes precedence over the timezone.  Natural-language phrases such as 'yesterday' or 'next Tuesday at 3pm' are also accepted.	An IANA formatted timezone can be specified (e.g. America/New_York), otherwise UTC is used.  The date/time is read as local wall-clock time in that timezone."),
+			mcp_go.WithOutputSchema[ElapsedTimeOutput](),
 			mcp_go.WithReadOnlyHintAnnotation(true),
 			mcp_go.WithString("dateTime"),
 			mcp_go.WithString("timeZone"),
+			withDSTPolicy(),
+			withOutputStyle(),
 		),
 		s.TimeUntil)
 	s.MCPServer.AddTool(
 		mcp_go.NewTool(
 			"timeDifference",
-			mcp_go.WithDescription("Calculate the difference between two date and time values.  The date/time must be in the format YYYY-MM-DD HH:MM:SS or YYYY-MM-DD.	An IANA formatted timezone can be specified (e.g. America/New_York), otherwise UTC is used."),
+			mcp_go.WithDescription("Calculate the difference between two date and time values.  The date/time must be an ISO 8601 date or date/time (e.g. 2024-05-01, 2024-05-01 13:45:00, 2024-05-01T13:45:00.123Z, 2024-W18-3 or 2024-122); an inline offset takes precedence over the timezone.  Natural-language phrases such as 'yesterday' or 'next Tuesday at 3pm' are also accepted.	An IANA formatted timezone can be specified (e.g. America/New_York), otherwise UTC is used.  The date/time is read as local wall-clock time in that timezone."),
+			mcp_go.WithOutputSchema[TimeDifferenceOutput](),
 			mcp_go.WithReadOnlyHintAnnotation(true),
 			mcp_go.WithString("firstDateTime"),
 			mcp_go.WithString("secondDateTime"),
 			mcp_go.WithString("firstTimeZone"),
 			mcp_go.WithString("secondTimeZone"),
+			withDSTPolicy(),
+			withOutputStyle(),
 		),
 		s.TimeDifference)
 
@@ -62,6 +100,7 @@ func NewServer() *Server {
 		mcp_go.NewTool(
 			"isLeapYear",
 			mcp_go.WithDescription("Check if a given year is a leap year.  The year must be provided as a number in the format YYYY."),
+			mcp_go.WithOutputSchema[LeapYearOutput](),
 			mcp_go.WithReadOnlyHintAnnotation(true),
 			mcp_go.WithNumber("year"),
 		),
@@ -70,16 +109,99 @@ func NewServer() *Server {
 	s.MCPServer.AddTool(
 		mcp_go.NewTool(
 			"dayOfWeek",
-			mcp_go.WithDescription("Get the day of the week for a given date.	The date must be in the format YYYY-MM-DD."),
+			mcp_go.WithDescription("Get the day of the week for a given date.	The date must be an ISO 8601 date (e.g. 2024-05-01, 2024-W18-3 or 2024-122) or a phrase such as 'next Friday'."),
+			mcp_go.WithOutputSchema[DayOfWeekOutput](),
 			mcp_go.WithReadOnlyHintAnnotation(true),
 			mcp_go.WithString("dateTime"),
 		),
 		s.DayOfWeek)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"dateInfo",
+			mcp_go.WithDescription("Describe a date's place in the calendar: day of the week, ISO 8601 week number, week-year and week date, day of the year, quarter, whether the year is a leap year, and the first and last days of its week, month, quarter and year with the days remaining in each.  The date/time may be an ISO 8601 date or date/time or a phrase such as 'next Friday'; if omitted today is used."),
+			mcp_go.WithOutputSchema[DateInfoOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			mcp_go.WithString("dateTime", mcp_go.Description("Date to describe.  Defaults to today.")),
+			mcp_go.WithString("timeZone", mcp_go.Description("IANA timezone the date/time is read in and today is taken from.  Defaults to UTC.")),
+			withWeekStart(),
+		),
+		s.DateInfo)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"monthCalendar",
+			mcp_go.WithDescription("Lay out a month as a wall calendar, as text and as a grid of weeks, along with each day's weekday, day of the year and ISO week number."),
+			mcp_go.WithOutputSchema[MonthCalendarOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			mcp_go.WithNumber("year", mcp_go.Description("Year, from 1 to 9999.  Defaults to the current year.")),
+			mcp_go.WithNumber("month", mcp_go.Description("Month, from 1 to 12.  Defaults to the current month.")),
+			withWeekStart(),
+		),
+		s.MonthCalendar)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"fiscalDate",
+			mcp_go.WithDescription("Find a date's fiscal year, quarter, period and week in a fiscal calendar, with the first and last days of each and the days remaining.  Fiscal calendars may start in any month and may be 52/53-week retail calendars of 4-4-5, 4-5-4 or 5-4-4 week periods.  The date/time may be an ISO 8601 date or date/time or a phrase such as 'next Friday'; if omitted today is used."),
+			mcp_go.WithOutputSchema[FiscalDateOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			withFiscalCalendar(),
+			mcp_go.WithString("dateTime", mcp_go.Description("Date to place in the fiscal calendar.  Defaults to today.")),
+			mcp_go.WithString("timeZone", mcp_go.Description("IANA timezone the date/time is read in and today is taken from.  Defaults to UTC.")),
+		),
+		s.FiscalDate)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"fiscalPeriods",
+			mcp_go.WithDescription("List the first and last days of the quarters and periods of a fiscal year in a fiscal calendar, and of the year itself, with the number of weeks in each for 52/53-week calendars."),
+			mcp_go.WithOutputSchema[FiscalYearOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			withFiscalCalendar(),
+			mcp_go.WithNumber("fiscalYear", mcp_go.Description("Fiscal year, by the number it is named for, e.g. 2025 for FY2025.  Defaults to the current fiscal year.")),
+		),
+		s.FiscalPeriods)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"convertCalendar",
+			mcp_go.WithDescription("Convert a date between the Gregorian calendar and the Julian, ISO 8601 week date, Hebrew, Islamic (tabular, civil epoch), Persian (Solar Hijri), Chinese lunisolar, Coptic and Ethiopian calendars, with month names and leap month and leap year flags.  Give a Gregorian date/time, which may be an ISO 8601 date or a phrase such as 'next Friday' and defaults to today, or a fromCalendar with its year, month and day."),
+			mcp_go.WithOutputSchema[ConvertCalendarOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			mcp_go.WithString("dateTime", mcp_go.Description("Gregorian date to convert.  Defaults to today.")),
+			mcp_go.WithString("timeZone", mcp_go.Description("IANA timezone the date/time is read in and today is taken from.  Defaults to UTC.")),
+			mcp_go.WithString("fromCalendar", mcp_go.Enum(calendarSystemIDs()...), mcp_go.Description("Calendar of the year, month and day to convert instead of dateTime.")),
+			mcp_go.WithNumber("year", mcp_go.Description("Year in fromCalendar.  For the Chinese calendar, the Gregorian year the Chinese year starts in; for the ISO calendar, the week-numbering year.")),
+			mcp_go.WithNumber("month", mcp_go.Description("Month in fromCalendar, from 1.  Hebrew months are counted from Nisan, with Tishri as 7 and Adar II as 13; Coptic and Ethiopian years have a short thirteenth month; for the ISO calendar, the week.")),
+			mcp_go.WithNumber("day", mcp_go.Description("Day of the month in fromCalendar; for the ISO calendar, the weekday from 1 (Monday) to 7.")),
+			mcp_go.WithBoolean("leapMonth", mcp_go.Description("Whether the month is a Chinese leap month, which repeats the number of the month before it.")),
+			mcp_go.WithString("toCalendar", mcp_go.Enum(calendarSystemIDs()...), mcp_go.Description("Calendar to convert to.  Defaults to all of them.")),
+		),
+		s.ConvertCalendar)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"sunTimes",
+			mcp_go.WithDescription("Get sunrise, sunset, civil, nautical and astronomical twilight, solar noon and the day length at a latitude and longitude on a date, computed offline, with the times in an IANA timezone.  Near the poles, reports polar day or polar night and omits the events the Sun does not reach."),
+			mcp_go.WithOutputSchema[SunTimesOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			withCoordinates(),
+			mcp_go.WithString("dateTime", mcp_go.Description("Date, as an ISO 8601 date or a phrase such as 'June 3' or 'tomorrow'.  Defaults to today.")),
+			mcp_go.WithString("timeZone", mcp_go.Description("IANA timezone the date is read in and the times are given in, e.g. America/Denver.  Defaults to UTC.")),
+		),
+		s.SunTimes)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"solarPosition",
+			mcp_go.WithDescription("Get the Sun's elevation above the horizon and its compass azimuth at a latitude and longitude at an instant, and whether it is day, twilight or night there."),
+			mcp_go.WithOutputSchema[SolarPositionOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			withCoordinates(),
+			mcp_go.WithString("dateTime", mcp_go.Description("Date/time, as ISO 8601 or a phrase such as 'today at 5pm'.  Defaults to now.")),
+			mcp_go.WithString("timeZone", mcp_go.Description("IANA timezone the date/time is read in.  Defaults to UTC.")),
+		),
+		s.SolarPosition)
 
 	s.MCPServer.AddTool(
 		mcp_go.NewTool(
 			"nextOccurrence",
-			mcp_go.WithDescription("Get the next occurrence of a specified day of the week after a given date. The date must be in the format YYYY-MM-DD. The day of the week must be provided as a string (e.g. 'Monday', 'Tuesday', etc.)."),
+			mcp_go.WithDescription("Get the next occurrence of a specified day of the week after a given date. The date must be an ISO 8601 date (e.g. 2024-05-01, 2024-W18-3 or 2024-122) or a phrase such as 'next Friday'. The day of the week must be provided as a string (e.g. 'Monday', 'Tuesday', etc.)."),
+			mcp_go.WithOutputSchema[OccurrenceOutput](),
 			mcp_go.WithReadOnlyHintAnnotation(true),
 			mcp_go.WithString("dateTime"),
 			mcp_go.WithString("dayOfWeek"),
@@ -88,25 +210,34 @@ func NewServer() *Server {
 	s.MCPServer.AddTool(
 		mcp_go.NewTool(
 			"addDuration",
-			mcp_go.WithDescription("Add a duration to a given date and time. The date/time must be in the format YYYY-MM-DD HH:MM:SS or YYYY-MM-DD. The duration must be in the format '1h30m' for 1 hour and 30 minutes."),
+			mcp_go.WithDescription("Add a duration to a given date and time. The date/time must be an ISO 8601 date or date/time (e.g. 2024-05-01, 2024-05-01 13:45:00, 2024-05-01T13:45:00.123Z, 2024-W18-3 or 2024-122); an inline offset takes precedence over the timezone.  Natural-language phrases such as 'yesterday' or 'next Tuesday at 3pm' are also accepted. The duration may be an ISO 8601 duration (e.g. 'P1Y2M10DT2H30M' or 'P3W') or units such as '3 weeks 2 days', '2y3mo' or '1h30m'; 'm' means minutes and 'mo' months.  Years, months, weeks and days move the calendar date in the given IANA timezone (default UTC) and keep the local time of day across daylight saving changes."),
+			mcp_go.WithOutputSchema[DurationShiftOutput](),
 			mcp_go.WithReadOnlyHintAnnotation(true),
 			mcp_go.WithString("dateTime"),
 			mcp_go.WithString("duration"),
+			mcp_go.WithString("timeZone"),
+			mcp_go.WithString("monthEndPolicy", mcp_go.Enum(string(MonthEndClamp), string(MonthEndOverflow)), mcp_go.Description("What to do when adding months lands past the end of a month: clamp (Jan 31 + 1 month = Feb 28/29) or overflow (Jan 31 + 1 month = Mar 2/3).  Defaults to clamp.")),
+			withDSTPolicy(),
 		),
 		s.AddDuration)
 	s.MCPServer.AddTool(
 		mcp_go.NewTool(
 			"subtractDuration",
-			mcp_go.WithDescription("Subtract a duration from a given date and time. The date/time must be in the format YYYY-MM-DD HH:MM:SS or YYYY-MM-DD. The duration must be in the format '1h30m' for 1 hour and 30 minutes."),
+			mcp_go.WithDescription("Subtract a duration from a given date and time. The date/time must be an ISO 8601 date or date/time (e.g. 2024-05-01, 2024-05-01 13:45:00, 2024-05-01T13:45:00.123Z, 2024-W18-3 or 2024-122); an inline offset takes precedence over the timezone.  Natural-language phrases such as 'yesterday' or 'next Tuesday at 3pm' are also accepted. The duration may be an ISO 8601 duration (e.g. 'P1Y2M10DT2H30M' or 'P3W') or units such as '3 weeks 2 days', '2y3mo' or '1h30m'; 'm' means minutes and 'mo' months.  Years, months, weeks and days move the calendar date in the given IANA timezone (default UTC) and keep the local time of day across daylight saving changes."),
+			mcp_go.WithOutputSchema[DurationShiftOutput](),
 			mcp_go.WithReadOnlyHintAnnotation(true),
 			mcp_go.WithString("dateTime"),
 			mcp_go.WithString("duration"),
+			mcp_go.WithString("timeZone"),
+			mcp_go.WithString("monthEndPolicy", mcp_go.Enum(string(MonthEndClamp), string(MonthEndOverflow)), mcp_go.Description("What to do when adding months lands past the end of a month: clamp (Jan 31 + 1 month = Feb 28/29) or overflow (Jan 31 + 1 month = Mar 2/3).  Defaults to clamp.")),
+			withDSTPolicy(),
 		),
 		s.SubtractDuration)
 	s.MCPServer.AddTool(
 		mcp_go.NewTool(
 			"previousOccurrence",
-			mcp_go.WithDescription("Get the previous occurrence of a specified day of the week before a given date. The date must be in the format YYYY-MM-DD. The day of the week must be provided as a string (e.g. 'Monday', 'Tuesday', etc.)."),
+			mcp_go.WithDescription("Get the previous occurrence of a specified day of the week before a given date. The date must be an ISO 8601 date (e.g. 2024-05-01, 2024-W18-3 or 2024-122) or a phrase such as 'next Friday'. The day of the week must be provided as a string (e.g. 'Monday', 'Tuesday', etc.)."),
+			mcp_go.WithOutputSchema[OccurrenceOutput](),
 			mcp_go.WithReadOnlyHintAnnotation(true),
 			mcp_go.WithString("dateTime"),
 			mcp_go.WithString("dayOfWeek"),
@@ -115,7 +246,8 @@ func NewServer() *Server {
 	s.MCPServer.AddTool(
 		mcp_go.NewTool(
 			"isWeekend",
-			mcp_go.WithDescription("Check if a given date is a weekend (Saturday or Sunday). The date must be in the format YYYY-MM-DD."),
+			mcp_go.WithDescription("Check if a given date is a weekend (Saturday or Sunday). The date must be an ISO 8601 date (e.g. 2024-05-01, 2024-W18-3 or 2024-122) or a phrase such as 'next Friday'."),
+			mcp_go.WithOutputSchema[WeekendOutput](),
 			mcp_go.WithReadOnlyHintAnnotation(true),
 			mcp_go.WithString("dateTime"),
 		),
@@ -123,7 +255,8 @@ func NewServer() *Server {
 	s.MCPServer.AddTool(
 		mcp_go.NewTool(
 			"isWeekday",
-			mcp_go.WithDescription("Check if a given date is a weekday (Monday to Friday). The date must be in the format YYYY-MM-DD."),
+			mcp_go.WithDescription("Check if a given date is a weekday (Monday to Friday). The date must be an ISO 8601 date (e.g. 2024-05-01, 2024-W18-3 or 2024-122) or a phrase such as 'next Friday'."),
+			mcp_go.WithOutputSchema[WeekdayOutput](),
 			mcp_go.WithReadOnlyHintAnnotation(true),
 			mcp_go.WithString("dateTime"),
 		),
@@ -131,16 +264,433 @@ func NewServer() *Server {
 	s.MCPServer.AddTool(
 		mcp_go.NewTool(
 			"daysBetween",
-			mcp_go.WithDescription("Calculate the number of days between two dates. The dates must be in the format YYYY-MM-DD."),
+			mcp_go.WithDescription("Calculate the number of days between two dates. The dates must be ISO 8601 dates (e.g. 2024-05-01, 2024-W18-3 or 2024-122) or phrases such as 'next Friday'."),
+			mcp_go.WithOutputSchema[DaysBetweenOutput](),
 			mcp_go.WithReadOnlyHintAnnotation(true),
-			mcp_go.WithString("firstDate"),
-			mcp_go.WithString("secondDate"),
+			mcp_go.WithString("firstDateTime"),
+			mcp_go.WithString("secondDateTime"),
 		),
 		s.DaysBetween)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"resolveDateExpression",
+			mcp_go.WithDescription("Resolve a date expression to an absolute date and time and explain how it was interpreted.  Accepts ISO 8601 input or natural-language phrases relative to the current time such as 'yesterday', '3 days ago', 'in 2 weeks', 'next Tuesday at 3pm', 'end of next month' or 'the first Monday of June'.  An IANA formatted timezone can be specified (e.g. America/New_York), otherwise UTC is used."),
+			mcp_go.WithOutputSchema[ResolvedInput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			mcp_go.WithString("expression", mcp_go.Required()),
+			mcp_go.WithString("timeZone"),
+			withDSTPolicy(),
+		),
+		s.ResolveDateExpression)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"convertTimeZone",
+			mcp_go.WithDescription("Convert a date and time from one IANA timezone into one or more target IANA timezones, reporting each zone's abbreviation, UTC offset and whether daylight saving time is in effect.  The date/time must be an ISO 8601 date or date/time or a natural-language phrase and is read as local wall-clock time in the source timezone; if omitted the current time is used.  Local times skipped or repeated by a daylight saving transition are flagged."),
+			mcp_go.WithOutputSchema[ConvertTimeZoneOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			mcp_go.WithString("dateTime"),
+			mcp_go.WithString("timeZone", mcp_go.Description("Source IANA timezone.  Defaults to UTC.")),
+			mcp_go.WithArray("targetTimeZones", mcp_go.Required(), mcp_go.WithStringItems(), mcp_go.Description("Target IANA timezones (e.g. [\"Asia/Kolkata\", \"Europe/London\"]).")),
+			withDSTPolicy(),
+		),
+		s.ConvertTimeZone)
+
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"findTimeZone",
+			mcp_go.WithDescription("Find the time zones that a city (e.g. Tokyo or \"Portland, US\"), country name or ISO code, abbreviation (e.g. PST or IST), fixed offset (e.g. UTC+5:30) or misspelled zone name may refer to, most likely first.  Time zone arguments of the other tools accept the same names; use this tool to see which zone a name resolves to or, when it is ambiguous, the zones it may mean."),
+			mcp_go.WithOutputSchema[FindTimeZoneOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			mcp_go.WithString("query", mcp_go.Required(), mcp_go.Description("City, country, abbreviation, offset or time zone name to look up.")),
+			mcp_go.WithNumber("limit", mcp_go.Description("Number of candidates to list, from 1 to 50.  Defaults to 10.")),
+		),
+		s.FindTimeZone)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"timeZoneDatabaseInfo",
+			mcp_go.WithDescription("Report which release of the IANA time zone database (tzdata) the server uses, e.g. 2025b, and where it was loaded from.  Time zone rules change several times a year, so check this when an offset or daylight saving date looks out of date."),
+			mcp_go.WithOutputSchema[TimeZoneDatabaseOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+		),
+		s.TimeZoneDatabaseInfo)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"dstTransitions",
+			mcp_go.WithDescription("List every change of a time zone's UTC offset in a year, or between two dates, such as the starts and ends of daylight saving time.  Each change gives the instant, the old and new offsets and abbreviations and whether clocks go forward or back.  Use it rather than assuming when daylight saving time starts or ends, since the dates differ between zones and years."),
+			mcp_go.WithOutputSchema[DSTTransitionsOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			mcp_go.WithString("timeZone", mcp_go.Required(), mcp_go.Description("IANA timezone, e.g. America/New_York.")),
+			mcp_go.WithNumber("year", mcp_go.Description("Year to list the changes of.  Defaults to the current year.  Ignored when from or to is given.")),
+			mcp_go.WithString("from", mcp_go.Description("Start of the window, as an ISO 8601 date or date/time or a phrase such as 'today', read in the timezone.  Defaults to now.")),
+			mcp_go.WithString("to", mcp_go.Description("End of the window, exclusive.  Defaults to a year after from; the window may be up to 100 years long.")),
+		),
+		s.DSTTransitions)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"nextTransition",
+			mcp_go.WithDescription("Find when a time zone's clocks next change, e.g. the next start or end of daylight saving time, how long until then and whether they go forward or back, along with the last change before now."),
+			mcp_go.WithOutputSchema[NextTransitionOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			mcp_go.WithString("timeZone", mcp_go.Required(), mcp_go.Description("IANA timezone, e.g. Europe/London.")),
+		),
+		s.NextTransition)
+
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"isBusinessDay",
+			mcp_go.WithDescription("Check if a given date is a business day: not a weekend day and not a holiday in the selected holiday calendar.  The date must be an ISO 8601 date (e.g. 2024-05-01, 2024-W18-3 or 2024-122) or a phrase such as 'next Friday'."),
+			mcp_go.WithOutputSchema[BusinessDayOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			mcp_go.WithString("dateTime", mcp_go.Required()),
+			mcp_go.WithString("timeZone"),
+			withHolidayCalendar(),
+			withWeekend(),
+		),
+		s.IsBusinessDay)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"addBusinessDays",
+			mcp_go.WithDescription("Add a number of business days to a given date and time, skipping weekend days and holidays in the selected holiday calendar and keeping the local time of day.  A negative number moves backwards.  The date/time must be an ISO 8601 date or date/time or a natural-language phrase and is read as local wall-clock time in the given IANA timezone (default UTC)."),
+			mcp_go.WithOutputSchema[AddBusinessDaysOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			mcp_go.WithString("dateTime", mcp_go.Required()),
+			mcp_go.WithNumber("days", mcp_go.Required(), mcp_go.Description("Number of business days to add; negative to subtract.  At most 400 years of calendar days are walked.")),
+			mcp_go.WithString("timeZone"),
+			withDSTPolicy(),
+			withHolidayCalendar(),
+			withWeekend(),
+		),
+		s.AddBusinessDays)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"businessDaysBetween",
+			mcp_go.WithDescription("Count the business days from the first date up to, but not including, the second date, excluding weekend days and holidays in the selected holiday calendar.  The dates must be ISO 8601 dates or phrases such as 'next Friday'."),
+			mcp_go.WithOutputSchema[BusinessDaysBetweenOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			mcp_go.WithString("firstDate", mcp_go.Required()),
+			mcp_go.WithString("secondDate", mcp_go.Required()),
+			mcp_go.WithString("timeZone"),
+			withHolidayCalendar(),
+			withWeekend(),
+		),
+		s.BusinessDaysBetween)
+
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"listHolidays",
+			mcp_go.WithDescription("List the holidays of a holiday calendar in a year, with the day each is observed when it is moved off a weekend."),
+			mcp_go.WithOutputSchema[ListHolidaysOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			withHolidayCalendar(mcp_go.Required()),
+			mcp_go.WithNumber("year", mcp_go.Description("Year in the format YYYY.  Defaults to the current year.")),
+		),
+		s.ListHolidays)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"nextHoliday",
+			mcp_go.WithDescription("Find the next holidays of a holiday calendar on or after today's date in the given IANA timezone (default UTC)."),
+			mcp_go.WithOutputSchema[NextHolidayOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			withHolidayCalendar(mcp_go.Required()),
+			mcp_go.WithString("timeZone"),
+			mcp_go.WithNumber("count", mcp_go.Description("Number of holidays to return, from 1 to 50.  Defaults to 1.")),
+		),
+		s.NextHoliday)
+
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"expandRecurrence",
+			mcp_go.WithDescription("Expand an RFC 5545 (iCalendar) recurrence rule into its occurrences and describe it in English.  Supports FREQ, INTERVAL, COUNT, UNTIL, BYMONTH, BYMONTHDAY, BYYEARDAY, BYDAY with ordinals (e.g. -1FR), BYHOUR, BYMINUTE, BYSECOND, BYSETPOS and WKST.  Occurrences follow the local wall-clock time of DTSTART in the given IANA timezone; a time skipped by a daylight saving transition moves forward by the length of the gap."),
+			mcp_go.WithOutputSchema[RecurrenceOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			mcp_go.WithString("rrule", mcp_go.Required(), mcp_go.Description("The rule, e.g. \"FREQ=MONTHLY;BYDAY=-1FR;COUNT=6\".  May also be iCalendar text with DTSTART (optionally with TZID), RRULE, RDATE and EXDATE lines.")),
+			mcp_go.WithString("dtstart", mcp_go.Description("Start of the recurrence as an ISO 8601 date/time or natural-language phrase, read in timeZone.  Overrides any DTSTART line.  Defaults to now.")),
+			mcp_go.WithString("timeZone", mcp_go.Description("IANA timezone for the recurrence.  Defaults to the DTSTART TZID, or UTC.")),
+			mcp_go.WithNumber("count", mcp_go.Description("Maximum number of occurrences to return, from 1 to 500.  Defaults to 10.")),
+			mcp_go.WithString("after", mcp_go.Description("Only return occurrences at or after this date/time.")),
+			mcp_go.WithString("before", mcp_go.Description("Only return occurrences at or before this date/time.")),
+			mcp_go.WithArray("rdates", mcp_go.WithStringItems(), mcp_go.Description("Extra occurrences to add (RDATE).")),
+			mcp_go.WithArray("exdates", mcp_go.WithStringItems(), mcp_go.Description("Occurrences to exclude (EXDATE).  A date without a time excludes every occurrence on that day.")),
+		),
+		s.ExpandRecurrence)
+
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"nextCronRuns",
+			mcp_go.WithDescription("List the next times a cron expression fires after the current time, and explain the schedule.  Accepts standard 5-field expressions, 6-field expressions with a leading seconds field, Quartz expressions (seconds, ?, L, W, #, optional year) and the macros @yearly, @monthly, @weekly, @daily and @hourly.  Times are evaluated on the wall clock of the given IANA timezone (default UTC): a job at a fixed hour runs once when daylight saving time repeats an hour and as the clocks go forward when it skips one, while a job whose hour field is * keeps running in real time."),
+			mcp_go.WithOutputSchema[CronRunsOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			withCronExpression(),
+			withCronDialect(),
+			mcp_go.WithString("timeZone"),
+			mcp_go.WithNumber("count", mcp_go.Description("Number of runs to return, from 1 to 100.  Defaults to 5.")),
+		),
+		s.NextCronRuns)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"previousCronRuns",
+			mcp_go.WithDescription("List the most recent times a cron expression fired before the current time, latest first, and explain the schedule.  Accepts the same expressions and daylight saving handling as nextCronRuns."),
+			mcp_go.WithOutputSchema[CronRunsOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			withCronExpression(),
+			withCronDialect(),
+			mcp_go.WithString("timeZone"),
+			mcp_go.WithNumber("count", mcp_go.Description("Number of runs to return, from 1 to 100.  Defaults to 5.")),
+		),
+		s.PreviousCronRuns)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"explainCron",
+			mcp_go.WithDescription("Explain a cron expression in plain English.  Accepts the same expressions as nextCronRuns."),
+			mcp_go.WithOutputSchema[CronExplanationOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			withCronExpression(),
+			withCronDialect(),
+		),
+		s.ExplainCron)
+
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"sessionTimeline",
+			mcp_go.WithDescription("Show how time has passed in this conversation: when the MCP session started, how long ago the previous tool call was, the gaps between tool calls and a compact summary.  Call it at the start of a turn to notice that time has passed since the last one."),
+			mcp_go.WithOutputSchema[SessionTimelineOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			mcp_go.WithString("timeZone", mcp_go.Description("IANA timezone to show times in.  Defaults to UTC.")),
+			mcp_go.WithNumber("limit", mcp_go.Description("Number of recent tool calls to list, from 0 to 200.  Defaults to 20.")),
+		),
+		s.SessionTimeline)
+
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"startTimer",
+			mcp_go.WithDescription("Start a named timer, e.g. \"build\", to measure how long a task takes.  Starting a timer that was stopped restarts it; starting one that is running is an error."),
+			mcp_go.WithOutputSchema[TimerOutput](),
+			mcp_go.WithString("name", mcp_go.Required(), mcp_go.Description("Name of the timer.")),
+			withTimerScope("Who can see the timer: session (the default) for this MCP session only, or global for every session."),
+			mcp_go.WithString("timeZone", mcp_go.Description("IANA timezone to show times in.  Defaults to UTC.")),
+		),
+		s.StartTimer)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"lapTimer",
+			mcp_go.WithDescription("Record a lap on a running timer and report the time since the previous lap and since the start."),
+			mcp_go.WithOutputSchema[TimerOutput](),
+			mcp_go.WithString("name", mcp_go.Required(), mcp_go.Description("Name of the timer.")),
+			mcp_go.WithString("label", mcp_go.Description("Optional label for the lap, e.g. \"compile finished\".")),
+			withTimerScope("Where to look for the timer: session or global.  By default this session's timers are searched first, then the global ones."),
+			mcp_go.WithString("timeZone", mcp_go.Description("IANA timezone to show times in.  Defaults to UTC.")),
+		),
+		s.LapTimer)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"stopTimer",
+			mcp_go.WithDescription("Stop a running timer and report how long it ran.  A stopped timer is kept, and listed by listTimers, until it is restarted or stopped with remove."),
+			mcp_go.WithOutputSchema[TimerOutput](),
+			mcp_go.WithString("name", mcp_go.Required(), mcp_go.Description("Name of the timer.")),
+			mcp_go.WithBoolean("remove", mcp_go.Description("Forget the timer once it is stopped.  Defaults to false.")),
+			withTimerScope("Where to look for the timer: session or global.  By default this session's timers are searched first, then the global ones."),
+			mcp_go.WithString("timeZone", mcp_go.Description("IANA timezone to show times in.  Defaults to UTC.")),
+		),
+		s.StopTimer)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"listTimers",
+			mcp_go.WithDescription("List named timers with how long each has been running or ran before it was stopped."),
+			mcp_go.WithOutputSchema[TimersOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			withTimerScope("Which timers to list: session or global.  Defaults to both."),
+			mcp_go.WithString("timeZone", mcp_go.Description("IANA timezone to show times in.  Defaults to UTC.")),
+		),
+		s.ListTimers)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"addEvent",
+			mcp_go.WithDescription("Register a named deadline or event, e.g. \"Q3 report\" due 2025-09-30 17:00 America/New_York, so that later calls, including from other sessions, can ask how long remains.  The date/time is read like timeUntil's, and may also be a phrase such as 'next Friday at 5pm'.  An event with the same name is replaced."),
+			mcp_go.WithOutputSchema[EventOutput](),
+			mcp_go.WithString("name", mcp_go.Required(), mcp_go.Description("Name of the event.  Names are matched without regard to case.")),
+			mcp_go.WithString("dateTime", mcp_go.Required(), mcp_go.Description("When the event is due.")),
+			mcp_go.WithString("timeZone", mcp_go.Description("IANA timezone the date/time is read in and the event is shown in.  Defaults to UTC.")),
+			mcp_go.WithString("description", mcp_go.Description("Optional notes about the event.")),
+			withDSTPolicy(),
+			withOutputStyle(),
+		),
+		s.AddEvent)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"getEvent",
+			mcp_go.WithDescription("Report how long remains until a registered event, or how overdue it is."),
+			mcp_go.WithOutputSchema[EventOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			mcp_go.WithString("name", mcp_go.Required(), mcp_go.Description("Name of the event.")),
+			withOutputStyle(),
+		),
+		s.GetEvent)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"listEvents",
+			mcp_go.WithDescription("List registered events, soonest first, with the time remaining until each."),
+			mcp_go.WithOutputSchema[EventsOutput](),
+			mcp_go.WithReadOnlyHintAnnotation(true),
+			mcp_go.WithBoolean("includePast", mcp_go.Description("Also list events that are overdue.  Defaults to false.")),
+			withOutputStyle(),
+		),
+		s.ListEvents)
+	s.MCPServer.AddTool(
+		mcp_go.NewTool(
+			"removeEvent",
+			mcp_go.WithDescription("Forget a registered event."),
+			mcp_go.WithOutputSchema[EventOutput](),
+			mcp_go.WithString("name", mcp_go.Required(), mcp_go.Description("Name of the event.")),
+		),
+		s.RemoveEvent)
+
+	s.MCPServer.AddResource(
+		mcp_go.NewResource(
+			"time://now",
+			"Current time",
+			mcp_go.WithResourceDescription("The current date and time in UTC."),
+			mcp_go.WithMIMEType("application/json"),
+		),
+		s.NowResource)
+	s.MCPServer.AddResourceTemplate(
+		mcp_go.NewResourceTemplate(
+			"time://now/{+timezone}",
+			"Current time in a time zone",
+			mcp_go.WithTemplateDescription("The current date and time in an IANA time zone, e.g. time://now/America/New_York."),
+			mcp_go.WithTemplateMIMEType("application/json"),
+		),
+		s.NowResource)
+	s.MCPServer.AddResource(
+		mcp_go.NewResource(
+			"time://timezones",
+			"Time zones",
+			mcp_go.WithResourceDescription("The IANA time zones known to the server with their current UTC offsets."),
+			mcp_go.WithMIMEType("application/json"),
+		),
+		s.TimeZonesResource)
+	s.MCPServer.AddResourceTemplate(
+		mcp_go.NewResourceTemplate(
+			"time://calendar/{year}/{month}",
+			"Month calendar",
+			mcp_go.WithTemplateDescription("The days of a month with their weekdays and ISO week numbers, e.g. time://calendar/2025/3."),
+			mcp_go.WithTemplateMIMEType("application/json"),
+		),
+		s.CalendarResource)
+	s.MCPServer.AddResourceTemplate(
+		mcp_go.NewResourceTemplate(
+			"time://holidays/{region}/{year}",
+			"Holidays",
+			mcp_go.WithTemplateDescription("The holidays of a holiday calendar in a year, e.g. time://holidays/US/2025."),
+			mcp_go.WithTemplateMIMEType("application/json"),
+		),
+		s.HolidaysResource)
+	s.MCPServer.AddResource(
+		mcp_go.NewResource(
+			"time://events",
+			"Events",
+			mcp_go.WithResourceDescription("Every registered deadline and event, soonest first, with the time remaining until each."),
+			mcp_go.WithMIMEType("application/json"),
+		),
+		s.EventsResource)
+	s.MCPServer.AddResourceTemplate(
+		mcp_go.NewResourceTemplate(
+			"time://events/{name}",
+			"Event",
+			mcp_go.WithTemplateDescription("A registered deadline or event with the time remaining until it."),
+			mcp_go.WithTemplateMIMEType("application/json"),
+		),
+		s.EventResource)
+
+	s.MCPServer.AddPrompt(
+		mcp_go.NewPrompt(
+			"ground-in-time",
+			mcp_go.WithPromptDescription("Ground the conversation in the current date and time in the user's time zone, with how long the session has been running."),
+			mcp_go.WithArgument("timeZone", mcp_go.ArgumentDescription("The user's IANA time zone (e.g. America/New_York); defaults to UTC.")),
+		),
+		s.GroundInTimePrompt)
+	s.MCPServer.AddPrompt(
+		mcp_go.NewPrompt(
+			"plan-schedule",
+			mcp_go.WithPromptDescription("Plan work against a deadline, with the working days, weekends and holidays left before it."),
+			mcp_go.WithArgument("deadline", mcp_go.ArgumentDescription("The deadline as an ISO 8601 date or date/time (e.g. 2024-05-01 17:00) or a phrase such as 'next Friday'."), mcp_go.RequiredArgument()),
+			mcp_go.WithArgument("estimate", mcp_go.ArgumentDescription("The work estimate in working days (e.g. 5 or 2.5) or as a duration in days and hours (e.g. '3 days', '12h' or 'P2DT4H'); hours count at 8 a day."), mcp_go.RequiredArgument()),
+			mcp_go.WithArgument("timeZone", mcp_go.ArgumentDescription("The IANA time zone of the deadline; defaults to UTC.")),
+			mcp_go.WithArgument("holidayCalendar", mcp_go.ArgumentDescription("A holiday calendar whose holidays are not working days (e.g. US or UK).")),
+		),
+		s.PlanSchedulePrompt)
+	s.MCPServer.AddPrompt(
+		mcp_go.NewPrompt(
+			"explain-timezone-difference",
+			mcp_go.WithPromptDescription("Explain the time difference between two time zones, including how daylight saving time changes it through the year."),
+			mcp_go.WithArgument("fromTimeZone", mcp_go.ArgumentDescription("The IANA time zone to compare from (e.g. America/New_York)."), mcp_go.RequiredArgument()),
+			mcp_go.WithArgument("toTimeZone", mcp_go.ArgumentDescription("The IANA time zone to compare to (e.g. Asia/Kolkata)."), mcp_go.RequiredArgument()),
+			mcp_go.WithArgument("dateTime", mcp_go.ArgumentDescription("The date/time to compare at, read in fromTimeZone; defaults to now.")),
+		),
+		s.ExplainTimeZoneDifferencePrompt)
 
 	return s
 }
 
+// withDSTPolicy declares the dstPolicy argument shared by tools that read
+// local wall-clock times.
+func withDSTPolicy() mcp_go.ToolOption {
+	return mcp_go.WithString("dstPolicy", mcp_go.Enum(string(DSTPolicyEarlier), string(DSTPolicyLater), string(DSTPolicyError)), mcp_go.Description("How to resolve a local time skipped or repeated by a daylight saving transition.  Defaults to earlier."))
+}
+
+// withWeekStart declares the weekStart argument shared by the calendar
+// tools.
+func withWeekStart() mcp_go.ToolOption {
+	return mcp_go.WithString("weekStart", mcp_go.Description("Day weeks start on, e.g. Sunday or Monday.  Defaults to Monday, as in ISO 8601."))
+}
+
+// withOutputStyle declares the outputStyle argument shared by tools that
+// report a duration.
+func withOutputStyle() mcp_go.ToolOption {
+	return mcp_go.WithString("outputStyle", mcp_go.Enum(string(OutputStyleRaw), string(OutputStyleCalendar), string(OutputStyleTotals), string(OutputStyleApproximate), string(OutputStyleAll)), mcp_go.Description("How to present the duration: raw (e.g. 8760h0m0s), calendar (e.g. 1 year, 2 months, 3 days), totals (in days, weeks, hours, minutes and seconds), approximate (e.g. about 14 months ago) or all.  Defaults to raw."))
+}
+
+// withHolidayCalendar declares the holidayCalendar argument shared by the
+// business-day and holiday tools.
+func withHolidayCalendar(opts ...mcp_go.PropertyOption) mcp_go.ToolOption {
+	opts = append(opts, mcp_go.Description("Holiday calendar, e.g. US (federal), UK (England and Wales bank holidays), GR (Greece) or TARGET2, or a calendar loaded from a file.  For the business-day tools, only weekends are skipped without one."))
+	return mcp_go.WithString("holidayCalendar", opts...)
+}
+
+// withFiscalCalendar declares the fiscalCalendar argument shared by the
+// fiscal calendar tools.
+func withFiscalCalendar() mcp_go.ToolOption {
+	return mcp_go.WithString("fiscalCalendar", mcp_go.Required(), mcp_go.Description("Fiscal calendar, e.g. CALENDAR (January to December), US (federal, October to September), UK (government, April to March), AU (government, July to June) or NRF (4-5-4 retail, February to January), or a calendar loaded from a file."))
+}
+
+// withWeekend declares the weekend argument shared by the business-day tools.
+func withWeekend() mcp_go.ToolOption {
+	return mcp_go.WithString("weekend", mcp_go.Description("Comma-separated weekend days, e.g. 'Friday,Saturday', or 'none'.  Defaults to the holiday calendar's weekend, otherwise Saturday and Sunday."))
+}
+
 func (s *Server) Ready() bool {
 	return s.ready
 }
+
+// withCronExpression declares the expression argument of the cron tools.
+func withCronExpression() mcp_go.ToolOption {
+	return mcp_go.WithString("expression", mcp_go.Required(), mcp_go.Description("The cron expression, e.g. \"*/15 9-17 * * MON-FRI\", \"0 0 12 ? * 6L\" or \"@daily\"."))
+}
+
+// withCronDialect declares the dialect argument of the cron tools.
+func withCronDialect() mcp_go.ToolOption {
+	return mcp_go.WithString("dialect", mcp_go.Enum(string(CronDialectAuto), string(CronDialectStandard), string(CronDialectSeconds), string(CronDialectQuartz)), mcp_go.Description("How to read the fields.  auto (the default) treats 5 fields as standard, 7 as Quartz, and 6 as Quartz if a day field is ? and otherwise as standard with a leading seconds field.  Quartz numbers days of the week from 1 for Sunday; the others from 0."))
+}
+
+// withTimerScope declares the scope argument of the timer tools.
+func withTimerScope(description string) mcp_go.ToolOption {
+	return mcp_go.WithString("scope", mcp_go.Enum(string(TimerScopeSession), string(TimerScopeGlobal)), mcp_go.Description(description))
+}
+
+// withCoordinates declares the latitude and longitude arguments of the sun
+// tools.
+func withCoordinates() mcp_go.ToolOption {
+	return func(t *mcp_go.Tool) {
+		mcp_go.WithNumber("latitude", mcp_go.Required(), mcp_go.Min(-90), mcp_go.Max(90), mcp_go.Description("Latitude in degrees, positive north, e.g. 39.7392 for Denver."))(t)
+		mcp_go.WithNumber("longitude", mcp_go.Required(), mcp_go.Min(-180), mcp_go.Max(180), mcp_go.Description("Longitude in degrees, positive east, e.g. -104.9903 for Denver."))(t)
+	}
+}
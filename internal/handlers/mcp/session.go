@@ -0,0 +1,133 @@
+package mcp
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	mcp_go "github.com/mark3labs/mcp-go/mcp"
+	mcp_go_server "github.com/mark3labs/mcp-go/server"
+)
+
+// maxSessionCalls bounds the calls kept for each session.  Older calls are
+// dropped but still counted.
+const maxSessionCalls = 1000
+
+// SessionCall is one tool invocation within an MCP session.
+type SessionCall struct {
+	Tool string
+	Time time.Time
+}
+
+// SessionTimeline is a snapshot of one session's history.
+type SessionTimeline struct {
+	ID      string
+	Started time.Time
+	// Calls holds the most recent calls, oldest first.  TotalCalls also
+	// counts those that were dropped.
+	Calls      []SessionCall
+	TotalCalls int
+}
+
+// SessionTracker records when each MCP session started and when it called
+// tools.  Sessions are keyed by the mcp-go session ID, so stdio and
+// streamable HTTP clients are tracked alike.
+type SessionTracker struct {
+	mu       sync.Mutex
+	sessions map[string]*SessionTimeline
+}
+
+func NewSessionTracker() *SessionTracker {
+	return &SessionTracker{sessions: map[string]*SessionTimeline{}}
+}
+
+// session returns the timeline for id, starting it at t if it is new.  The
+// caller must hold st.mu.
+func (st *SessionTracker) session(id string, t time.Time) *SessionTimeline {
+	tl, ok := st.sessions[id]
+	if !ok {
+		tl = &SessionTimeline{ID: id, Started: t}
+		st.sessions[id] = tl
+	}
+	return tl
+}
+
+// Start records that the session id began at t.  It has no effect on a
+// session that is already known.
+func (st *SessionTracker) Start(id string, t time.Time) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.session(id, t)
+}
+
+// Record adds a call to tool at t to the session id.
+func (st *SessionTracker) Record(id, tool string, t time.Time) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	tl := st.session(id, t)
+	if len(tl.Calls) == maxSessionCalls {
+		tl.Calls = append(tl.Calls[:0], tl.Calls[1:]...)
+	}
+	tl.Calls = append(tl.Calls, SessionCall{Tool: tool, Time: t})
+	tl.TotalCalls++
+}
+
+// End forgets the session id.
+func (st *SessionTracker) End(id string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	delete(st.sessions, id)
+}
+
+// Timeline returns a copy of the session's history.
+func (st *SessionTracker) Timeline(id string) (SessionTimeline, bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	tl, ok := st.sessions[id]
+	if !ok {
+		return SessionTimeline{}, false
+	}
+	out := *tl
+	out.Calls = append([]SessionCall(nil), tl.Calls...)
+	return out, true
+}
+
+// sessionID returns the ID of the MCP session in ctx, or "" outside a
+// session.
+func sessionID(ctx context.Context) string {
+	if session := mcp_go_server.ClientSessionFromContext(ctx); session != nil {
+		return session.SessionID()
+	}
+	return ""
+}
+
+// sessionHooks starts and ends sessions in s.Sessions and s.Subscriptions
+// as clients connect and disconnect, and drops the timers and subscriptions
+// of a session that has ended.
+func (s *Server) sessionHooks() *mcp_go_server.Hooks {
+	hooks := &mcp_go_server.Hooks{}
+	hooks.AddOnRegisterSession(func(ctx context.Context, session mcp_go_server.ClientSession) {
+		s.Sessions.Start(session.SessionID(), s.TimeManager.Now())
+		s.Subscriptions.Register(session.SessionID())
+	})
+	hooks.AddOnUnregisterSession(func(ctx context.Context, session mcp_go_server.ClientSession) {
+		s.Sessions.End(session.SessionID())
+		if err := s.Timers.EndSession(session.SessionID()); err != nil {
+			slog.WarnContext(ctx, "Timers were not saved", slog.Any("error", err))
+		}
+		s.Subscriptions.End(session.SessionID())
+	})
+	return hooks
+}
+
+// trackSession is tool middleware that records each call in s.Sessions once
+// it has been handled, so a sessionTimeline call sees only earlier calls.
+func (s *Server) trackSession(next mcp_go_server.ToolHandlerFunc) mcp_go_server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+		called := s.TimeManager.Now()
+		result, err := next(ctx, request)
+		s.Sessions.Record(sessionID(ctx), request.Params.Name, called)
+		return result, err
+	}
+}
@@ -0,0 +1,111 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// testSession is a minimal mcp-go client session.
+type testSession struct {
+	id            string
+	notifications chan mcp.JSONRPCNotification
+}
+
+func newTestSession(id string) *testSession {
+	return &testSession{id: id, notifications: make(chan mcp.JSONRPCNotification, 16)}
+}
+
+func (s *testSession) Initialize()                                         {}
+func (s *testSession) Initialized() bool                                   { return true }
+func (s *testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+func (s *testSession) SessionID() string                                   { return s.id }
+
+func TestSessionTracker(t *testing.T) {
+	st := NewSessionTracker()
+	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+	st.Start("a", start)
+	st.Start("a", start.Add(time.Hour))
+	st.Record("a", "currentDateTime", start.Add(time.Minute))
+	st.Record("b", "timeSince", start.Add(2*time.Minute))
+
+	a, ok := st.Timeline("a")
+	if !ok || !a.Started.Equal(start) || a.TotalCalls != 1 || a.Calls[0].Tool != "currentDateTime" {
+		t.Errorf("Timeline(a) = %+v, %v", a, ok)
+	}
+	a.Calls[0].Tool = "changed"
+	if again, _ := st.Timeline("a"); again.Calls[0].Tool != "currentDateTime" {
+		t.Error("Timeline returned a slice shared with the tracker")
+	}
+
+	// A session first seen through a call starts at that call.
+	b, ok := st.Timeline("b")
+	if !ok || !b.Started.Equal(start.Add(2*time.Minute)) {
+		t.Errorf("Timeline(b) = %+v, %v", b, ok)
+	}
+
+	st.End("a")
+	if _, ok := st.Timeline("a"); ok {
+		t.Error("Timeline(a) found a session that had ended")
+	}
+}
+
+func TestSessionTrackerDropsOldCalls(t *testing.T) {
+	st := NewSessionTracker()
+	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < maxSessionCalls+5; i++ {
+		st.Record("a", "currentDateTime", start.Add(time.Duration(i)*time.Second))
+	}
+	tl, _ := st.Timeline("a")
+	if len(tl.Calls) != maxSessionCalls || tl.TotalCalls != maxSessionCalls+5 {
+		t.Errorf("kept %d calls of %d, want %d of %d", len(tl.Calls), tl.TotalCalls, maxSessionCalls, maxSessionCalls+5)
+	}
+	if want := start.Add(5 * time.Second); !tl.Calls[0].Time.Equal(want) {
+		t.Errorf("oldest kept call at %s, want %s", tl.Calls[0].Time, want)
+	}
+}
+
+func TestSessionsRecordToolCalls(t *testing.T) {
+	s := NewServer()
+	s.TimeManager = &mockTmanager{}
+	session := newTestSession("session-1")
+	ctx := context.Background()
+	if err := s.MCPServer.RegisterSession(ctx, session); err != nil {
+		t.Fatal(err)
+	}
+	ctx = s.MCPServer.WithContext(ctx, session)
+
+	for _, tool := range []string{"currentDateTime", "isLeapYear", "sessionTimeline"} {
+		msg, _ := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"method":  "tools/call",
+			"params":  map[string]any{"name": tool, "arguments": map[string]any{"year": 2024}},
+		})
+		s.MCPServer.HandleMessage(ctx, msg)
+	}
+
+	tl, ok := s.Sessions.Timeline("session-1")
+	if !ok {
+		t.Fatal("session was not tracked")
+	}
+	if !tl.Started.Equal((&mockTmanager{}).Now()) {
+		t.Errorf("session started at %s, want the registration time", tl.Started)
+	}
+	var tools []string
+	for _, call := range tl.Calls {
+		tools = append(tools, call.Tool)
+	}
+	if len(tools) != 3 || tools[0] != "currentDateTime" || tools[2] != "sessionTimeline" {
+		t.Errorf("recorded calls = %v", tools)
+	}
+
+	s.MCPServer.UnregisterSession(ctx, "session-1")
+	if _, ok := s.Sessions.Timeline("session-1"); ok {
+		t.Error("session was not forgotten when it ended")
+	}
+}
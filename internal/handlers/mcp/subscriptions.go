@@ -0,0 +1,347 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	mcp_go "github.com/mark3labs/mcp-go/mcp"
+	mcp_go_server "github.com/mark3labs/mcp-go/server"
+)
+
+// mcp-go routes neither of these methods, so the server answers them itself;
+// see interceptSubscription.
+const (
+	methodResourcesSubscribe   = "resources/subscribe"
+	methodResourcesUnsubscribe = "resources/unsubscribe"
+)
+
+// stdioSessionID is the session ID mcp-go gives the single stdio client.
+const stdioSessionID = "stdio"
+
+// SubscriptionTracker records the resources each MCP session has subscribed
+// to.  A session with subscriptions has a scheduler goroutine that sends
+// notifications/resources/updated when one of them changes: the time://now
+// resources each minute, and the event resources when an event passes.
+// Only sessions that mcp-go has registered may subscribe, so that every
+// scheduler is stopped when its session is unregistered.
+type SubscriptionTracker struct {
+	mu         sync.Mutex
+	registered map[string]bool
+	sessions   map[string]*subscriber
+}
+
+// subscriber is one session's subscriptions.
+type subscriber struct {
+	// since holds, for each subscribed URI, when the session last saw it
+	// change or subscribed to it.
+	since  map[string]time.Time
+	wake   chan struct{}
+	cancel context.CancelFunc
+}
+
+func NewSubscriptionTracker() *SubscriptionTracker {
+	return &SubscriptionTracker{registered: map[string]bool{}, sessions: map[string]*subscriber{}}
+}
+
+// Register allows session id to subscribe.
+func (st *SubscriptionTracker) Register(id string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.registered[id] = true
+}
+
+// poke makes the scheduler recompute when to wake up.
+func (sub *subscriber) poke() {
+	select {
+	case sub.wake <- struct{}{}:
+	default:
+	}
+}
+
+// URIs returns the resources the session id has subscribed to, sorted.
+func (st *SubscriptionTracker) URIs(id string) []string {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	var uris []string
+	if sub, ok := st.sessions[id]; ok {
+		for uri := range sub.since {
+			uris = append(uris, uri)
+		}
+	}
+	sort.Strings(uris)
+	return uris
+}
+
+// End drops the subscriptions of session id and stops its scheduler.
+func (st *SubscriptionTracker) End(id string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	delete(st.registered, id)
+	if sub, ok := st.sessions[id]; ok {
+		sub.cancel()
+		delete(st.sessions, id)
+	}
+}
+
+// Subscribe subscribes session id to uri, starting the session's scheduler
+// if this is its first subscription.  It reports false, subscribing nothing,
+// if the session is not registered.
+func (s *Server) Subscribe(id, uri string) bool {
+	st := s.Subscriptions
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if !st.registered[id] {
+		return false
+	}
+	sub, ok := st.sessions[id]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		sub = &subscriber{since: map[string]time.Time{}, wake: make(chan struct{}, 1), cancel: cancel}
+		st.sessions[id] = sub
+		go s.schedule(ctx, id, sub)
+	}
+	if _, ok := sub.since[uri]; !ok {
+		sub.since[uri] = s.TimeManager.Now()
+	}
+	sub.poke()
+	return true
+}
+
+// Unsubscribe removes a subscription, stopping the session's scheduler once
+// it has none left.
+func (s *Server) Unsubscribe(id, uri string) {
+	st := s.Subscriptions
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	sub, ok := st.sessions[id]
+	if !ok {
+		return
+	}
+	delete(sub.since, uri)
+	if len(sub.since) == 0 {
+		sub.cancel()
+		delete(st.sessions, id)
+		return
+	}
+	sub.poke()
+}
+
+// schedule sends the session's notifications until ctx is cancelled.
+func (s *Server) schedule(ctx context.Context, id string, sub *subscriber) {
+	for {
+		var fire <-chan time.Time
+		stop := func() bool { return false }
+		if next, ok := s.nextNotification(sub); ok {
+			fire, stop = s.TimeManager.NewTimer(next.Sub(s.TimeManager.Now()))
+		}
+		select {
+		case <-ctx.Done():
+			stop()
+			return
+		case <-sub.wake:
+			stop()
+		case <-fire:
+			s.notifyChanged(ctx, id, sub)
+		}
+	}
+}
+
+// subscriptions returns a copy of sub.since.
+func (s *Server) subscriptions(sub *subscriber) map[string]time.Time {
+	s.Subscriptions.mu.Lock()
+	defer s.Subscriptions.mu.Unlock()
+	since := make(map[string]time.Time, len(sub.since))
+	for uri, t := range sub.since {
+		since[uri] = t
+	}
+	return since
+}
+
+// nextNotification returns when the first of sub's resources next changes.
+func (s *Server) nextNotification(sub *subscriber) (time.Time, bool) {
+	var first time.Time
+	found := false
+	for uri, since := range s.subscriptions(sub) {
+		if next, ok := s.nextChange(uri, since); ok && (!found || next.Before(first)) {
+			first, found = next, true
+		}
+	}
+	return first, found
+}
+
+// notifyChanged notifies the session of each of its resources that has
+// changed by now.
+func (s *Server) notifyChanged(ctx context.Context, id string, sub *subscriber) {
+	now := s.TimeManager.Now()
+	for uri, since := range s.subscriptions(sub) {
+		if next, ok := s.nextChange(uri, since); !ok || next.After(now) {
+			continue
+		}
+		s.Subscriptions.mu.Lock()
+		_, subscribed := sub.since[uri]
+		if subscribed {
+			sub.since[uri] = now
+		}
+		s.Subscriptions.mu.Unlock()
+		if subscribed {
+			s.notifyUpdated(ctx, id, uri)
+		}
+	}
+}
+
+// nextChange returns the first time after since at which the resource at
+// uri changes by itself.  Resources that change only when they are edited,
+// or not at all, have none.
+func (s *Server) nextChange(uri string, since time.Time) (time.Time, bool) {
+	switch {
+	case uri == "time://now" || strings.HasPrefix(uri, "time://now/"):
+		return since.Truncate(time.Minute).Add(time.Minute), true
+	case uri == "time://events":
+		events, err := s.Events.List()
+		if err != nil {
+			return time.Time{}, false
+		}
+		for _, e := range events {
+			if e.Time.After(since) {
+				return e.Time, true
+			}
+		}
+	case strings.HasPrefix(uri, "time://events/"):
+		e, err := s.Events.Get(eventURIName(uri))
+		if err == nil && e.Time.After(since) {
+			return e.Time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// eventURIName returns the event name in a time://events/{name} URI.
+func eventURIName(uri string) string {
+	name := strings.TrimPrefix(uri, "time://events/")
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		return unescaped
+	}
+	return name
+}
+
+// eventChanged notifies the sessions subscribed to the named event, or to
+// the list of events, that it was added, replaced or removed, and has the
+// schedulers take its new time into account.
+func (s *Server) eventChanged(ctx context.Context, name string) {
+	type notification struct{ id, uri string }
+	var notifications []notification
+	s.Subscriptions.mu.Lock()
+	for id, sub := range s.Subscriptions.sessions {
+		for uri := range sub.since {
+			if uri == "time://events" || strings.HasPrefix(uri, "time://events/") && eventKey(eventURIName(uri)) == eventKey(name) {
+				notifications = append(notifications, notification{id, uri})
+			}
+		}
+		sub.poke()
+	}
+	s.Subscriptions.mu.Unlock()
+	for _, n := range notifications {
+		s.notifyUpdated(ctx, n.id, n.uri)
+	}
+}
+
+// notifyUpdated sends notifications/resources/updated for uri to session id.
+func (s *Server) notifyUpdated(ctx context.Context, id, uri string) {
+	err := s.MCPServer.SendNotificationToSpecificClient(id, string(mcp_go.MethodNotificationResourceUpdated), map[string]any{"uri": uri})
+	if err != nil {
+		slog.WarnContext(ctx, "Resource update not sent", slog.String("session_id", id), slog.String("uri", uri), slog.Any("error", err))
+	}
+}
+
+// interceptSubscription handles a resources/subscribe or
+// resources/unsubscribe request from session id, which mcp-go would reject
+// as an unknown method.  It returns the message to pass on to mcp-go: the
+// message itself, or for a subscription request a ping with the same ID,
+// whose empty result is the reply a subscription request expects.  A
+// request from a missing or unregistered session is passed on unchanged for
+// mcp-go to reject.
+func (s *Server) interceptSubscription(ctx context.Context, id string, message []byte) []byte {
+	var request struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id"`
+		Method  string          `json:"method"`
+		Params  struct {
+			URI string `json:"uri"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(message, &request); err != nil || request.ID == nil || request.Params.URI == "" || id == "" {
+		return message
+	}
+	switch request.Method {
+	case methodResourcesSubscribe:
+		if !s.Subscribe(id, request.Params.URI) {
+			return message
+		}
+	case methodResourcesUnsubscribe:
+		s.Unsubscribe(id, request.Params.URI)
+	default:
+		return message
+	}
+	slog.InfoContext(ctx, "Resource subscription", slog.String("session_id", id), slog.String("method", request.Method), slog.String("uri", request.Params.URI))
+	ping, err := json.Marshal(map[string]any{"jsonrpc": request.JSONRPC, "id": request.ID, "method": mcp_go.MethodPing})
+	if err != nil {
+		return message
+	}
+	return ping
+}
+
+// SubscriptionHandler wraps the streamable HTTP handler so that it accepts
+// resource subscriptions.
+func (s *Server) SubscriptionHandler(next func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			next(w, r)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+		body = s.interceptSubscription(r.Context(), r.Header.Get(mcp_go_server.HeaderKeySessionID), body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+		next(w, r)
+	}
+}
+
+// SubscriptionReader wraps the stdio transport's input so that it accepts
+// resource subscriptions.
+func (s *Server) SubscriptionReader(stdin io.Reader) io.Reader {
+	pr, pw := io.Pipe()
+	go func() {
+		reader := bufio.NewReader(stdin)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(line) > 0 {
+				if rewritten := s.interceptSubscription(context.Background(), stdioSessionID, line); !bytes.Equal(rewritten, line) {
+					line = append(rewritten, '\n')
+				}
+				if _, err := pw.Write(line); err != nil {
+					return
+				}
+			}
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+	return pr
+}
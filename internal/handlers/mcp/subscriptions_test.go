@@ -0,0 +1,231 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// subscriptionMessage is a resources/subscribe or resources/unsubscribe
+// request for uri.
+func subscriptionMessage(method, uri string) []byte {
+	msg, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      7,
+		"method":  method,
+		"params":  map[string]any{"uri": uri},
+	})
+	return msg
+}
+
+// subscribedServer returns a server on a fake clock with session "a"
+// registered.
+func subscribedServer(t *testing.T, now time.Time) (*Server, *fakeClock, *testSession) {
+	t.Helper()
+	clock := newFakeClock(now)
+	s := NewServer()
+	s.TimeManager = clock
+	session := newTestSession("a")
+	if err := s.MCPServer.RegisterSession(context.Background(), session); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Subscriptions.End("a") })
+	return s, clock, session
+}
+
+// subscribe subscribes session "a" to uri through the server and checks the
+// reply.
+func subscribe(t *testing.T, s *Server, method, uri string) {
+	t.Helper()
+	msg := s.interceptSubscription(context.Background(), "a", subscriptionMessage(method, uri))
+	resp, ok := s.MCPServer.HandleMessage(context.Background(), msg).(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("%s %s: reply is not a result", method, uri)
+	}
+	if id := fmt.Sprint(resp.ID.Value()); id != "7" {
+		t.Errorf("%s %s: reply ID = %v, want 7", method, uri, resp.ID.Value())
+	}
+}
+
+// nextUpdate waits for a notifications/resources/updated notification and
+// returns its URI.
+func nextUpdate(t *testing.T, session *testSession) string {
+	t.Helper()
+	select {
+	case n := <-session.notifications:
+		if n.Method != string(mcp.MethodNotificationResourceUpdated) {
+			t.Fatalf("notification method = %s", n.Method)
+		}
+		return n.Params.AdditionalFields["uri"].(string)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification")
+		return ""
+	}
+}
+
+// noUpdate checks that no notification is waiting.
+func noUpdate(t *testing.T, session *testSession) {
+	t.Helper()
+	select {
+	case n := <-session.notifications:
+		t.Errorf("unexpected notification %+v", n)
+	default:
+	}
+}
+
+func TestSubscriptionClockTicks(t *testing.T) {
+	s, clock, session := subscribedServer(t, time.Date(2025, time.January, 1, 12, 0, 30, 0, time.UTC))
+	subscribe(t, s, methodResourcesSubscribe, "time://now/UTC")
+
+	clock.BlockUntil(t, 1)
+	clock.Advance(29 * time.Second)
+	clock.BlockUntil(t, 1)
+	noUpdate(t, session)
+
+	clock.Advance(time.Second)
+	if uri := nextUpdate(t, session); uri != "time://now/UTC" {
+		t.Errorf("updated %s, want time://now/UTC", uri)
+	}
+	clock.BlockUntil(t, 1)
+	clock.Advance(time.Minute)
+	if uri := nextUpdate(t, session); uri != "time://now/UTC" {
+		t.Errorf("updated %s, want time://now/UTC", uri)
+	}
+
+	subscribe(t, s, methodResourcesUnsubscribe, "time://now/UTC")
+	clock.BlockUntil(t, 0)
+	clock.Advance(time.Minute)
+	noUpdate(t, session)
+	if uris := s.Subscriptions.URIs("a"); len(uris) != 0 {
+		t.Errorf("subscriptions after unsubscribing = %v", uris)
+	}
+}
+
+func TestSubscriptionDeadlines(t *testing.T) {
+	s, clock, session := subscribedServer(t, time.Date(2025, time.September, 30, 20, 58, 30, 0, time.UTC))
+	ctx := context.Background()
+	addEvent := func(name, dateTime string) {
+		t.Helper()
+		req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"name": name, "dateTime": dateTime, "timeZone": "America/New_York"}}}
+		if got, _ := s.AddEvent(ctx, req); got.IsError {
+			t.Fatalf("addEvent %s: %+v", name, got)
+		}
+	}
+	addEvent("Q3 report", "2025-09-30 17:00")
+
+	subscribe(t, s, methodResourcesSubscribe, "time://events/Q3%20report")
+	clock.BlockUntil(t, 1)
+	clock.Advance(90 * time.Second)
+	if uri := nextUpdate(t, session); uri != "time://events/Q3%20report" {
+		t.Errorf("updated %s when the deadline passed", uri)
+	}
+	// A passed deadline does not fire again.
+	clock.BlockUntil(t, 0)
+
+	// Moving the deadline notifies at once and again when it passes.
+	addEvent("q3 report", "2025-09-30 17:30")
+	if uri := nextUpdate(t, session); uri != "time://events/Q3%20report" {
+		t.Errorf("updated %s when the deadline moved", uri)
+	}
+	addEvent("Launch", "2025-10-01 09:00")
+	noUpdate(t, session)
+	clock.BlockUntil(t, 1)
+	clock.Advance(30 * time.Minute)
+	if uri := nextUpdate(t, session); uri != "time://events/Q3%20report" {
+		t.Errorf("updated %s when the moved deadline passed", uri)
+	}
+
+	// Ending the session stops its scheduler.
+	subscribe(t, s, methodResourcesSubscribe, "time://now")
+	clock.BlockUntil(t, 1)
+	s.MCPServer.UnregisterSession(ctx, "a")
+	clock.BlockUntil(t, 0)
+	if uris := s.Subscriptions.URIs("a"); len(uris) != 0 {
+		t.Errorf("subscriptions after the session ended = %v", uris)
+	}
+}
+
+func TestSubscriptionHandler(t *testing.T) {
+	s := NewServer()
+	if err := s.MCPServer.RegisterSession(context.Background(), newTestSession("http-1")); err != nil {
+		t.Fatal(err)
+	}
+	var got string
+	handler := s.SubscriptionHandler(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = string(body)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(string(subscriptionMessage(methodResourcesSubscribe, "time://now"))))
+	req.Header.Set("Mcp-Session-Id", "http-1")
+	handler(httptest.NewRecorder(), req)
+	if want := `{"id":7,"jsonrpc":"2.0","method":"ping"}`; got != want {
+		t.Errorf("subscription passed on as %s, want %s", got, want)
+	}
+	if uris := s.Subscriptions.URIs("http-1"); len(uris) != 1 || uris[0] != "time://now" {
+		t.Errorf("subscriptions = %v", uris)
+	}
+	s.Subscriptions.End("http-1")
+
+	// Requests from unknown or missing sessions are left for mcp-go to
+	// reject, and start no scheduler.
+	for _, id := range []string{"made-up", ""} {
+		subscription := string(subscriptionMessage(methodResourcesSubscribe, "time://now"))
+		req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(subscription))
+		if id != "" {
+			req.Header.Set("Mcp-Session-Id", id)
+		}
+		handler(httptest.NewRecorder(), req)
+		if got != subscription {
+			t.Errorf("subscription from session %q passed on as %s", id, got)
+		}
+		if uris := s.Subscriptions.URIs(id); len(uris) != 0 {
+			t.Errorf("subscriptions of session %q = %v", id, uris)
+		}
+	}
+	if n := len(s.Subscriptions.sessions); n != 0 {
+		t.Errorf("%d sessions have schedulers, want none", n)
+	}
+
+	other := `{"jsonrpc":"2.0","id":8,"method":"tools/list"}`
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(other)))
+	if got != other {
+		t.Errorf("other request passed on as %s", got)
+	}
+}
+
+func TestSubscriptionReader(t *testing.T) {
+	s := NewServer()
+	if err := s.MCPServer.RegisterSession(context.Background(), newTestSession(stdioSessionID)); err != nil {
+		t.Fatal(err)
+	}
+	input := `{"jsonrpc":"2.0","id":1,"method":"tools/list"}` + "\n" +
+		string(subscriptionMessage(methodResourcesSubscribe, "time://now")) + "\n" +
+		`{"jsonrpc":"2.0","id":2,"method":"ping"}`
+	scanner := bufio.NewScanner(s.SubscriptionReader(strings.NewReader(input)))
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	want := []string{
+		`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`,
+		`{"id":7,"jsonrpc":"2.0","method":"ping"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"ping"}`,
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("stdin passed on as\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+	if uris := s.Subscriptions.URIs(stdioSessionID); len(uris) != 1 {
+		t.Errorf("subscriptions = %v", uris)
+	}
+	s.Subscriptions.End(stdioSessionID)
+}
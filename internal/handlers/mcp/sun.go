@@ -0,0 +1,192 @@
+package mcp
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// The altitudes of the Sun's centre that mark sunrise and sunset, allowing
+// for refraction and the Sun's radius, and the ends of twilight.
+const (
+	sunriseAltitude          = -0.833
+	civilTwilightAltitude    = -6
+	nauticalTwilightAltitude = -12
+	astronomicalAltitude     = -18
+)
+
+// SunCrossing is when the Sun crosses an altitude rising or setting.  When
+// it does not, Always says why: "above" if the Sun stays above that
+// altitude all day and "below" if it stays below.
+type SunCrossing struct {
+	At     time.Time
+	Always string
+}
+
+// Occurs reports whether the Sun crosses the altitude.
+func (c SunCrossing) Occurs() bool {
+	return c.Always == ""
+}
+
+// SunDay holds the times of the Sun's rising, setting and twilight on one
+// day at one place.
+type SunDay struct {
+	SolarNoon        time.Time
+	NoonElevation    float64
+	Sunrise          SunCrossing
+	Sunset           SunCrossing
+	CivilDawn        SunCrossing
+	CivilDusk        SunCrossing
+	NauticalDawn     SunCrossing
+	NauticalDusk     SunCrossing
+	AstronomicalDawn SunCrossing
+	AstronomicalDusk SunCrossing
+}
+
+// DayLength is the time from sunrise to sunset: a whole day in polar day
+// and none in polar night.
+func (d SunDay) DayLength() time.Duration {
+	switch {
+	case d.Sunrise.Occurs() && d.Sunset.Occurs():
+		return d.Sunset.At.Sub(d.Sunrise.At)
+	case d.Sunrise.Always == "above":
+		return 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// Daylight is "normal" on a day with a sunrise and sunset, "polar day" when
+// the Sun stays up and "polar night" when it stays down.
+func (d SunDay) Daylight() string {
+	switch d.Sunrise.Always {
+	case "above":
+		return "polar day"
+	case "below":
+		return "polar night"
+	default:
+		return "normal"
+	}
+}
+
+// sunDay computes the Sun's times on the calendar date of date, in its
+// location, at latitude and longitude in degrees.
+func sunDay(date time.Time, latitude, longitude float64) SunDay {
+	// The formulas divide by the cosine of the latitude.
+	latitude = math.Max(-89.9999, math.Min(89.9999, latitude))
+	loc := date.Location()
+	day := civilDate(date)
+	// Start from noon on the zone's clock and take the nearest transit,
+	// moving a day if the zone is so far from the longitude's solar time
+	// that the transit falls on another local date.
+	transit := momentFromTime(time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, loc))
+	for range 2 {
+		for range 4 {
+			transit -= sunHourAngle(transit, longitude) / siderealDegreesPerDay
+		}
+		shift := int(day.Sub(civilDate(timeFromMoment(transit).In(loc))).Hours() / 24)
+		if shift == 0 {
+			break
+		}
+		transit += float64(shift)
+	}
+	elevation, _ := sunAltitudeAzimuth(transit, latitude, longitude)
+
+	crossing := func(altitude float64, rising bool) SunCrossing {
+		t := transit
+		for range 6 {
+			_, declination := sunEquatorial(t)
+			cosH := (sinDeg(altitude) - sinDeg(latitude)*sinDeg(declination)) / (cosDeg(latitude) * cosDeg(declination))
+			switch {
+			case cosH < -1:
+				return SunCrossing{Always: "above"}
+			case cosH > 1:
+				return SunCrossing{Always: "below"}
+			}
+			target := acosDeg(cosH)
+			if rising {
+				target = -target
+			}
+			t += signedDegrees(target-sunHourAngle(t, longitude)) / siderealDegreesPerDay
+		}
+		return SunCrossing{At: timeFromMoment(t).In(loc)}
+	}
+	return SunDay{
+		SolarNoon:        timeFromMoment(transit).In(loc),
+		NoonElevation:    elevation,
+		Sunrise:          crossing(sunriseAltitude, true),
+		Sunset:           crossing(sunriseAltitude, false),
+		CivilDawn:        crossing(civilTwilightAltitude, true),
+		CivilDusk:        crossing(civilTwilightAltitude, false),
+		NauticalDawn:     crossing(nauticalTwilightAltitude, true),
+		NauticalDusk:     crossing(nauticalTwilightAltitude, false),
+		AstronomicalDawn: crossing(astronomicalAltitude, true),
+		AstronomicalDusk: crossing(astronomicalAltitude, false),
+	}
+}
+
+// describeSunCrossing gives the time of c, with its date if it is not
+// date, or why there is none, e.g. "none, the Sun stays above -6° all
+// day".
+func describeSunCrossing(c SunCrossing, altitude float64, date time.Time) string {
+	switch c.Always {
+	case "above":
+		return fmt.Sprintf("none, the Sun stays above %g° all day", altitude)
+	case "below":
+		return fmt.Sprintf("none, the Sun stays below %g° all day", altitude)
+	}
+	if c.At.Year() != date.Year() || c.At.YearDay() != date.YearDay() {
+		return c.At.Format("15:04 MST on " + dateFormat)
+	}
+	return c.At.Format("15:04 MST")
+}
+
+// describeElevation writes an elevation as e.g. "3.1° below the horizon".
+func describeElevation(elevation float64) string {
+	if elevation < 0 {
+		return fmt.Sprintf("%.1f° below the horizon", -elevation)
+	}
+	return fmt.Sprintf("%.1f° above the horizon", elevation)
+}
+
+// formatCoordinates writes a latitude and longitude as e.g. "39.7392°N,
+// 104.9903°W".
+func formatCoordinates(latitude, longitude float64) string {
+	ns, ew := "N", "E"
+	if latitude < 0 {
+		ns = "S"
+	}
+	if longitude < 0 {
+		ew = "W"
+	}
+	return fmt.Sprintf("%.4f°%s, %.4f°%s", math.Abs(latitude), ns, math.Abs(longitude), ew)
+}
+
+// compassPoint names the nearest of the 16 points of the compass to an
+// azimuth, e.g. "WNW".
+func compassPoint(azimuth float64) string {
+	points := []string{"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"}
+	return points[int(math.Round(degrees(azimuth)/22.5))%16]
+}
+
+// formatDayLength writes a day length as e.g. "14 hours, 51 minutes".
+func formatDayLength(d time.Duration) string {
+	minutes := int(d.Round(time.Minute).Minutes())
+	return plural(minutes/60, "hour") + ", " + plural(minutes%60, "minute")
+}
+
+// solarPhase names the part of the day at which the Sun has an elevation.
+func solarPhase(elevation float64) string {
+	switch {
+	case elevation >= sunriseAltitude:
+		return "day"
+	case elevation >= civilTwilightAltitude:
+		return "civil twilight"
+	case elevation >= nauticalTwilightAltitude:
+		return "nautical twilight"
+	case elevation >= astronomicalAltitude:
+		return "astronomical twilight"
+	default:
+		return "night"
+	}
+}
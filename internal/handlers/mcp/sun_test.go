@@ -0,0 +1,113 @@
+package mcp
+
+import (
+	"testing"
+	"time"
+)
+
+// sunReferenceTimes are sunrise and sunset times, to the minute, worked out
+// with the NOAA Solar Calculator's equations.  Kiritimati, at UTC+14, and
+// Apia, at UTC+13, keep clocks more than twelve hours from their solar
+// time.
+var sunReferenceTimes = []struct {
+	place               string
+	latitude, longitude float64
+	timeZone            string
+	date                string
+	sunrise, sunset     string
+}{
+	{place: "Denver", latitude: 39.7392, longitude: -104.9903, timeZone: "America/Denver", date: "2025-06-03", sunrise: "05:32", sunset: "20:24"},
+	{place: "London", latitude: 51.5074, longitude: -0.1278, timeZone: "Europe/London", date: "2025-12-21", sunrise: "08:04", sunset: "15:53"},
+	{place: "Sydney", latitude: -33.8688, longitude: 151.2093, timeZone: "Australia/Sydney", date: "2025-06-21", sunrise: "07:00", sunset: "16:53"},
+	{place: "Null Island", latitude: 0, longitude: 0, timeZone: "UTC", date: "2025-03-20", sunrise: "06:04", sunset: "18:10"},
+	{place: "Kiritimati", latitude: 1.87, longitude: -157.4, timeZone: "Pacific/Kiritimati", date: "2025-01-01", sunrise: "06:33", sunset: "18:33"},
+	{place: "Apia", latitude: -13.8333, longitude: -171.7667, timeZone: "Pacific/Apia", date: "2025-01-01", sunrise: "06:03", sunset: "18:58"},
+}
+
+func TestSunDayReferenceTimes(t *testing.T) {
+	for _, tc := range sunReferenceTimes {
+		t.Run(tc.place, func(t *testing.T) {
+			loc, err := time.LoadLocation(tc.timeZone)
+			if err != nil {
+				t.Fatal(err)
+			}
+			date, _ := time.ParseInLocation(dateFormat, tc.date, loc)
+			day := sunDay(date, tc.latitude, tc.longitude)
+			if got := day.SolarNoon.Format(dateFormat); got != tc.date {
+				t.Errorf("solar noon on %s, want %s", got, tc.date)
+			}
+			for _, event := range []struct {
+				name     string
+				crossing SunCrossing
+				want     string
+			}{
+				{"sunrise", day.Sunrise, tc.sunrise},
+				{"sunset", day.Sunset, tc.sunset},
+			} {
+				want, _ := time.ParseInLocation(dateFormat+" 15:04", tc.date+" "+event.want, loc)
+				if !event.crossing.Occurs() {
+					t.Errorf("%s: no %s, want %s", tc.date, event.name, event.want)
+					continue
+				}
+				if diff := event.crossing.At.Sub(want).Abs(); diff > 2*time.Minute {
+					t.Errorf("%s: %s = %s, want %s", tc.date, event.name, event.crossing.At.Format("15:04:05"), event.want)
+				}
+			}
+			if !day.CivilDawn.At.Before(day.Sunrise.At) || !day.SolarNoon.After(day.Sunrise.At) || !day.Sunset.At.After(day.SolarNoon) || !day.CivilDusk.At.After(day.Sunset.At) {
+				t.Errorf("%s: events out of order: %+v", tc.date, day)
+			}
+		})
+	}
+}
+
+func TestSunDayPolar(t *testing.T) {
+	tromso, err := time.LoadLocation("Europe/Oslo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		date         string
+		wantDaylight string
+		wantLength   time.Duration
+	}{
+		{date: "2025-06-21", wantDaylight: "polar day", wantLength: 24 * time.Hour},
+		{date: "2025-12-21", wantDaylight: "polar night", wantLength: 0},
+		{date: "2025-03-20", wantDaylight: "normal"},
+	}
+	for _, tc := range testCases {
+		date, _ := time.ParseInLocation(dateFormat, tc.date, tromso)
+		day := sunDay(date, 69.6492, 18.9553)
+		if got := day.Daylight(); got != tc.wantDaylight {
+			t.Errorf("%s: Daylight() = %s, want %s", tc.date, got, tc.wantDaylight)
+		}
+		if tc.wantDaylight != "normal" && day.DayLength() != tc.wantLength {
+			t.Errorf("%s: DayLength() = %v, want %v", tc.date, day.DayLength(), tc.wantLength)
+		}
+	}
+
+	// At the pole itself the Sun circles the sky without setting.
+	day := sunDay(time.Date(2025, time.June, 21, 0, 0, 0, 0, time.UTC), 90, 0)
+	if day.Daylight() != "polar day" {
+		t.Errorf("North Pole in June: Daylight() = %s, want polar day", day.Daylight())
+	}
+}
+
+func TestSunAltitudeAzimuth(t *testing.T) {
+	// At the March equinox the Sun passes nearly overhead on the equator at
+	// solar noon, and sets due west.
+	noon := momentFromTime(time.Date(2025, time.March, 20, 12, 7, 23, 0, time.UTC))
+	if elevation, _ := sunAltitudeAzimuth(noon, 0, 0); elevation < 89.5 {
+		t.Errorf("elevation at solar noon = %.2f, want about 90", elevation)
+	}
+	evening := momentFromTime(time.Date(2025, time.March, 20, 17, 0, 0, 0, time.UTC))
+	elevation, azimuth := sunAltitudeAzimuth(evening, 0, 0)
+	if elevation < 15 || elevation > 20 || azimuth < 268 || azimuth > 272 {
+		t.Errorf("elevation, azimuth at 17:00 = %.2f, %.2f, want about 17, 270", elevation, azimuth)
+	}
+	if got := compassPoint(azimuth); got != "W" {
+		t.Errorf("compassPoint(%.2f) = %s, want W", azimuth, got)
+	}
+	if got := compassPoint(355); got != "N" {
+		t.Errorf("compassPoint(355) = %s, want N", got)
+	}
+}
@@ -0,0 +1,229 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TimerScope selects who can see a timer.
+type TimerScope string
+
+const (
+	// TimerScopeSession timers belong to the MCP session that started them.
+	TimerScopeSession TimerScope = "session"
+	// TimerScopeGlobal timers are shared by every session.
+	TimerScopeGlobal TimerScope = "global"
+)
+
+// ParseTimerScope parses a scope argument.  An empty string selects
+// TimerScopeSession.
+func ParseTimerScope(s string) (TimerScope, error) {
+	switch TimerScope(s) {
+	case "":
+		return TimerScopeSession, nil
+	case TimerScopeSession, TimerScopeGlobal:
+		return TimerScope(s), nil
+	default:
+		return "", NewInvalidTimerScopeError(s)
+	}
+}
+
+// Lap is one split recorded on a running timer.
+type Lap struct {
+	Time  time.Time `json:"time"`
+	Label string    `json:"label,omitempty"`
+}
+
+// Timer is a named stopwatch.  Stopped is zero while it runs.
+type Timer struct {
+	Name    string    `json:"name"`
+	Started time.Time `json:"started"`
+	Laps    []Lap     `json:"laps,omitempty"`
+	Stopped time.Time `json:"stopped,omitzero"`
+}
+
+// Running reports whether the timer has not been stopped.
+func (t Timer) Running() bool {
+	return t.Stopped.IsZero()
+}
+
+// Elapsed returns how long the timer ran, up to now if it is still running.
+func (t Timer) Elapsed(now time.Time) time.Duration {
+	if t.Running() {
+		return now.Sub(t.Started)
+	}
+	return t.Stopped.Sub(t.Started)
+}
+
+func (t Timer) clone() Timer {
+	t.Laps = append([]Lap(nil), t.Laps...)
+	return t
+}
+
+// TimerStore holds named timers, keyed by scope: the MCP session ID for
+// session timers, or a shared key for global ones.  When a file is set the
+// timers are saved to it after every change, so that they survive a
+// restart.
+type TimerStore struct {
+	mu     sync.Mutex
+	timers map[string]map[string]*Timer
+	path   string
+}
+
+// globalTimerKey is the store key of global timers; session keys are
+// prefixed so that no session ID can collide with it.
+const globalTimerKey = "global"
+
+func NewTimerStore() *TimerStore {
+	return &TimerStore{timers: map[string]map[string]*Timer{}}
+}
+
+// timerKey returns the store key for a scope as seen from session id.
+func timerKey(scope TimerScope, id string) string {
+	if scope == TimerScopeGlobal {
+		return globalTimerKey
+	}
+	return "session/" + id
+}
+
+// SetFile loads the timers saved in path, if it exists, and saves every
+// later change there.
+func (ts *TimerStore) SetFile(path string) error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return NewTimerStoreError(path, err)
+	default:
+		timers := map[string]map[string]*Timer{}
+		if err := json.Unmarshal(data, &timers); err != nil {
+			return NewTimerStoreError(path, err)
+		}
+		ts.timers = timers
+	}
+	ts.path = path
+	return nil
+}
+
+// save writes the timers to the store's file, if any.  The caller must hold
+// ts.mu.
+func (ts *TimerStore) save() error {
+	if ts.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(ts.timers, "", "  ")
+	if err != nil {
+		return NewTimerStoreError(ts.path, err)
+	}
+	if err := writeFileAtomic(ts.path, data); err != nil {
+		return NewTimerStoreError(ts.path, err)
+	}
+	return nil
+}
+
+// Start starts a timer at now.  A stopped timer of the same name is
+// replaced; a running one is an error.
+func (ts *TimerStore) Start(key, name string, now time.Time) (Timer, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if t, ok := ts.timers[key][name]; ok && t.Running() {
+		return Timer{}, NewTimerRunningError(name, t.Started)
+	}
+	if ts.timers[key] == nil {
+		ts.timers[key] = map[string]*Timer{}
+	}
+	t := &Timer{Name: name, Started: now}
+	ts.timers[key][name] = t
+	return t.clone(), ts.save()
+}
+
+// running returns the named timer if it exists and is running.  The caller
+// must hold ts.mu.
+func (ts *TimerStore) running(key, name string) (*Timer, error) {
+	t, ok := ts.timers[key][name]
+	if !ok {
+		return nil, NewTimerNotFoundError(name)
+	}
+	if !t.Running() {
+		return nil, NewTimerStoppedError(name, t.Stopped)
+	}
+	return t, nil
+}
+
+// Lap records a split on a running timer.
+func (ts *TimerStore) Lap(key, name, label string, now time.Time) (Timer, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	t, err := ts.running(key, name)
+	if err != nil {
+		return Timer{}, err
+	}
+	t.Laps = append(t.Laps, Lap{Time: now, Label: label})
+	return t.clone(), ts.save()
+}
+
+// Stop stops a running timer.  When remove is set the timer is forgotten
+// once stopped.
+func (ts *TimerStore) Stop(key, name string, now time.Time, remove bool) (Timer, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	t, err := ts.running(key, name)
+	if err != nil {
+		return Timer{}, err
+	}
+	t.Stopped = now
+	if remove {
+		delete(ts.timers[key], name)
+		if len(ts.timers[key]) == 0 {
+			delete(ts.timers, key)
+		}
+	}
+	return t.clone(), ts.save()
+}
+
+// List returns the timers under key, oldest first.
+func (ts *TimerStore) List(key string) []Timer {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	out := make([]Timer, 0, len(ts.timers[key]))
+	for _, t := range ts.timers[key] {
+		out = append(out, t.clone())
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Started.Equal(out[j].Started) {
+			return out[i].Name < out[j].Name
+		}
+		return out[i].Started.Before(out[j].Started)
+	})
+	return out
+}
+
+// EndSession forgets the timers of session id.  When the timers are saved
+// to a file, those of the stdio session are kept, since a stdio client
+// reconnects with the same session ID after a restart; other sessions, such
+// as streamable HTTP ones, get a new ID each time and never come back.
+func (ts *TimerStore) EndSession(id string) error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	key := timerKey(TimerScopeSession, id)
+	if _, ok := ts.timers[key]; !ok || (ts.path != "" && id == stdioSessionID) {
+		return nil
+	}
+	delete(ts.timers, key)
+	return ts.save()
+}
+
+// timerPhrase describes the time from start to end, e.g. "1 hour, 5 minutes".
+func timerPhrase(start, end time.Time) string {
+	if end.Before(start) {
+		end = start
+	}
+	return calendarBreakdown(start, end).phrase()
+}
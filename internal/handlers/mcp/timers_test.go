@@ -0,0 +1,125 @@
+package mcp
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTimerStore(t *testing.T) {
+	ts := NewTimerStore()
+	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+	if _, err := ts.Start("k", "build", start); err != nil {
+		t.Fatal(err)
+	}
+	var running *TimerRunningError
+	if _, err := ts.Start("k", "build", start); !errors.As(err, &running) {
+		t.Errorf("starting a running timer: error = %v, want TimerRunningError", err)
+	}
+	if _, err := ts.Start("other", "build", start); err != nil {
+		t.Errorf("a timer in another scope is separate: error = %v", err)
+	}
+
+	timer, err := ts.Lap("k", "build", "compiled", start.Add(time.Minute))
+	if err != nil || len(timer.Laps) != 1 || timer.Laps[0].Label != "compiled" {
+		t.Errorf("Lap() = %+v, %v", timer, err)
+	}
+	timer, err = ts.Stop("k", "build", start.Add(5*time.Minute), false)
+	if err != nil || timer.Running() || timer.Elapsed(start.Add(time.Hour)) != 5*time.Minute {
+		t.Errorf("Stop() = %+v, %v", timer, err)
+	}
+
+	var stopped *TimerStoppedError
+	if _, err := ts.Lap("k", "build", "", start.Add(6*time.Minute)); !errors.As(err, &stopped) {
+		t.Errorf("lap on a stopped timer: error = %v, want TimerStoppedError", err)
+	}
+	var notFound *TimerNotFoundError
+	if _, err := ts.Stop("k", "test", start, false); !errors.As(err, &notFound) {
+		t.Errorf("stopping an unknown timer: error = %v, want TimerNotFoundError", err)
+	}
+
+	// Starting a stopped timer restarts it.
+	if timer, err := ts.Start("k", "build", start.Add(time.Hour)); err != nil || !timer.Running() || len(timer.Laps) != 0 {
+		t.Errorf("restart = %+v, %v", timer, err)
+	}
+	if _, err := ts.Start("k", "deploy", start.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if list := ts.List("k"); len(list) != 2 || list[0].Name != "deploy" || list[1].Name != "build" {
+		t.Errorf("List() = %+v, want deploy then build", list)
+	}
+
+	if _, err := ts.Stop("k", "deploy", start.Add(2*time.Minute), true); err != nil {
+		t.Fatal(err)
+	}
+	if list := ts.List("k"); len(list) != 1 {
+		t.Errorf("List() after removing = %+v", list)
+	}
+}
+
+func TestTimerStoreFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "timers.json")
+	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+	ts := NewTimerStore()
+	if err := ts.SetFile(path); err != nil {
+		t.Fatalf("SetFile() on a missing file returned error: %v", err)
+	}
+	key := timerKey(TimerScopeSession, "stdio")
+	if _, err := ts.Start(key, "build", start); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ts.Lap(key, "build", "halfway", start.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	// Saved stdio timers outlive the session; those of other sessions,
+	// whose IDs are not reused, do not.
+	httpKey := timerKey(TimerScopeSession, "http-session")
+	if _, err := ts.Start(httpKey, "deploy", start); err != nil {
+		t.Fatal(err)
+	}
+	if err := ts.EndSession("stdio"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ts.EndSession("http-session"); err != nil {
+		t.Fatal(err)
+	}
+
+	restarted := NewTimerStore()
+	if err := restarted.SetFile(path); err != nil {
+		t.Fatal(err)
+	}
+	list := restarted.List(key)
+	if len(list) != 1 || !list[0].Started.Equal(start) || len(list[0].Laps) != 1 || list[0].Laps[0].Label != "halfway" || !list[0].Running() {
+		t.Errorf("timers after restart = %+v", list)
+	}
+	if list := restarted.List(httpKey); len(list) != 0 {
+		t.Errorf("timers of an ended HTTP session after restart = %+v", list)
+	}
+
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var storeErr *TimerStoreError
+	if err := NewTimerStore().SetFile(path); !errors.As(err, &storeErr) {
+		t.Errorf("SetFile() on a corrupt file: error = %v, want TimerStoreError", err)
+	}
+}
+
+func TestTimerStoreEndSession(t *testing.T) {
+	ts := NewTimerStore()
+	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	ts.Start(timerKey(TimerScopeSession, "a"), "build", start)
+	ts.Start(timerKey(TimerScopeGlobal, "a"), "release", start)
+
+	ts.EndSession("a")
+	if list := ts.List(timerKey(TimerScopeSession, "a")); len(list) != 0 {
+		t.Errorf("session timers after the session ended = %+v", list)
+	}
+	if list := ts.List(timerKey(TimerScopeGlobal, "b")); len(list) != 1 {
+		t.Errorf("global timers after a session ended = %+v", list)
+	}
+}
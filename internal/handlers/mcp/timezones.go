@@ -0,0 +1,301 @@
+package mcp
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	mcp_go "github.com/mark3labs/mcp-go/mcp"
+)
+
+// zoneinfoZip is the IANA time zone database built into the server: Go's
+// lib/time/zoneinfo.zip with a +VERSION file naming the tzdata release.
+//
+//go:embed data/zoneinfo.zip
+var zoneinfoZip []byte
+
+// embeddedTimeZoneSource is the Source of the built-in database.
+const embeddedTimeZoneSource = "embedded"
+
+// zoneinfoDirs are the directories searched for the IANA time zone
+// database, as by the time package on Unix systems.
+var zoneinfoDirs = []string{
+	"/usr/share/zoneinfo/",
+	"/usr/share/lib/zoneinfo/",
+	"/usr/lib/locale/TZ/",
+	"/etc/zoneinfo/",
+}
+
+// TimeZoneDatabase is a copy of the IANA time zone database, in a zoneinfo
+// directory or zip file, that time zones are loaded from.  Loading zones
+// from one copy rather than whatever the host provides keeps the results
+// the same wherever the server runs.
+type TimeZoneDatabase struct {
+	// Source is "embedded" for the copy built into the server, or the path
+	// of a zoneinfo directory or zip file.
+	Source string
+	// Version is the tzdata release, e.g. "2025b", or empty if the copy
+	// does not record it.
+	Version string
+	fsys    fs.FS
+	names   func() []string
+	index   func() *zoneIndex
+	zones   sync.Map
+}
+
+func newTimeZoneDatabase(source string, fsys fs.FS) *TimeZoneDatabase {
+	db := &TimeZoneDatabase{Source: source, Version: tzdataVersion(fsys), fsys: fsys}
+	db.names = sync.OnceValue(func() []string { return zoneNamesIn(fsys) })
+	db.index = sync.OnceValue(func() *zoneIndex { return newZoneIndex(db.names(), zoneAliases) })
+	return db
+}
+
+// EmbeddedTimeZoneDatabase returns the database built into the server.
+var EmbeddedTimeZoneDatabase = sync.OnceValue(func() *TimeZoneDatabase {
+	r, err := zip.NewReader(bytes.NewReader(zoneinfoZip), int64(len(zoneinfoZip)))
+	if err != nil {
+		panic(fmt.Sprintf("reading the embedded zoneinfo.zip: %v", err))
+	}
+	return newTimeZoneDatabase(embeddedTimeZoneSource, r)
+})
+
+// OpenTimeZoneDatabase opens the zoneinfo directory or zip file at path.
+func OpenTimeZoneDatabase(path string) (*TimeZoneDatabase, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, NewTimeZoneDatabaseError(path, err)
+	}
+	var db *TimeZoneDatabase
+	if info.IsDir() {
+		db = newTimeZoneDatabase(path, os.DirFS(path))
+	} else {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, NewTimeZoneDatabaseError(path, err)
+		}
+		r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, NewTimeZoneDatabaseError(path, err)
+		}
+		db = newTimeZoneDatabase(path, r)
+	}
+	if len(db.Names()) == 0 {
+		return nil, NewTimeZoneDatabaseError(path, fmt.Errorf("it holds no time zones"))
+	}
+	return db, nil
+}
+
+// SystemTimeZoneDatabase opens the copy of the database installed on the
+// host, found as by the time package: the directory or zip file named by
+// the ZONEINFO environment variable, or else the first of zoneinfoDirs that
+// holds any zones.
+func SystemTimeZoneDatabase() (*TimeZoneDatabase, error) {
+	if zoneinfo := os.Getenv("ZONEINFO"); zoneinfo != "" {
+		if db, err := OpenTimeZoneDatabase(zoneinfo); err == nil {
+			return db, nil
+		}
+	}
+	for _, dir := range zoneinfoDirs {
+		if db, err := OpenTimeZoneDatabase(dir); err == nil {
+			return db, nil
+		}
+	}
+	return nil, NewTimeZoneDatabaseError("the system zoneinfo", fs.ErrNotExist)
+}
+
+// NewerThan reports whether db holds a later tzdata release than other.  A
+// copy that does not record its release is never newer.
+func (db *TimeZoneDatabase) NewerThan(other *TimeZoneDatabase) bool {
+	return compareTZDataVersions(db.Version, other.Version) > 0
+}
+
+// LoadLocation loads the zone name from db, treating "" and "UTC" as UTC
+// and "Local" as the host's zone like time.LoadLocation.
+func (db *TimeZoneDatabase) LoadLocation(name string) (*time.Location, error) {
+	switch name {
+	case "", "UTC":
+		return time.UTC, nil
+	case "Local":
+		return time.Local, nil
+	}
+	if loc, ok := db.zones.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	if !fs.ValidPath(name) || strings.Contains(name, `\`) {
+		return nil, fmt.Errorf("unknown time zone %s", name)
+	}
+	data, err := fs.ReadFile(db.fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %s", name)
+	}
+	loc, err := time.LoadLocationFromTZData(name, data)
+	if err != nil {
+		return nil, err
+	}
+	db.zones.Store(name, loc)
+	return loc, nil
+}
+
+// Names returns the names of the zones in db, sorted.
+func (db *TimeZoneDatabase) Names() []string {
+	return db.names()
+}
+
+// timeZoneDatabase is the database that zones are loaded from; nil means
+// the embedded one.
+var timeZoneDatabase atomic.Pointer[TimeZoneDatabase]
+
+// CurrentTimeZoneDatabase returns the database that zones are loaded from.
+func CurrentTimeZoneDatabase() *TimeZoneDatabase {
+	if db := timeZoneDatabase.Load(); db != nil {
+		return db
+	}
+	return EmbeddedTimeZoneDatabase()
+}
+
+// SetTimeZoneDatabase makes the server load zones from db.  Call it at
+// startup, before any requests are handled.
+func SetTimeZoneDatabase(db *TimeZoneDatabase) {
+	timeZoneDatabase.Store(db)
+}
+
+// TimeZoneNames returns the names of the IANA time zones in the current
+// database, sorted.
+func TimeZoneNames() []string {
+	return CurrentTimeZoneDatabase().Names()
+}
+
+// zoneNamesIn lists the zones in a zoneinfo tree.
+func zoneNamesIn(fsys fs.FS) []string {
+	var names []string
+	fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			// posix/ and right/ duplicate the zones with other leap
+			// second handling.
+			if name == "posix" || name == "right" {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if isZoneName(name) && isTZif(fsys, name) {
+			names = append(names, name)
+		}
+		return nil
+	})
+	sort.Strings(names)
+	return names
+}
+
+// isZoneName reports whether a file in a zoneinfo tree is named like a time
+// zone, leaving out files such as "localtime", "posixrules", "+VERSION" and
+// "zone1970.tab".
+func isZoneName(name string) bool {
+	if name == "" || name[0] < 'A' || name[0] > 'Z' || strings.HasSuffix(name, "/") {
+		return false
+	}
+	return name != "Factory" && !strings.Contains(name, ".")
+}
+
+// isTZif reports whether the file name in fsys starts with the TZif magic
+// number.
+func isTZif(fsys fs.FS, name string) bool {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(f, magic); err != nil {
+		return false
+	}
+	return bytes.Equal(magic, []byte("TZif"))
+}
+
+// tzdataVersion reads the tzdata release of a zoneinfo tree from its
+// +VERSION file, or from the first line of tzdata.zi, "# version 2025b".
+func tzdataVersion(fsys fs.FS) string {
+	if data, err := fs.ReadFile(fsys, "+VERSION"); err == nil {
+		return strings.TrimSpace(string(data))
+	}
+	f, err := fsys.Open("tzdata.zi")
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	line, _ := bufio.NewReader(f).ReadString('\n')
+	if version, ok := strings.CutPrefix(strings.TrimSpace(line), "# version "); ok {
+		return version
+	}
+	return ""
+}
+
+// compareTZDataVersions compares tzdata releases such as "2024a" and
+// "2025b" by year and then by letter, returning -1, 0 or +1.  An empty
+// version is older than any other.
+func compareTZDataVersions(a, b string) int {
+	parse := func(v string) (int, string) {
+		i := strings.IndexFunc(v, func(r rune) bool { return r < '0' || r > '9' })
+		if i < 0 {
+			i = len(v)
+		}
+		year, err := strconv.Atoi(v[:i])
+		if err != nil {
+			return -1, ""
+		}
+		return year, v[i:]
+	}
+	aYear, aLetters := parse(a)
+	bYear, bLetters := parse(b)
+	switch {
+	case aYear != bYear:
+		return cmp.Compare(aYear, bYear)
+	case len(aLetters) != len(bLetters):
+		// Releases after "z" continue "za", "zb" and so on.
+		return cmp.Compare(len(aLetters), len(bLetters))
+	default:
+		return strings.Compare(aLetters, bLetters)
+	}
+}
+
+// describeTimeZoneDatabase names db and its release for messages, e.g.
+// "the built-in tzdata 2026c".
+func describeTimeZoneDatabase(db *TimeZoneDatabase) string {
+	version := "of unknown version"
+	if db.Version != "" {
+		version = db.Version
+	}
+	if db.Source == embeddedTimeZoneSource {
+		return "the built-in tzdata " + version
+	}
+	return fmt.Sprintf("tzdata %s from %s", version, db.Source)
+}
+
+// addTimeZoneDatabaseMeta is an initialize hook that reports the tzdata
+// release in use in the result's metadata, so that clients can tell which
+// rules the server's answers follow.
+func addTimeZoneDatabaseMeta(ctx context.Context, id any, message *mcp_go.InitializeRequest, result *mcp_go.InitializeResult) {
+	db := CurrentTimeZoneDatabase()
+	if result.Meta == nil {
+		result.Meta = &mcp_go.Meta{}
+	}
+	if result.Meta.AdditionalFields == nil {
+		result.Meta.AdditionalFields = map[string]any{}
+	}
+	result.Meta.AdditionalFields["tzdata"] = map[string]any{"version": db.Version, "source": db.Source}
+}
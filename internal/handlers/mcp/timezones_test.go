@@ -0,0 +1,170 @@
+package mcp
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestEmbeddedTimeZoneDatabase(t *testing.T) {
+	db := EmbeddedTimeZoneDatabase()
+	if db.Source != "embedded" || db.Version == "" {
+		t.Errorf("embedded database = %s %q, want a recorded version", db.Source, db.Version)
+	}
+	names := db.Names()
+	if !slices.Contains(names, "America/New_York") || slices.Contains(names, "+VERSION") {
+		t.Errorf("Names() = %d zones, want America/New_York and no +VERSION", len(names))
+	}
+
+	loc, err := db.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("LoadLocation() error = %v", err)
+	}
+	if _, offset := time.Date(2024, time.July, 1, 12, 0, 0, 0, loc).Zone(); offset != -4*3600 {
+		t.Errorf("offset in July = %d, want -14400", offset)
+	}
+	for _, name := range []string{"", "UTC"} {
+		if loc, err := db.LoadLocation(name); err != nil || loc != time.UTC {
+			t.Errorf("LoadLocation(%q) = %v, %v, want UTC", name, loc, err)
+		}
+	}
+	for _, name := range []string{"Mars/Olympus_Mons", "../etc/passwd", "/etc/passwd", "+VERSION"} {
+		if _, err := db.LoadLocation(name); err == nil {
+			t.Errorf("LoadLocation(%q) succeeded, want an error", name)
+		}
+	}
+}
+
+func TestOpenTimeZoneDatabase(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "zoneinfo.zip")
+	writeZoneinfoZip(t, path, map[string]string{"+VERSION": "2099a\n", "Europe/Paris": "", "zone.tab": "# not a zone\n"})
+
+	db, err := OpenTimeZoneDatabase(path)
+	if err != nil {
+		t.Fatalf("OpenTimeZoneDatabase() error = %v", err)
+	}
+	if db.Source != path || db.Version != "2099a" {
+		t.Errorf("database = %s %q, want %s 2099a", db.Source, db.Version, path)
+	}
+	if got := db.Names(); !slices.Equal(got, []string{"Europe/Paris"}) {
+		t.Errorf("Names() = %v, want [Europe/Paris]", got)
+	}
+	if _, err := db.LoadLocation("Europe/Paris"); err != nil {
+		t.Errorf("LoadLocation() error = %v", err)
+	}
+	if _, err := db.LoadLocation("Europe/Berlin"); err == nil {
+		t.Errorf("LoadLocation() of a zone missing from the zip succeeded")
+	}
+	if !db.NewerThan(EmbeddedTimeZoneDatabase()) || EmbeddedTimeZoneDatabase().NewerThan(db) {
+		t.Errorf("2099a is not newer than the embedded %s", EmbeddedTimeZoneDatabase().Version)
+	}
+
+	var dbErr *TimeZoneDatabaseError
+	if _, err := OpenTimeZoneDatabase(filepath.Join(dir, "missing.zip")); !errors.As(err, &dbErr) {
+		t.Errorf("opening a missing file: error = %v, want TimeZoneDatabaseError", err)
+	}
+	empty := filepath.Join(dir, "empty.zip")
+	writeZoneinfoZip(t, empty, map[string]string{"+VERSION": "2099a\n"})
+	if _, err := OpenTimeZoneDatabase(empty); !errors.As(err, &dbErr) {
+		t.Errorf("opening a zip with no zones: error = %v, want TimeZoneDatabaseError", err)
+	}
+	if _, err := OpenTimeZoneDatabase(dir); !errors.As(err, &dbErr) {
+		t.Errorf("opening a directory with no zones: error = %v, want TimeZoneDatabaseError", err)
+	}
+}
+
+// writeZoneinfoZip writes a zip of files to path.  An empty file content is
+// replaced by the embedded database's copy of that zone.
+func writeZoneinfoZip(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	w := zip.NewWriter(f)
+	for name, content := range files {
+		if content == "" {
+			data, err := fs.ReadFile(EmbeddedTimeZoneDatabase().fsys, name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			content = string(data)
+		}
+		fw, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCompareTZDataVersions(t *testing.T) {
+	testCases := []struct {
+		a, b string
+		want int
+	}{
+		{"2025b", "2025b", 0},
+		{"2025a", "2025b", -1},
+		{"2026a", "2025z", 1},
+		{"2025za", "2025z", 1},
+		{"", "2025a", -1},
+		{"", "", 0},
+	}
+	for _, tc := range testCases {
+		if got := compareTZDataVersions(tc.a, tc.b); got != tc.want {
+			t.Errorf("compareTZDataVersions(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestInitializeReportsTimeZoneDatabase(t *testing.T) {
+	s := NewServer()
+	msg, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "initialize",
+		"params": map[string]any{
+			"protocolVersion": mcp.LATEST_PROTOCOL_VERSION,
+			"clientInfo":      map[string]any{"name": "test", "version": "1.0.0"},
+		},
+	})
+	resp, ok := s.MCPServer.HandleMessage(context.Background(), msg).(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatal("initialize: no result")
+	}
+	data, err := json.Marshal(resp.Result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result struct {
+		Meta struct {
+			TZData struct {
+				Version string `json:"version"`
+				Source  string `json:"source"`
+			} `json:"tzdata"`
+		} `json:"_meta"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+	db := CurrentTimeZoneDatabase()
+	if result.Meta.TZData.Version != db.Version || result.Meta.TZData.Source != db.Source {
+		t.Errorf("initialize _meta.tzdata = %+v, want %s %s", result.Meta.TZData, db.Version, db.Source)
+	}
+}
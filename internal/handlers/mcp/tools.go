@@ -2,9 +2,12 @@ package mcp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"strings"
+	"sync"
 	"time"
 
 	mcp_go "github.com/mark3labs/mcp-go/mcp"
@@ -17,9 +20,15 @@ const dateTimeFormat = "2006-01-02 15:04:05"
 
 const dateTimeFormatTimeZone = "2006-01-02 15:04:05 -0700"
 
+// offsetProbeZone is used to detect whether an input carries its own offset.
+var offsetProbeZone = time.FixedZone("probe", 3600)
+
 type TimeManager interface {
 	Now() time.Time
 	LoadLocation(name string) (*time.Location, error)
+	// NewTimer returns a channel that receives the time once d has passed
+	// and a function that stops the timer, like time.NewTimer.
+	NewTimer(d time.Duration) (<-chan time.Time, func() bool)
 }
 
 // TimeOpts is a struct that holds options for parsing time.
@@ -27,47 +36,79 @@ type TimeOpts struct {
 	input      string
 	timeZone   string
 	timeFormat string
+	dstPolicy  DSTPolicy
+	// reference anchors relative expressions such as "tomorrow".  When zero
+	// the current time is used.
+	reference time.Time
 }
 
 // ParseTime creates a new TimeOpts instance with the provided input and
 // optional time zone.  If TimeZone is not provided, it defaults to UTC.
+// Inputs without an explicit offset are read as wall-clock time in the
+// requested zone.
 func ParseTime(opts *TimeOpts) (time.Time, error) {
+	wc, err := parseWallClock(opts)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return wc.Time, nil
+}
+
+// parseWallClock parses opts like ParseTime but also reports whether the
+// input fell in a DST gap or fold.
+func parseWallClock(opts *TimeOpts) (WallClock, error) {
 	if opts == nil {
-		return time.Time{}, NewNilTimeOptsError()
+		return WallClock{}, NewNilTimeOptsError()
 	}
 	if opts.input == "" {
-		return time.Time{}, NewNilInputTime()
-	}
-	if opts.timeFormat == "" {
-
+		return WallClock{}, NewNilInputTime()
 	}
 
-	var t time.Time
-	var err error
-	if opts.timeFormat != "" {
-		t, err = time.Parse(opts.timeFormat, opts.input)
-		if err != nil {
-			return time.Time{}, NewInvalidTimeFormatError(opts.input)
+	loc := time.UTC
+	if opts.timeZone != "" {
+		now := opts.reference
+		if now.IsZero() {
+			now = time.Now()
 		}
-	} else {
-
-		t, err = time.Parse(dateTimeFormat, opts.input)
+		var err error
+		loc, err = resolveTimeZone(opts.timeZone, now, CurrentTimeZoneDatabase().LoadLocation)
 		if err != nil {
-			t, err = time.Parse(dateFormat, opts.input)
-			if err != nil {
-				return time.Time{}, NewInvalidTimeFormatError(opts.input)
-			}
+			return WallClock{}, err
 		}
 	}
 
-	if opts.timeZone != "" {
-		loc, err := time.LoadLocation(opts.timeZone)
+	if opts.timeFormat == "" {
+		d, err := parseISO8601(opts.input)
 		if err != nil {
-			return time.Time{}, NewTimeZoneLoadError(opts.timeZone, err)
+			return parseRelativeWallClock(opts, loc, err)
 		}
-		t = t.In(loc)
+		if d.hasOffset {
+			// An inline offset names an instant and takes precedence over the
+			// requested time zone.
+			t := d.instant()
+			return WallClock{Time: t, Kind: WallClockNormal, Earlier: t, Later: t}, nil
+		}
+		if d.endOfDay {
+			// "24:00" is midnight at the start of the following day.
+			return resolveWallClock(d.year, d.month, d.day+1, 0, 0, 0, 0, loc, opts.dstPolicy)
+		}
+		return resolveWallClock(d.year, d.month, d.day, d.hour, d.minute, d.second, d.nsec, loc, opts.dstPolicy)
+	}
+
+	t, err := time.Parse(opts.timeFormat, opts.input)
+	if err != nil {
+		return WallClock{}, NewInvalidTimeFormatError(opts.input)
+	}
+
+	// Parsing again in a different default zone only moves the instant when
+	// the input has no offset of its own.
+	if probe, err := time.ParseInLocation(opts.timeFormat, opts.input, offsetProbeZone); err == nil && probe.Equal(t) {
+		return WallClock{Time: t, Kind: WallClockNormal, Earlier: t, Later: t}, nil
 	}
-	return t, nil
+
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	return resolveWallClock(year, month, day, hour, min, sec, t.Nanosecond(), loc, opts.dstPolicy)
 }
 
 type LiveTimeManager struct{}
@@ -77,13 +118,18 @@ func (l *LiveTimeManager) Now() time.Time {
 }
 
 func (l *LiveTimeManager) LoadLocation(name string) (*time.Location, error) {
-	loc, err := time.LoadLocation(name)
+	loc, err := CurrentTimeZoneDatabase().LoadLocation(name)
 	if err != nil {
 		return nil, NewTimeZoneLoadError(name, err)
 	}
 	return loc, nil
 }
 
+func (l *LiveTimeManager) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	t := time.NewTimer(d)
+	return t.C, t.Stop
+}
+
 func (s *Server) CurrentDateTime(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
 	tz := request.GetString("timeZone", "UTC")
 
@@ -92,9 +138,9 @@ func (s *Server) CurrentDateTime(ctx context.Context, request mcp_go.CallToolReq
 	// Get the zone name and offset
 	zoneName, offsetSeconds := now.Zone()
 
-	loc, err := s.TimeManager.LoadLocation(tz)
+	loc, err := s.loadLocation(tz)
 	if err != nil {
-		return nil, NewTimeZoneLoadError(tz, err)
+		return nil, err
 	}
 
 	t := now.In(loc)
@@ -102,14 +148,7 @@ func (s *Server) CurrentDateTime(ctx context.Context, request mcp_go.CallToolReq
 	_, requestedZoneOffset := t.Zone()
 	slog.InfoContext(ctx, "CurrentDateTime", slog.String("server_local_tz", zoneName), slog.Int("server_local_offset_seconds", offsetSeconds), slog.String("requested_tz", tz), slog.Int("requested_tz_offset", requestedZoneOffset))
 
-	return &mcp_go.CallToolResult{
-		Content: []mcp_go.Content{
-			mcp_go.TextContent{
-				Type: "text",
-				Text: t.Format(dateTimeFormatTimeZone),
-			},
-		},
-	}, nil
+	return mcp_go.NewToolResultStructured(newZonedTime(t), t.Format(dateTimeFormatTimeZone)), nil
 }
 
 func (s *Server) TimeSince(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
@@ -118,45 +157,44 @@ func (s *Server) TimeSince(ctx context.Context, request mcp_go.CallToolRequest)
 	if input == "" {
 		return mcp_go.NewToolResultError(NewNilInputTime().Error()), nil
 	}
+	policy, err := ParseDSTPolicy(request.GetString("dstPolicy", ""))
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	style, err := ParseOutputStyle(request.GetString("outputStyle", ""))
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
 
 	opts := &TimeOpts{
-		input:    input,
-		timeZone: tz,
+		input:     input,
+		reference: s.TimeManager.Now(),
+		timeZone:  tz,
+		dstPolicy: policy,
 	}
 
-	t, err := ParseTime(opts)
+	wc, err := parseWallClock(opts)
 	if err != nil {
 		return mcp_go.NewToolResultError(err.Error()), nil
 	}
-	t = normalizeTimeToUTC(ctx, t)
+	t := wc.Time
 
 	now := s.TimeManager.Now()
-	now = normalizeTimeToUTC(ctx, now)
 
 	if t.After(now) {
 		return mcp_go.NewToolResultError("The specified time is in the future"), nil
 	}
+
+	output := ElapsedTimeOutput{
+		Input:    newResolvedInput(input, wc),
+		Now:      newZonedTime(now.In(t.Location())),
+		Duration: newDurationOutput(t, now, style, "ago"),
+	}
 	if t.Equal(now) {
-		return &mcp_go.CallToolResult{
-			Content: []mcp_go.Content{
-				mcp_go.TextContent{
-					Type: "text",
-					Text: "The specified time is now.",
-				},
-			},
-		}, nil
+		return mcp_go.NewToolResultStructured(output, "The specified time is now."), nil
 	}
 
-	duration := now.Sub(t)
-
-	return &mcp_go.CallToolResult{
-		Content: []mcp_go.Content{
-			mcp_go.TextContent{
-				Type: "text",
-				Text: duration.String(),
-			},
-		},
-	}, nil
+	return mcp_go.NewToolResultStructured(output, formatDuration(t, now, style, "ago")+dstNote(input, wc)), nil
 }
 
 func (s *Server) TimeUntil(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
@@ -165,35 +203,41 @@ func (s *Server) TimeUntil(ctx context.Context, request mcp_go.CallToolRequest)
 	if input == "" {
 		return mcp_go.NewToolResultError(NewNilInputTime().Error()), nil
 	}
+	policy, err := ParseDSTPolicy(request.GetString("dstPolicy", ""))
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	style, err := ParseOutputStyle(request.GetString("outputStyle", ""))
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
 
 	opts := &TimeOpts{
-		input:    input,
-		timeZone: tz,
+		input:     input,
+		reference: s.TimeManager.Now(),
+		timeZone:  tz,
+		dstPolicy: policy,
 	}
 
-	t, err := ParseTime(opts)
+	wc, err := parseWallClock(opts)
 	if err != nil {
 		return mcp_go.NewToolResultError(err.Error()), nil
 	}
-	t = normalizeTimeToUTC(ctx, t)
+	t := wc.Time
 
 	now := s.TimeManager.Now()
-	now = normalizeTimeToUTC(ctx, now)
 
 	if t.Before(now) {
 		return mcp_go.NewToolResultError("The specified time is in the past"), nil
 	}
 
-	duration := t.Sub(now)
+	output := ElapsedTimeOutput{
+		Input:    newResolvedInput(input, wc),
+		Now:      newZonedTime(now.In(t.Location())),
+		Duration: newDurationOutput(now.In(t.Location()), t, style, "from now"),
+	}
 
-	return &mcp_go.CallToolResult{
-		Content: []mcp_go.Content{
-			mcp_go.TextContent{
-				Type: "text",
-				Text: duration.String(),
-			},
-		},
-	}, nil
+	return mcp_go.NewToolResultStructured(output, formatDuration(now.In(t.Location()), t, style, "from now")+dstNote(input, wc)), nil
 }
 
 func (s *Server) TimeDifference(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
@@ -205,63 +249,64 @@ func (s *Server) TimeDifference(ctx context.Context, request mcp_go.CallToolRequ
 		return mcp_go.NewToolResultError("Both firstDateTime and secondDateTime must be provided"), nil
 	}
 
+	policy, err := ParseDSTPolicy(request.GetString("dstPolicy", ""))
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	style, err := ParseOutputStyle(request.GetString("outputStyle", ""))
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+
 	firstOpts := &TimeOpts{
-		input:    firstDateTime,
-		timeZone: firstTimeZone,
+		input:     firstDateTime,
+		reference: s.TimeManager.Now(),
+		timeZone:  firstTimeZone,
+		dstPolicy: policy,
 	}
 	secondOpts := &TimeOpts{
-		input:    secondDateTime,
-		timeZone: secondTimeZone,
+		input:     secondDateTime,
+		reference: s.TimeManager.Now(),
+		timeZone:  secondTimeZone,
+		dstPolicy: policy,
 	}
-	firstTime, err := ParseTime(firstOpts)
+	firstWallClock, err := parseWallClock(firstOpts)
 	if err != nil {
 		return mcp_go.NewToolResultErrorFromErr("error with first input time", err), nil
 	}
-	secondTime, err := ParseTime(secondOpts)
+	secondWallClock, err := parseWallClock(secondOpts)
 	if err != nil {
 		return mcp_go.NewToolResultErrorFromErr("error with second input time", err), nil
 	}
+	firstTime := firstWallClock.Time
+	secondTime := secondWallClock.Time
+	note := dstNote(firstDateTime, firstWallClock) + dstNote(secondDateTime, secondWallClock)
 
-	firstTime = normalizeTimeToUTC(ctx, firstTime)
-	secondTime = normalizeTimeToUTC(ctx, secondTime)
-
-	var result *mcp_go.CallToolResult
+	output := TimeDifferenceOutput{
+		First:   newResolvedInput(firstDateTime, firstWallClock),
+		Second:  newResolvedInput(secondDateTime, secondWallClock),
+		Seconds: secondTime.Sub(firstTime).Seconds(),
+	}
+	var text string
 
 	if firstTime.Equal(secondTime) {
-		result = &mcp_go.CallToolResult{
-			Content: []mcp_go.Content{
-				mcp_go.TextContent{
-					Type: "text",
-					Text: "The two times are equal.",
-				},
-			},
-		}
+		output.Comparison = "equal"
+		output.Duration = newDurationOutput(firstTime, secondTime, style, "")
+		text = "The two times are equal." + note
 	}
 
 	if firstTime.Before(secondTime) {
-		duration := secondTime.Sub(firstTime)
-		result = &mcp_go.CallToolResult{
-			Content: []mcp_go.Content{
-				mcp_go.TextContent{
-					Type: "text",
-					Text: "The first time is earlier than the second time by " + duration.String(),
-				},
-			},
-		}
+		output.Comparison = "earlier"
+		output.Duration = newDurationOutput(firstTime, secondTime, style, "")
+		text = "The first time is earlier than the second time by " + formatDuration(firstTime, secondTime, style, "") + note
 	}
 	if firstTime.After(secondTime) {
-		duration := firstTime.Sub(secondTime)
-		result = &mcp_go.CallToolResult{
-			Content: []mcp_go.Content{
-				mcp_go.TextContent{
-					Type: "text",
-					Text: "The first time is later than the second time by " + duration.String(),
-				},
-			},
-		}
+		output.Comparison = "later"
+		output.Duration = newDurationOutput(secondTime, firstTime, style, "")
+		text = "The first time is later than the second time by " + formatDuration(secondTime, firstTime, style, "") + note
 	}
 
-	return result, nil
+	return mcp_go.NewToolResultStructured(output, text), nil
 
 }
 
@@ -272,17 +317,13 @@ func (s *Server) IsLeapYear(ctx context.Context, request mcp_go.CallToolRequest)
 	}
 	isLeap := (year%4 == 0 && year%100 != 0) || (year%400 == 0)
 	result := "is not a leap year."
+	daysInYear := 365
 	if isLeap {
 		result = "is a leap year."
+		daysInYear = 366
 	}
-	return &mcp_go.CallToolResult{
-		Content: []mcp_go.Content{
-			mcp_go.TextContent{
-				Type: "text",
-				Text: fmt.Sprintf("%d %s", year, result),
-			},
-		},
-	}, nil
+	output := LeapYearOutput{Year: year, IsLeapYear: isLeap, DaysInYear: daysInYear}
+	return mcp_go.NewToolResultStructured(output, fmt.Sprintf("%d %s", year, result)), nil
 }
 
 func (s *Server) DayOfWeek(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
@@ -291,7 +332,8 @@ func (s *Server) DayOfWeek(ctx context.Context, request mcp_go.CallToolRequest)
 		return mcp_go.NewToolResultError(NewNilInputTime().Error()), nil
 	}
 	opts := &TimeOpts{
-		input: input,
+		input:     input,
+		reference: s.TimeManager.Now(),
 	}
 
 	t, err := ParseTime(opts)
@@ -299,89 +341,88 @@ func (s *Server) DayOfWeek(ctx context.Context, request mcp_go.CallToolRequest)
 		return mcp_go.NewToolResultError(err.Error()), nil
 	}
 	dayOfWeek := t.Weekday().String()
+	isoWeekday := int(t.Weekday())
+	if isoWeekday == 0 {
+		isoWeekday = 7
+	}
+	output := DayOfWeekOutput{Date: t.Format(dateFormat), DayOfWeek: dayOfWeek, ISOWeekday: isoWeekday}
 
-	return &mcp_go.CallToolResult{
-		Content: []mcp_go.Content{
-			mcp_go.TextContent{
-				Type: "text",
-				Text: fmt.Sprintf("The day of the week for %s is %s.", t.Format(dateFormat), dayOfWeek),
-			},
-		},
-	}, nil
+	return mcp_go.NewToolResultStructured(output, fmt.Sprintf("The day of the week for %s is %s.", t.Format(dateFormat), dayOfWeek)), nil
 }
 
 func (s *Server) AddDuration(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
-	input := request.GetString("dateTime", "")
-	if input == "" {
-		return mcp_go.NewToolResultError(NewNilInputTime().Error()), nil
-	}
-
-	opts := &TimeOpts{
-		input: input,
-	}
-
-	t, err := ParseTime(opts)
-	if err != nil {
-		return mcp_go.NewToolResultError(err.Error()), nil
+	newTime, output, errResult := s.shiftByDuration(ctx, request, false)
+	if errResult != nil {
+		return errResult, nil
 	}
 
-	durationStr := request.GetString("duration", "")
-	if durationStr == "" {
-		return mcp_go.NewToolResultError("Duration must be provided"), nil
-	}
+	return mcp_go.NewToolResultStructured(output, fmt.Sprintf("New time after adding duration: %s", newTime.Format(dateTimeFormatTimeZone))), nil
+}
 
-	duration, err := time.ParseDuration(durationStr)
-	if err != nil {
-		return mcp_go.NewToolResultError(fmt.Sprintf("Invalid duration format: %s", durationStr)), nil
+func (s *Server) SubtractDuration(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	newTime, output, errResult := s.shiftByDuration(ctx, request, true)
+	if errResult != nil {
+		return errResult, nil
 	}
 
-	newTime := t.Add(duration)
-
-	return &mcp_go.CallToolResult{
-		Content: []mcp_go.Content{
-			mcp_go.TextContent{
-				Type: "text",
-				Text: fmt.Sprintf("New time after adding duration: %s", newTime.Format(dateTimeFormatTimeZone)),
-			},
-		},
-	}, nil
+	return mcp_go.NewToolResultStructured(output, fmt.Sprintf("New time after subtracting duration: %s", newTime.Format(dateTimeFormatTimeZone))), nil
 }
 
-func (s *Server) SubtractDuration(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+// shiftByDuration parses the dateTime and duration arguments of
+// AddDuration and SubtractDuration and applies the duration, negated when
+// subtract is set.  Calendar units are applied to the wall-clock date in the
+// requested time zone.  It returns the new time along with its structured
+// description.
+func (s *Server) shiftByDuration(ctx context.Context, request mcp_go.CallToolRequest, subtract bool) (time.Time, DurationShiftOutput, *mcp_go.CallToolResult) {
 	input := request.GetString("dateTime", "")
 	if input == "" {
-		return mcp_go.NewToolResultError(NewNilInputTime().Error()), nil
+		return time.Time{}, DurationShiftOutput{}, mcp_go.NewToolResultError(NewNilInputTime().Error())
+	}
+	dstPolicy, err := ParseDSTPolicy(request.GetString("dstPolicy", ""))
+	if err != nil {
+		return time.Time{}, DurationShiftOutput{}, mcp_go.NewToolResultError(err.Error())
+	}
+	monthEnd, err := ParseMonthEndPolicy(request.GetString("monthEndPolicy", ""))
+	if err != nil {
+		return time.Time{}, DurationShiftOutput{}, mcp_go.NewToolResultError(err.Error())
 	}
 
 	opts := &TimeOpts{
-		input: input,
+		input:     input,
+		reference: s.TimeManager.Now(),
+		timeZone:  request.GetString("timeZone", ""),
+		dstPolicy: dstPolicy,
 	}
 
 	t, err := ParseTime(opts)
 	if err != nil {
-		return mcp_go.NewToolResultError(err.Error()), nil
+		return time.Time{}, DurationShiftOutput{}, mcp_go.NewToolResultError(err.Error())
 	}
 
 	durationStr := request.GetString("duration", "")
 	if durationStr == "" {
-		return mcp_go.NewToolResultError("Duration must be provided"), nil
+		return time.Time{}, DurationShiftOutput{}, mcp_go.NewToolResultError("Duration must be provided")
 	}
 
-	duration, err := time.ParseDuration(durationStr)
+	duration, err := ParseCalendarDuration(durationStr)
 	if err != nil {
-		return mcp_go.NewToolResultError(fmt.Sprintf("Invalid duration format: %s", durationStr)), nil
+		return time.Time{}, DurationShiftOutput{}, mcp_go.NewToolResultError(err.Error())
+	}
+	if subtract {
+		duration = duration.Negate()
 	}
 
-	newTime := t.Add(-duration)
-
-	return &mcp_go.CallToolResult{
-		Content: []mcp_go.Content{
-			mcp_go.TextContent{
-				Type: "text",
-				Text: fmt.Sprintf("New time after subtracting duration: %s", newTime.Format(dateTimeFormatTimeZone)),
-			},
-		},
-	}, nil
+	newTime, err := duration.AddTo(t, monthEnd, dstPolicy)
+	if err != nil {
+		return time.Time{}, DurationShiftOutput{}, mcp_go.NewToolResultError(err.Error())
+	}
+	slog.InfoContext(ctx, "shiftByDuration", slog.String("input_time", t.Format(dateTimeFormatTimeZone)), slog.String("duration", duration.String()), slog.String("month_end_policy", string(monthEnd)), slog.String("new_time", newTime.Format(dateTimeFormatTimeZone)))
+	output := DurationShiftOutput{
+		Input:    newZonedTime(t),
+		Duration: duration.String(),
+		Result:   newZonedTime(newTime),
+	}
+	return newTime, output, nil
 }
 
 // NextOccurrence calculates the next date for a specified day of the week (e.g. "Monday") after a given date and time.
@@ -391,7 +432,8 @@ func (s *Server) NextOccurrence(ctx context.Context, request mcp_go.CallToolRequ
 		return mcp_go.NewToolResultError(NewNilInputTime().Error()), nil
 	}
 	opts := &TimeOpts{
-		input: input,
+		input:     input,
+		reference: s.TimeManager.Now(),
 	}
 	t, err := ParseTime(opts)
 	if err != nil {
@@ -418,14 +460,13 @@ func (s *Server) NextOccurrence(ctx context.Context, request mcp_go.CallToolRequ
 		nextOccurrence = nextOccurrence.AddDate(0, 0, 1)
 	}
 	slog.InfoContext(ctx, "NextOccurrence", slog.String("input_time", t.Format(dateTimeFormatTimeZone)), slog.String("requested_day_of_week", dayOfWeekStr), slog.String("next_occurrence", nextOccurrence.Format(dateTimeFormatTimeZone)))
-	return &mcp_go.CallToolResult{
-		Content: []mcp_go.Content{
-			mcp_go.TextContent{
-				Type: "text",
-				Text: fmt.Sprintf("The next occurrence of %s after %s is %s.", dayOfWeekStr, t.Format(dateTimeFormatTimeZone), nextOccurrence.Format(dateTimeFormatTimeZone)),
-			},
-		},
-	}, nil
+	output := OccurrenceOutput{
+		From:       newZonedTime(t),
+		DayOfWeek:  dayOfWeek.String(),
+		Occurrence: newZonedTime(nextOccurrence),
+		Days:       daysFrom(t, nextOccurrence),
+	}
+	return mcp_go.NewToolResultStructured(output, fmt.Sprintf("The next occurrence of %s after %s is %s.", dayOfWeekStr, t.Format(dateTimeFormatTimeZone), nextOccurrence.Format(dateTimeFormatTimeZone))), nil
 }
 
 func (s *Server) PreviousOccurrence(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
@@ -434,7 +475,8 @@ func (s *Server) PreviousOccurrence(ctx context.Context, request mcp_go.CallTool
 		return mcp_go.NewToolResultError(NewNilInputTime().Error()), nil
 	}
 	opts := &TimeOpts{
-		input: input,
+		input:     input,
+		reference: s.TimeManager.Now(),
 	}
 	t, err := ParseTime(opts)
 	if err != nil {
@@ -461,14 +503,13 @@ func (s *Server) PreviousOccurrence(ctx context.Context, request mcp_go.CallTool
 		prevOccurrence = prevOccurrence.AddDate(0, 0, -1)
 	}
 	slog.InfoContext(ctx, "PreviousOccurrence", slog.String("input_time", t.Format(dateTimeFormatTimeZone)), slog.String("requested_day_of_week", dayOfWeekStr), slog.String("previous_occurrence", prevOccurrence.Format(dateTimeFormatTimeZone)))
-	return &mcp_go.CallToolResult{
-		Content: []mcp_go.Content{
-			mcp_go.TextContent{
-				Type: "text",
-				Text: fmt.Sprintf("The previous occurrence of %s before %s is %s.", dayOfWeekStr, t.Format(dateTimeFormatTimeZone), prevOccurrence.Format(dateTimeFormatTimeZone)),
-			},
-		},
-	}, nil
+	output := OccurrenceOutput{
+		From:       newZonedTime(t),
+		DayOfWeek:  dayOfWeek.String(),
+		Occurrence: newZonedTime(prevOccurrence),
+		Days:       daysFrom(t, prevOccurrence),
+	}
+	return mcp_go.NewToolResultStructured(output, fmt.Sprintf("The previous occurrence of %s before %s is %s.", dayOfWeekStr, t.Format(dateTimeFormatTimeZone), prevOccurrence.Format(dateTimeFormatTimeZone))), nil
 }
 
 func (s *Server) IsWeekend(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
@@ -477,7 +518,8 @@ func (s *Server) IsWeekend(ctx context.Context, request mcp_go.CallToolRequest)
 		return mcp_go.NewToolResultError(NewNilInputTime().Error()), nil
 	}
 	opts := &TimeOpts{
-		input: input,
+		input:     input,
+		reference: s.TimeManager.Now(),
 	}
 	t, err := ParseTime(opts)
 	if err != nil {
@@ -490,15 +532,9 @@ func (s *Server) IsWeekend(ctx context.Context, request mcp_go.CallToolRequest)
 	if isWeekend {
 		result = "is a weekend."
 	}
+	output := WeekendOutput{Date: t.Format(dateFormat), DayOfWeek: t.Weekday().String(), IsWeekend: isWeekend}
 
-	return &mcp_go.CallToolResult{
-		Content: []mcp_go.Content{
-			mcp_go.TextContent{
-				Type: "text",
-				Text: fmt.Sprintf("%s %s", t.Format(dateFormat), result),
-			},
-		},
-	}, nil
+	return mcp_go.NewToolResultStructured(output, fmt.Sprintf("%s %s", t.Format(dateFormat), result)), nil
 }
 
 func (s *Server) IsWeekday(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
@@ -507,7 +543,8 @@ func (s *Server) IsWeekday(ctx context.Context, request mcp_go.CallToolRequest)
 		return mcp_go.NewToolResultError(NewNilInputTime().Error()), nil
 	}
 	opts := &TimeOpts{
-		input: input,
+		input:     input,
+		reference: s.TimeManager.Now(),
 	}
 	t, err := ParseTime(opts)
 	if err != nil {
@@ -520,15 +557,9 @@ func (s *Server) IsWeekday(ctx context.Context, request mcp_go.CallToolRequest)
 	if isWeekday {
 		result = "is a weekday."
 	}
+	output := WeekdayOutput{Date: t.Format(dateFormat), DayOfWeek: t.Weekday().String(), IsWeekday: isWeekday}
 
-	return &mcp_go.CallToolResult{
-		Content: []mcp_go.Content{
-			mcp_go.TextContent{
-				Type: "text",
-				Text: fmt.Sprintf("%s %s", t.Format(dateFormat), result),
-			},
-		},
-	}, nil
+	return mcp_go.NewToolResultStructured(output, fmt.Sprintf("%s %s", t.Format(dateFormat), result)), nil
 }
 
 func (s *Server) DaysBetween(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
@@ -542,10 +573,12 @@ func (s *Server) DaysBetween(ctx context.Context, request mcp_go.CallToolRequest
 	}
 
 	firstOpts := &TimeOpts{
-		input: firstInput,
+		input:     firstInput,
+		reference: s.TimeManager.Now(),
 	}
 	secondOpts := &TimeOpts{
-		input: secondInput,
+		input:     secondInput,
+		reference: s.TimeManager.Now(),
 	}
 
 	firstTime, err := ParseTime(firstOpts)
@@ -557,27 +590,1590 @@ func (s *Server) DaysBetween(ctx context.Context, request mcp_go.CallToolRequest
 		return mcp_go.NewToolResultError(err.Error()), nil
 	}
 
-	firstTime = normalizeTimeToUTC(ctx, firstTime)
-	secondTime = normalizeTimeToUTC(ctx, secondTime)
-
 	daysBetween := int(secondTime.Sub(firstTime).Hours() / 24)
 
-	return &mcp_go.CallToolResult{
-		Content: []mcp_go.Content{
-			mcp_go.TextContent{
-				Type: "text",
-				Text: fmt.Sprintf("There are %d days between %s and %s.", daysBetween, firstTime.Format(dateFormat), secondTime.Format(dateFormat)),
-			},
-		},
-	}, nil
+	output := DaysBetweenOutput{FirstDate: firstTime.Format(dateFormat), SecondDate: secondTime.Format(dateFormat), Days: daysBetween}
+
+	return mcp_go.NewToolResultStructured(output, fmt.Sprintf("There are %d days between %s and %s.", daysBetween, firstTime.Format(dateFormat), secondTime.Format(dateFormat))), nil
+}
+
+// ResolveDateExpression resolves an ISO 8601 timestamp or a natural-language
+// expression such as "next Tuesday at 3pm" to an absolute time and explains
+// how it was interpreted.
+func (s *Server) ResolveDateExpression(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	input := request.GetString("expression", "")
+	if input == "" {
+		return mcp_go.NewToolResultError(NewNilInputTime().Error()), nil
+	}
+	tz := request.GetString("timeZone", "UTC")
+	policy, err := ParseDSTPolicy(request.GetString("dstPolicy", ""))
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+
+	opts := &TimeOpts{
+		input:     input,
+		reference: s.TimeManager.Now(),
+		timeZone:  tz,
+		dstPolicy: policy,
+	}
+	wc, err := parseWallClock(opts)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+
+	explanation := wc.Explanation
+	if explanation == "" {
+		explanation = "parsed as an ISO 8601 date/time"
+	}
+	slog.InfoContext(ctx, "ResolveDateExpression", slog.String("expression", input), slog.String("time_zone", tz), slog.String("resolved", wc.Time.Format(dateTimeFormatTimeZone)))
+
+	output := newResolvedInput(input, wc)
+	output.Explanation = explanation
+
+	return mcp_go.NewToolResultStructured(output, fmt.Sprintf("%q resolves to %s (%s). Interpretation: %s.%s", input, wc.Time.Format(dateTimeFormatTimeZone), wc.Time.Weekday(), explanation, dstNote(input, wc))), nil
+}
+
+// ConvertTimeZone converts a date and time in a source time zone into one or
+// more target time zones, describing each zone's abbreviation, UTC offset and
+// DST status.
+func (s *Server) ConvertTimeZone(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	tz := request.GetString("timeZone", "UTC")
+	input := request.GetString("dateTime", "")
+	targets := request.GetStringSlice("targetTimeZones", nil)
+	if len(targets) == 0 {
+		for _, target := range strings.Split(request.GetString("targetTimeZones", ""), ",") {
+			if target = strings.TrimSpace(target); target != "" {
+				targets = append(targets, target)
+			}
+		}
+	}
+	if len(targets) == 0 {
+		return mcp_go.NewToolResultError("At least one target time zone must be provided"), nil
+	}
+	policy, err := ParseDSTPolicy(request.GetString("dstPolicy", ""))
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+
+	srcLoc, err := s.loadLocation(tz)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	var wc WallClock
+	if input == "" {
+		now := s.TimeManager.Now().In(srcLoc)
+		wc = WallClock{Time: now, Kind: WallClockNormal, Earlier: now, Later: now}
+	} else {
+		opts := &TimeOpts{
+			input:     input,
+			reference: s.TimeManager.Now(),
+			timeZone:  tz,
+			dstPolicy: policy,
+		}
+		wc, err = parseWallClock(opts)
+		if err != nil {
+			return mcp_go.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	// An offset in the input takes precedence over the timezone, so label
+	// the source by that offset.
+	source, sourceTime := tz, wc.Time.In(srcLoc)
+	if wc.Time.Location().String() != srcLoc.String() {
+		_, offset := wc.Time.Zone()
+		source = formatUTCOffset(offset)
+		sourceTime = wc.Time.In(time.FixedZone(source, offset))
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Source %s: %s", source, describeZoneTime(sourceTime))
+	switch wc.Kind {
+	case WallClockGap:
+		fmt.Fprintf(&b, "\nWarning: the local time %q was skipped by a daylight saving transition in %s; it was interpreted as %s (the other reading is %s).", input, tz, wc.Time.Format(dateTimeFormatTimeZone), otherReading(wc).Format(dateTimeFormatTimeZone))
+	case WallClockFold:
+		fmt.Fprintf(&b, "\nWarning: the local time %q occurs twice in %s because of a daylight saving transition; it was interpreted as %s (the other occurrence is %s).", input, tz, wc.Time.Format(dateTimeFormatTimeZone), otherReading(wc).Format(dateTimeFormatTimeZone))
+	}
+
+	output := ConvertTimeZoneOutput{Source: newResolvedInput(input, wc)}
+	for _, target := range targets {
+		loc, err := s.loadLocation(target)
+		if err != nil {
+			return mcp_go.NewToolResultError(err.Error()), nil
+		}
+		fmt.Fprintf(&b, "\n%s: %s", target, describeZoneTime(wc.Time.In(loc)))
+		output.Targets = append(output.Targets, newZonedTime(wc.Time.In(loc)))
+	}
+	slog.InfoContext(ctx, "ConvertTimeZone", slog.String("input_time", wc.Time.Format(dateTimeFormatTimeZone)), slog.String("source_tz", tz), slog.Any("target_tzs", targets))
+
+	return mcp_go.NewToolResultStructured(output, b.String()), nil
+}
+
+// IsBusinessDay reports whether a date is a working day under a holiday
+// calendar and weekend definition.
+func (s *Server) IsBusinessDay(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	input := request.GetString("dateTime", "")
+	if input == "" {
+		return mcp_go.NewToolResultError(NewNilInputTime().Error()), nil
+	}
+	b, err := s.businessCalendar(request)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	opts := &TimeOpts{
+		input:     input,
+		reference: s.TimeManager.Now(),
+		timeZone:  request.GetString("timeZone", ""),
+	}
+	t, err := ParseTime(opts)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+
+	output := BusinessDayOutput{
+		Date:          t.Format(dateFormat),
+		DayOfWeek:     t.Weekday().String(),
+		IsBusinessDay: b.isBusinessDay(t),
+		IsWeekend:     b.weekend.Contains(t.Weekday()),
+		Calendar:      b.calendarID(),
+		Weekend:       b.weekend.Days(),
+	}
+	var reasons []string
+	if output.IsWeekend {
+		reasons = append(reasons, "weekend: "+b.weekend.String())
+	}
+	if h, ok := b.holiday(t); ok {
+		output.Holiday = &newHolidayOutputs([]Holiday{h})[0]
+		reasons = append(reasons, fmt.Sprintf("%s, %s", h.Name, b.calendarID()))
+	}
+
+	text := fmt.Sprintf("%s is a business day.", t.Format(dateFormat))
+	if !output.IsBusinessDay {
+		text = fmt.Sprintf("%s is not a business day (%s).", t.Format(dateFormat), strings.Join(reasons, "; "))
+	}
+	return mcp_go.NewToolResultStructured(output, text), nil
+}
+
+// AddBusinessDays moves a date and time forwards or backwards by a number of
+// working days, skipping weekends and holidays.
+func (s *Server) AddBusinessDays(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	input := request.GetString("dateTime", "")
+	if input == "" {
+		return mcp_go.NewToolResultError(NewNilInputTime().Error()), nil
+	}
+	days := request.GetInt("days", 0)
+	b, err := s.businessCalendar(request)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	policy, err := ParseDSTPolicy(request.GetString("dstPolicy", ""))
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	opts := &TimeOpts{
+		input:     input,
+		reference: s.TimeManager.Now(),
+		timeZone:  request.GetString("timeZone", ""),
+		dstPolicy: policy,
+	}
+	t, err := ParseTime(opts)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+
+	newTime, skipped, err := b.addBusinessDays(t, days, policy)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	slog.InfoContext(ctx, "AddBusinessDays", slog.String("input_time", t.Format(dateTimeFormatTimeZone)), slog.Int("days", days), slog.String("calendar", b.calendarID()), slog.String("new_time", newTime.Format(dateTimeFormatTimeZone)))
+
+	output := AddBusinessDaysOutput{
+		Input:           newZonedTime(t),
+		BusinessDays:    days,
+		Result:          newZonedTime(newTime),
+		SkippedHolidays: newHolidayOutputs(skipped),
+		Calendar:        b.calendarID(),
+		Weekend:         b.weekend.Days(),
+	}
+	text := fmt.Sprintf("Adding %s to %s gives %s.", plural(days, "business day"), t.Format(dateTimeFormatTimeZone), newTime.Format(dateTimeFormatTimeZone))
+	if len(skipped) > 0 {
+		text = strings.TrimSuffix(text, ".") + fmt.Sprintf(" (skipped holidays: %s).", describeHolidays(skipped))
+	}
+	return mcp_go.NewToolResultStructured(output, text), nil
+}
+
+// BusinessDaysBetween counts the working days from one date up to, but not
+// including, another.
+func (s *Server) BusinessDaysBetween(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	firstInput := request.GetString("firstDate", "")
+	secondInput := request.GetString("secondDate", "")
+	if firstInput == "" || secondInput == "" {
+		return mcp_go.NewToolResultError("Both firstDate and secondDate must be provided"), nil
+	}
+	b, err := s.businessCalendar(request)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+
+	firstOpts := &TimeOpts{
+		input:     firstInput,
+		reference: s.TimeManager.Now(),
+		timeZone:  request.GetString("timeZone", ""),
+	}
+	secondOpts := &TimeOpts{
+		input:     secondInput,
+		reference: s.TimeManager.Now(),
+		timeZone:  request.GetString("timeZone", ""),
+	}
+	firstTime, err := ParseTime(firstOpts)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	secondTime, err := ParseTime(secondOpts)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+
+	span, err := b.between(firstTime, secondTime)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	output := BusinessDaysBetweenOutput{
+		FirstDate:    firstTime.Format(dateFormat),
+		SecondDate:   secondTime.Format(dateFormat),
+		BusinessDays: span.BusinessDays,
+		CalendarDays: span.CalendarDays,
+		WeekendDays:  span.WeekendDays,
+		Holidays:     newHolidayOutputs(span.Holidays),
+		Calendar:     b.calendarID(),
+		Weekend:      b.weekend.Days(),
+	}
+	text := fmt.Sprintf("There are %s between %s and %s (%s, %s", plural(span.BusinessDays, "business day"), firstTime.Format(dateFormat), secondTime.Format(dateFormat), plural(span.CalendarDays, "calendar day"), plural(span.WeekendDays, "weekend day"))
+	if len(span.Holidays) > 0 {
+		text += fmt.Sprintf(", %s: %s", plural(len(span.Holidays), "holiday"), describeHolidays(span.Holidays))
+	}
+	return mcp_go.NewToolResultStructured(output, text+")."), nil
+}
+
+// businessCalendar builds the working-day rules selected by the
+// holidayCalendar and weekend arguments.  Without a calendar only weekends
+// are non-working days.
+func (s *Server) businessCalendar(request mcp_go.CallToolRequest) (*businessCalendar, error) {
+	var calendar HolidayCalendar
+	weekend := DefaultWeekend
+	if request.GetString("holidayCalendar", "") != "" {
+		c, err := s.holidayCalendar(request)
+		if err != nil {
+			return nil, err
+		}
+		calendar, weekend = c, c.Weekend()
+	}
+	weekend, err := ParseWeekend(request.GetString("weekend", ""), weekend)
+	if err != nil {
+		return nil, err
+	}
+	return newBusinessCalendar(calendar, weekend), nil
+}
+
+// ListHolidays lists the holidays of a holiday calendar in a year.
+func (s *Server) ListHolidays(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	calendar, err := s.holidayCalendar(request)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	year := request.GetInt("year", s.TimeManager.Now().Year())
+	if year <= 0 {
+		return mcp_go.NewToolResultError("Invalid year provided"), nil
+	}
+
+	holidays := calendar.Holidays(year)
+	output := ListHolidaysOutput{
+		Calendar:     calendar.ID(),
+		CalendarName: calendar.Name(),
+		Year:         year,
+		Holidays:     newHolidayOutputs(holidays),
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s in %d (%s):", plural(len(holidays), "holiday"), year, calendar.Name())
+	for _, h := range holidays {
+		fmt.Fprintf(&b, "\n%s (%s): %s", h.Date.Format(dateFormat), h.Date.Weekday(), describeHoliday(h))
+	}
+	return mcp_go.NewToolResultStructured(output, b.String()), nil
+}
+
+// NextHoliday finds the next holidays of a holiday calendar on or after
+// today's date in the requested time zone.
+func (s *Server) NextHoliday(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	calendar, err := s.holidayCalendar(request)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	count := request.GetInt("count", 1)
+	if count < 1 || count > 50 {
+		return mcp_go.NewToolResultError("count must be between 1 and 50"), nil
+	}
+	tz := request.GetString("timeZone", "UTC")
+	loc, err := s.loadLocation(tz)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	today := civilDate(s.TimeManager.Now().In(loc))
+
+	output := NextHolidayOutput{
+		Calendar:     calendar.ID(),
+		CalendarName: calendar.Name(),
+		Today:        today.Format(dateFormat),
+		Holidays:     []UpcomingHolidayOutput{},
+	}
+	var lines []string
+	// A calendar with a recurring rule has a holiday every year, so count+1
+	// years always suffice; the bound stops the search for calendars made of
+	// one-off dates.
+	for year := today.Year(); year <= today.Year()+count && len(output.Holidays) < count; year++ {
+		for _, h := range calendar.Holidays(year) {
+			if h.Date.Before(today) || len(output.Holidays) == count {
+				continue
+			}
+			days := int(h.Date.Sub(today).Hours() / 24)
+			output.Holidays = append(output.Holidays, UpcomingHolidayOutput{HolidayOutput: newHolidayOutputs([]Holiday{h})[0], DaysUntil: days})
+			when := "in " + plural(days, "day")
+			if days == 0 {
+				when = "today"
+			}
+			lines = append(lines, fmt.Sprintf("%s (%s, %s): %s", h.Date.Format(dateFormat), h.Date.Weekday(), when, describeHoliday(h)))
+		}
+	}
+	if len(lines) == 0 {
+		return mcp_go.NewToolResultStructured(output, fmt.Sprintf("No upcoming holidays in %s.", calendar.Name())), nil
+	}
+	text := fmt.Sprintf("Next holiday in %s from %s: %s", calendar.Name(), today.Format(dateFormat), lines[0])
+	if len(lines) > 1 {
+		text = fmt.Sprintf("Next %d holidays in %s from %s:\n%s", len(lines), calendar.Name(), today.Format(dateFormat), strings.Join(lines, "\n"))
+	}
+	return mcp_go.NewToolResultStructured(output, text), nil
+}
+
+// holidayCalendar looks up the calendar named by the holidayCalendar
+// argument.
+func (s *Server) holidayCalendar(request mcp_go.CallToolRequest) (HolidayCalendar, error) {
+	id := request.GetString("holidayCalendar", "")
+	if s.Holidays == nil {
+		return nil, NewUnknownHolidayCalendarError(id, nil)
+	}
+	return s.Holidays.Lookup(id)
+}
+
+// describeHoliday names a holiday and, when it was moved off a weekend, the
+// day it falls on.
+func describeHoliday(h Holiday) string {
+	if h.Shifted() {
+		return fmt.Sprintf("%s (observed; falls on %s %s)", h.Name, h.Actual.Weekday(), h.Actual.Format(dateFormat))
+	}
+	return h.Name
 }
 
-func normalizeTimeToUTC(ctx context.Context, t time.Time) time.Time {
-	zone, offset := t.Zone()
-	slog.InfoContext(ctx, "normalizeTimeToUTC", slog.String("input_time", t.Format(dateTimeFormatTimeZone)), slog.String("time_zone", zone), slog.Int("offset_seconds", offset))
+// describeHolidays lists holidays as "Christmas Day on 2024-12-25".
+func describeHolidays(holidays []Holiday) string {
+	parts := make([]string, 0, len(holidays))
+	for _, h := range holidays {
+		parts = append(parts, fmt.Sprintf("%s on %s", h.Name, h.Date.Format(dateFormat)))
+	}
+	return strings.Join(parts, ", ")
+}
 
-	// Normalize the time to UTC by subtracting the offset
-	return t.Add(-time.Duration(offset) * time.Second)
+// maxRecurrenceCount bounds the occurrences expandRecurrence returns.
+const maxRecurrenceCount = 500
+
+// ExpandRecurrence lists the occurrences of an RFC 5545 recurrence rule and
+// describes the rule in English.
+func (s *Server) ExpandRecurrence(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	ical, err := parseICalRecurrence(request.GetString("rrule", ""))
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	count := request.GetInt("count", 10)
+	if count < 1 || count > maxRecurrenceCount {
+		return mcp_go.NewToolResultError(fmt.Sprintf("count must be between 1 and %d", maxRecurrenceCount)), nil
+	}
+	tz := request.GetString("timeZone", ical.TZID)
+	if tz == "" {
+		tz = "UTC"
+	}
+	loc, err := s.loadLocation(tz)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	now := s.TimeManager.Now()
+	parse := func(input string) (time.Time, error) {
+		t, err := ParseTime(&TimeOpts{input: strings.TrimSpace(input), timeZone: tz, reference: now})
+		return t.In(loc), err
+	}
+
+	start := now.In(loc).Truncate(time.Second)
+	if input := request.GetString("dtstart", ical.DTStart); input != "" {
+		if start, err = parse(input); err != nil {
+			return mcp_go.NewToolResultError(err.Error()), nil
+		}
+	}
+	rule, err := ParseRRule(ical.RRule, loc)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+
+	rec := &Recurrence{Rule: rule, Start: start, ExDays: map[string]bool{}}
+	for _, input := range append(ical.RDates, request.GetStringSlice("rdates", nil)...) {
+		t, err := parse(input)
+		if err != nil {
+			return mcp_go.NewToolResultError(err.Error()), nil
+		}
+		if isICalDate(input) {
+			// A date alone recurs at DTSTART's time of day.
+			hour, min, sec := start.Clock()
+			t = time.Date(t.Year(), t.Month(), t.Day(), hour, min, sec, 0, loc)
+		}
+		rec.RDates = append(rec.RDates, t)
+	}
+	for _, input := range append(ical.ExDates, request.GetStringSlice("exdates", nil)...) {
+		t, err := parse(input)
+		if err != nil {
+			return mcp_go.NewToolResultError(err.Error()), nil
+		}
+		if isICalDate(input) {
+			rec.ExDays[t.Format(dateFormat)] = true
+		} else {
+			rec.ExDates = append(rec.ExDates, t)
+		}
+	}
+
+	var after, before time.Time
+	if input := request.GetString("after", ""); input != "" {
+		if after, err = parse(input); err != nil {
+			return mcp_go.NewToolResultError(err.Error()), nil
+		}
+	}
+	if input := request.GetString("before", ""); input != "" {
+		if before, err = parse(input); err != nil {
+			return mcp_go.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	slog.InfoContext(ctx, "Expanding recurrence", slog.String("rule", rule.String()), slog.String("start", start.Format(dateTimeFormatTimeZone)), slog.Int("count", count))
+	occurrences := rec.Occurrences(after, before, count+1)
+	output := RecurrenceOutput{
+		Rule:        rule.String(),
+		Description: rule.Describe(start),
+		Start:       newZonedTime(start),
+		Occurrences: []ZonedTime{},
+		HasMore:     len(occurrences) > count,
+	}
+	if output.HasMore {
+		occurrences = occurrences[:count]
+	}
+	lines := make([]string, 0, len(occurrences))
+	for _, t := range occurrences {
+		output.Occurrences = append(output.Occurrences, newZonedTime(t))
+		lines = append(lines, fmt.Sprintf("%s (%s)", t.Format(dateTimeFormatTimeZone), t.Weekday()))
+	}
+
+	description := strings.ToUpper(output.Description[:1]) + output.Description[1:]
+	header := fmt.Sprintf("%s, starting %s in %s.", description, start.Format(dateTimeFormatTimeZone), loc)
+	if len(lines) == 0 {
+		return mcp_go.NewToolResultStructured(output, header+"\nNo occurrences."), nil
+	}
+	text := fmt.Sprintf("%s\n%s:\n%s", header, plural(len(lines), "occurrence"), strings.Join(lines, "\n"))
+	if output.HasMore {
+		text += "\n..."
+	}
+	return mcp_go.NewToolResultStructured(output, text), nil
+}
+
+// maxCronRuns bounds the fire times nextCronRuns and previousCronRuns return.
+const maxCronRuns = 100
+
+// NextCronRuns lists the next times a cron expression fires after now.
+func (s *Server) NextCronRuns(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	return s.cronRuns(ctx, request, false)
+}
+
+// PreviousCronRuns lists the most recent times a cron expression fired
+// before now.
+func (s *Server) PreviousCronRuns(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	return s.cronRuns(ctx, request, true)
+}
+
+func (s *Server) cronRuns(ctx context.Context, request mcp_go.CallToolRequest, previous bool) (*mcp_go.CallToolResult, error) {
+	schedule, err := cronSchedule(request)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	count := request.GetInt("count", 5)
+	if count < 1 || count > maxCronRuns {
+		return mcp_go.NewToolResultError(fmt.Sprintf("count must be between 1 and %d", maxCronRuns)), nil
+	}
+	tz := request.GetString("timeZone", "UTC")
+	loc, err := s.loadLocation(tz)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	now := s.TimeManager.Now().In(loc)
+
+	slog.InfoContext(ctx, "Evaluating cron expression", slog.String("expression", schedule.Expression), slog.String("dialect", string(schedule.Dialect)), slog.Bool("previous", previous))
+	var runs []time.Time
+	direction := "Next"
+	if previous {
+		runs = schedule.Previous(now, count, loc)
+		direction = "Previous"
+	} else {
+		runs = schedule.Next(now, count, loc)
+	}
+
+	output := CronRunsOutput{
+		Expression:  schedule.Expression,
+		Dialect:     string(schedule.Dialect),
+		Description: schedule.Describe(),
+		From:        newZonedTime(now),
+		Runs:        []ZonedTime{},
+	}
+	lines := make([]string, 0, len(runs))
+	for _, t := range runs {
+		output.Runs = append(output.Runs, newZonedTime(t))
+		lines = append(lines, fmt.Sprintf("%s (%s, %s)", t.Format(dateTimeFormatTimeZone), t.Weekday(), relativeDescription(now, t)))
+	}
+
+	header := fmt.Sprintf("%q: %s.", schedule.Expression, output.Description)
+	if len(lines) == 0 {
+		return mcp_go.NewToolResultStructured(output, fmt.Sprintf("%s\nNo %s runs found from %s.", header, strings.ToLower(direction), now.Format(dateTimeFormatTimeZone))), nil
+	}
+	text := fmt.Sprintf("%s\n%s %s from %s in %s:\n%s", header, direction, plural(len(lines), "run"), now.Format(dateTimeFormatTimeZone), loc, strings.Join(lines, "\n"))
+	return mcp_go.NewToolResultStructured(output, text), nil
+}
+
+// ExplainCron describes a cron expression in plain English.
+func (s *Server) ExplainCron(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	schedule, err := cronSchedule(request)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	output := CronExplanationOutput{
+		Expression:  schedule.Expression,
+		Dialect:     string(schedule.Dialect),
+		Description: schedule.Describe(),
+	}
+	return mcp_go.NewToolResultStructured(output, fmt.Sprintf("%q (%s cron): %s.", schedule.Expression, schedule.Dialect, output.Description)), nil
+}
+
+// cronSchedule parses the expression and dialect arguments.
+func cronSchedule(request mcp_go.CallToolRequest) (*CronSchedule, error) {
+	return ParseCron(request.GetString("expression", ""), CronDialect(request.GetString("dialect", string(CronDialectAuto))))
+}
+
+// relativeDescription says how far t is from now, e.g. "in 3 hours".
+func relativeDescription(now, t time.Time) string {
+	if t.Before(now) {
+		return approximateDuration(now.Sub(t)) + " ago"
+	}
+	return "in " + approximateDuration(t.Sub(now))
+}
+
+// maxSessionTimelineCalls bounds the calls sessionTimeline lists.
+const maxSessionTimelineCalls = 200
+
+// SessionTimeline reports when the caller's MCP session started and how
+// time has passed between its tool calls.
+func (s *Server) SessionTimeline(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	limit := request.GetInt("limit", 20)
+	if limit < 0 || limit > maxSessionTimelineCalls {
+		return mcp_go.NewToolResultError(fmt.Sprintf("limit must be between 0 and %d", maxSessionTimelineCalls)), nil
+	}
+	tz := request.GetString("timeZone", "UTC")
+	loc, err := s.loadLocation(tz)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	now := s.TimeManager.Now().In(loc)
+
+	id := sessionID(ctx)
+	tl := SessionTimeline{ID: id, Started: now}
+	if s.Sessions != nil {
+		if recorded, ok := s.Sessions.Timeline(id); ok {
+			tl = recorded
+		}
+	}
+	started := tl.Started.In(loc)
+	slog.InfoContext(ctx, "SessionTimeline", slog.String("session_id", id), slog.String("started", started.Format(dateTimeFormatTimeZone)), slog.Int("tool_calls", tl.TotalCalls))
+
+	output := SessionTimelineOutput{
+		SessionID: id,
+		Started:   newZonedTime(started),
+		Now:       newZonedTime(now),
+		Elapsed:   newDurationOutput(started, now, OutputStyleApproximate, "ago"),
+		ToolCalls: tl.TotalCalls,
+		Calls:     []SessionCallOutput{},
+	}
+	summary := []string{fmt.Sprintf("Session started %s, %s ago.", started.Format(dateTimeFormatTimeZone), approximateDuration(now.Sub(started)))}
+	if len(tl.Calls) == 0 {
+		output.Summary = strings.Join(append(summary, "No earlier tool calls in this session."), " ")
+		return mcp_go.NewToolResultStructured(output, output.Summary), nil
+	}
+
+	calls := make([]SessionCallOutput, len(tl.Calls))
+	previous := started
+	longest := -1
+	var totalGap time.Duration
+	for i, call := range tl.Calls {
+		t := call.Time.In(loc)
+		gap := max(t.Sub(previous), 0)
+		calls[i] = SessionCallOutput{Tool: call.Tool, Time: newZonedTime(t), GapSeconds: gap.Seconds(), Gap: approximateDuration(gap)}
+		if i > 0 {
+			totalGap += gap
+			if longest < 0 || calls[i].GapSeconds > calls[longest].GapSeconds {
+				longest = i
+			}
+		}
+		previous = t
+	}
+
+	last := tl.Calls[len(tl.Calls)-1]
+	sinceLast := newDurationOutput(last.Time.In(loc), now, OutputStyleApproximate, "ago")
+	output.SinceLastCall = &sinceLast
+	summary = append(summary, fmt.Sprintf("%s before this one; the last was %s, %s ago.", plural(tl.TotalCalls, "tool call"), last.Tool, approximateDuration(now.Sub(last.Time))))
+	if longest > 0 {
+		output.AverageGapSeconds = totalGap.Seconds() / float64(len(calls)-1)
+		output.LongestGap = &SessionGapOutput{
+			After:   calls[longest-1],
+			Before:  calls[longest],
+			Seconds: calls[longest].GapSeconds,
+			Text:    calls[longest].Gap,
+		}
+		summary = append(summary, fmt.Sprintf("Longest gap: %s, between %s at %s and %s at %s; average gap %s.",
+			calls[longest].Gap,
+			calls[longest-1].Tool, tl.Calls[longest-1].Time.In(loc).Format(dateTimeFormat),
+			calls[longest].Tool, tl.Calls[longest].Time.In(loc).Format(dateTimeFormat),
+			approximateDuration(totalGap/time.Duration(len(calls)-1))))
+	}
+	output.Summary = strings.Join(summary, " ")
+
+	first := max(len(calls)-limit, 0)
+	output.Calls = calls[first:]
+	lines := make([]string, 0, len(output.Calls))
+	for i, call := range tl.Calls[first:] {
+		after := "after the previous call"
+		if first+i == 0 {
+			after = "after the session started"
+		}
+		lines = append(lines, fmt.Sprintf("%s %s (%s %s)", call.Time.In(loc).Format(dateTimeFormatTimeZone), call.Tool, calls[first+i].Gap, after))
+	}
+	text := output.Summary
+	if len(lines) > 0 {
+		text += "\nRecent tool calls:\n" + strings.Join(lines, "\n")
+	}
+	return mcp_go.NewToolResultStructured(output, text), nil
+}
+
+// StartTimer starts a named timer.
+func (s *Server) StartTimer(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	name, scope, loc, err := s.timerArguments(request)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	if scope == "" {
+		scope = TimerScopeSession
+	}
+	now := s.TimeManager.Now()
+	t, err := s.Timers.Start(timerKey(scope, sessionID(ctx)), name, now)
+	if err := timerStoreFailure(ctx, err); err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	slog.InfoContext(ctx, "StartTimer", slog.String("name", name), slog.String("scope", string(scope)), slog.String("started", t.Started.Format(dateTimeFormatTimeZone)))
+	text := fmt.Sprintf("Started timer %q at %s.", name, t.Started.In(loc).Format(dateTimeFormatTimeZone))
+	return mcp_go.NewToolResultStructured(newTimerOutput(t, scope, now, loc), text), nil
+}
+
+// LapTimer records a split on a running timer.
+func (s *Server) LapTimer(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	name, scope, loc, err := s.timerArguments(request)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	now := s.TimeManager.Now()
+	label := request.GetString("label", "")
+	t, scope, err := s.findTimer(ctx, name, scope, func(key string) (Timer, error) {
+		return s.Timers.Lap(key, name, label, now)
+	})
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+
+	output := newTimerOutput(t, scope, now, loc)
+	lap := output.Laps[len(output.Laps)-1]
+	since := "since the previous lap"
+	if lap.Lap == 1 {
+		since = "since the start"
+	}
+	if label != "" {
+		label = " (" + label + ")"
+	}
+	text := fmt.Sprintf("Lap %d%s of timer %q at %s: %s %s, %s in total.", lap.Lap, label, name, now.In(loc).Format(dateTimeFormatTimeZone), lap.Split, since, timerPhrase(t.Started, now))
+	return mcp_go.NewToolResultStructured(output, text), nil
+}
+
+// StopTimer stops a running timer.
+func (s *Server) StopTimer(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	name, scope, loc, err := s.timerArguments(request)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	now := s.TimeManager.Now()
+	remove := request.GetBool("remove", false)
+	t, scope, err := s.findTimer(ctx, name, scope, func(key string) (Timer, error) {
+		return s.Timers.Stop(key, name, now, remove)
+	})
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+
+	text := fmt.Sprintf("Stopped timer %q at %s after %s", name, t.Stopped.In(loc).Format(dateTimeFormatTimeZone), timerPhrase(t.Started, t.Stopped))
+	if len(t.Laps) > 0 {
+		text += fmt.Sprintf(" (%s)", plural(len(t.Laps), "lap"))
+	}
+	return mcp_go.NewToolResultStructured(newTimerOutput(t, scope, now, loc), text+"."), nil
+}
+
+// ListTimers lists the caller's session timers and the global timers.
+func (s *Server) ListTimers(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	scope, err := ParseTimerScope(request.GetString("scope", ""))
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	loc, err := s.loadLocation(request.GetString("timeZone", "UTC"))
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	now := s.TimeManager.Now()
+	scopes := []TimerScope{TimerScopeSession, TimerScopeGlobal}
+	if request.GetString("scope", "") != "" {
+		scopes = []TimerScope{scope}
+	}
+
+	output := TimersOutput{Now: newZonedTime(now.In(loc)), Timers: []TimerOutput{}}
+	var lines []string
+	for _, scope := range scopes {
+		for _, t := range s.Timers.List(timerKey(scope, sessionID(ctx))) {
+			output.Timers = append(output.Timers, newTimerOutput(t, scope, now, loc))
+			state := fmt.Sprintf("running for %s", timerPhrase(t.Started, now))
+			if !t.Running() {
+				state = fmt.Sprintf("stopped after %s at %s", timerPhrase(t.Started, t.Stopped), t.Stopped.In(loc).Format(dateTimeFormatTimeZone))
+			}
+			if len(t.Laps) > 0 {
+				state += ", " + plural(len(t.Laps), "lap")
+			}
+			lines = append(lines, fmt.Sprintf("%s (%s): %s; started %s", t.Name, scope, state, t.Started.In(loc).Format(dateTimeFormatTimeZone)))
+		}
+	}
+	if len(lines) == 0 {
+		return mcp_go.NewToolResultStructured(output, "No timers."), nil
+	}
+	return mcp_go.NewToolResultStructured(output, fmt.Sprintf("%s at %s:\n%s", plural(len(lines), "timer"), now.In(loc).Format(dateTimeFormatTimeZone), strings.Join(lines, "\n"))), nil
+}
+
+// timerArguments reads the name, scope and timeZone arguments of the timer
+// tools.  The scope is empty when none was given.
+func (s *Server) timerArguments(request mcp_go.CallToolRequest) (string, TimerScope, *time.Location, error) {
+	name := strings.TrimSpace(request.GetString("name", ""))
+	if name == "" {
+		return "", "", nil, fmt.Errorf("a timer name is required")
+	}
+	var scope TimerScope
+	if arg := request.GetString("scope", ""); arg != "" {
+		var err error
+		if scope, err = ParseTimerScope(arg); err != nil {
+			return "", "", nil, err
+		}
+	}
+	loc, err := s.loadLocation(request.GetString("timeZone", "UTC"))
+	if err != nil {
+		return "", "", nil, err
+	}
+	return name, scope, loc, nil
+}
+
+// findTimer applies op to the named timer in scope.  Without a scope it
+// tries the caller's session timers and then the global ones.
+func (s *Server) findTimer(ctx context.Context, name string, scope TimerScope, op func(key string) (Timer, error)) (Timer, TimerScope, error) {
+	scopes := []TimerScope{scope}
+	if scope == "" {
+		scopes = []TimerScope{TimerScopeSession, TimerScopeGlobal}
+	}
+	var err error
+	for _, scope := range scopes {
+		var t Timer
+		t, err = op(timerKey(scope, sessionID(ctx)))
+		var notFound *TimerNotFoundError
+		if errors.As(err, &notFound) {
+			continue
+		}
+		return t, scope, timerStoreFailure(ctx, err)
+	}
+	return Timer{}, "", err
+}
+
+// timerStoreFailure logs a failure to save the timers and reports it to the
+// client, saying that the change is still in effect until the server stops.
+func timerStoreFailure(ctx context.Context, err error) error {
+	var storeErr *TimerStoreError
+	if errors.As(err, &storeErr) {
+		slog.WarnContext(ctx, "Timers were not saved", slog.Any("error", err))
+		return fmt.Errorf("the timer was changed but the change will be lost when the server stops: %w", err)
+	}
+	return err
+}
+
+// AddEvent registers a named deadline or event, replacing any event of the
+// same name.
+func (s *Server) AddEvent(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	name := strings.TrimSpace(request.GetString("name", ""))
+	if name == "" {
+		return mcp_go.NewToolResultError("an event name is required"), nil
+	}
+	input := request.GetString("dateTime", "")
+	if input == "" {
+		return mcp_go.NewToolResultError(NewNilInputTime().Error()), nil
+	}
+	tz := request.GetString("timeZone", "UTC")
+	policy, err := ParseDSTPolicy(request.GetString("dstPolicy", ""))
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	style, err := ParseOutputStyle(request.GetString("outputStyle", ""))
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+
+	now := s.TimeManager.Now()
+	t, err := ParseTime(&TimeOpts{
+		input:     input,
+		reference: now,
+		timeZone:  tz,
+		dstPolicy: policy,
+	})
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	e := Event{
+		Name:        name,
+		Time:        t,
+		TimeZone:    tz,
+		Description: strings.TrimSpace(request.GetString("description", "")),
+		Created:     now,
+	}
+	replaced, err := s.Events.Put(e)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	slog.InfoContext(ctx, "AddEvent", slog.String("name", name), slog.String("time", t.Format(dateTimeFormatTimeZone)), slog.Bool("replaced", replaced))
+	s.eventChanged(ctx, name)
+
+	loc := s.eventLocation(e)
+	output := newEventOutput(e, now, loc, style)
+	verb := "Added"
+	if replaced {
+		verb = "Updated"
+	}
+	text := fmt.Sprintf("%s event %q: due %s, %s.", verb, name, e.Time.In(loc).Format(dateTimeFormatTimeZone), eventCountdown(output))
+	return mcp_go.NewToolResultStructured(output, text), nil
+}
+
+// GetEvent reports how long remains until a registered event, or how
+// overdue it is.
+func (s *Server) GetEvent(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	style, err := ParseOutputStyle(request.GetString("outputStyle", ""))
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	e, err := s.Events.Get(request.GetString("name", ""))
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	now := s.TimeManager.Now()
+	loc := s.eventLocation(e)
+	output := newEventOutput(e, now, loc, style)
+	verb := "is"
+	if EventStatus(output.Status) == EventOverdue {
+		verb = "was"
+	}
+	text := fmt.Sprintf("Event %q %s due %s, %s.", e.Name, verb, e.Time.In(loc).Format(dateTimeFormatTimeZone), eventCountdown(output))
+	if e.Description != "" {
+		text += "\n" + e.Description
+	}
+	return mcp_go.NewToolResultStructured(output, text), nil
+}
+
+// ListEvents lists the registered events, soonest first.
+func (s *Server) ListEvents(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	style, err := ParseOutputStyle(request.GetString("outputStyle", ""))
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	output, events, err := s.eventsOutput(request.GetBool("includePast", false), style)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+
+	now := s.TimeManager.Now()
+	lines := make([]string, 0, len(events))
+	for i, e := range events {
+		lines = append(lines, fmt.Sprintf("%s: due %s, %s", e.Name, e.Time.In(s.eventLocation(e)).Format(dateTimeFormatTimeZone), eventCountdown(output.Events[i])))
+	}
+	hidden := ""
+	if output.Hidden > 0 {
+		hidden = fmt.Sprintf(" (%s hidden; set includePast to list them)", plural(output.Hidden, "past event"))
+	}
+	if len(lines) == 0 {
+		return mcp_go.NewToolResultStructured(output, "No events"+hidden+"."), nil
+	}
+	text := fmt.Sprintf("%s at %s%s:\n%s", plural(len(lines), "event"), now.Format(dateTimeFormatTimeZone), hidden, strings.Join(lines, "\n"))
+	return mcp_go.NewToolResultStructured(output, text), nil
+}
+
+// RemoveEvent forgets a registered event.
+func (s *Server) RemoveEvent(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	e, err := s.Events.Delete(request.GetString("name", ""))
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	slog.InfoContext(ctx, "RemoveEvent", slog.String("name", e.Name))
+	s.eventChanged(ctx, e.Name)
+	now := s.TimeManager.Now()
+	loc := s.eventLocation(e)
+	text := fmt.Sprintf("Removed event %q, which was due %s.", e.Name, e.Time.In(loc).Format(dateTimeFormatTimeZone))
+	return mcp_go.NewToolResultStructured(newEventOutput(e, now, loc, OutputStyleRaw), text), nil
+}
+
+// eventsOutput describes the registered events at the current time, leaving
+// out those that have passed unless includePast is set.  It also returns the
+// events described.
+func (s *Server) eventsOutput(includePast bool, style OutputStyle) (EventsOutput, []Event, error) {
+	events, err := s.Events.List()
+	if err != nil {
+		return EventsOutput{}, nil, err
+	}
+	now := s.TimeManager.Now()
+	output := EventsOutput{Now: newZonedTime(now), Events: []EventOutput{}}
+	kept := events[:0]
+	for _, e := range events {
+		if !includePast && eventStatus(e.Time, now) == EventOverdue {
+			output.Hidden++
+			continue
+		}
+		kept = append(kept, e)
+		output.Events = append(output.Events, newEventOutput(e, now, s.eventLocation(e), style))
+	}
+	return output, kept, nil
+}
+
+// eventLocation returns the time zone the event was registered in.
+func (s *Server) eventLocation(e Event) *time.Location {
+	loc, err := s.loadLocation(e.TimeZone)
+	if err != nil {
+		return e.Time.Location()
+	}
+	return loc
+}
+
+// eventCountdown describes how far an event is from now, e.g. "in
+// 72h0m0s" or "overdue by 2 days".
+func eventCountdown(e EventOutput) string {
+	switch EventStatus(e.Status) {
+	case EventUpcoming:
+		return "in " + e.Remaining.Text
+	case EventOverdue:
+		return "overdue by " + e.Remaining.Text
+	default:
+		return "due now"
+	}
+}
+
+// maxTimeZoneCandidates bounds the candidates findTimeZone lists.
+const maxTimeZoneCandidates = 50
+
+// FindTimeZone lists the time zones that a city, country, abbreviation,
+// offset or zone name may refer to, and the one it resolves to as a time
+// zone argument.
+func (s *Server) FindTimeZone(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	query, err := request.RequireString("query")
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	limit := request.GetInt("limit", 10)
+	if limit < 1 || limit > maxTimeZoneCandidates {
+		return mcp_go.NewToolResultError(fmt.Sprintf("limit must be between 1 and %d", maxTimeZoneCandidates)), nil
+	}
+	now := s.TimeManager.Now()
+	candidates := defaultTimeZoneResolver().Find(query, now, s.TimeManager.LoadLocation)
+	best, rivals := pickTimeZone(candidates)
+	slog.InfoContext(ctx, "FindTimeZone", slog.String("query", query), slog.Int("candidates", len(candidates)))
+
+	output := FindTimeZoneOutput{Query: query, Candidates: []TimeZoneCandidateOutput{}}
+	var lines []string
+	switch {
+	case best != nil:
+		output.Resolved = best.Location.String()
+		lines = append(lines, fmt.Sprintf("%q resolves to %s.", query, output.Resolved))
+	case len(rivals) > 0:
+		lines = append(lines, fmt.Sprintf("%q is ambiguous between %s.", query, plural(len(rivals), "time zone")))
+	default:
+		lines = append(lines, fmt.Sprintf("No time zone matches %q.", query))
+	}
+	for i, c := range candidates[:min(len(candidates), limit)] {
+		t := now.In(c.Location)
+		abbreviation, offset := t.Zone()
+		output.Candidates = append(output.Candidates, TimeZoneCandidateOutput{
+			TimeZone:     c.Location.String(),
+			Match:        string(c.Match),
+			Description:  c.Description,
+			Score:        c.Score,
+			Abbreviation: abbreviation,
+			UTCOffset:    t.Format("-07:00"),
+			IsDST:        t.IsDST(),
+		})
+		lines = append(lines, fmt.Sprintf("%d. %s (%s, %s): %s", i+1, c.Location, abbreviation, formatUTCOffset(offset), c.Description))
+	}
+	if best == nil && len(candidates) > 0 && len(rivals) == 0 {
+		lines[0] += "  Did you mean one of these?"
+	}
+	return mcp_go.NewToolResultStructured(output, strings.Join(lines, "\n")), nil
+}
+
+// DateInfo describes a date's place in the calendar: its ISO week, day of
+// the year and quarter, and the week, month, quarter and year around it.
+func (s *Server) DateInfo(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	weekStart, err := weekStartArgument(request)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	tz := request.GetString("timeZone", "UTC")
+	loc, err := s.loadLocation(tz)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	d := s.TimeManager.Now().In(loc)
+	if input := request.GetString("dateTime", ""); input != "" {
+		if d, err = ParseTime(&TimeOpts{input: input, reference: d, timeZone: tz}); err != nil {
+			return mcp_go.NewToolResultError(err.Error()), nil
+		}
+		d = d.In(loc)
+	}
+	output := newDateInfoOutput(d, weekStart)
+	slog.InfoContext(ctx, "DateInfo", slog.String("date", output.Date), slog.String("week_start", output.WeekStart))
+
+	leap := "not a leap year"
+	if output.IsLeapYear {
+		leap = "a leap year"
+	}
+	text := strings.Join([]string{
+		fmt.Sprintf("%s is a %s, day %d of %d (%s), in ISO week %d of %d (%s) and Q%d.", output.Date, output.DayOfWeek, output.DayOfYear, d.Year(), output.OrdinalDate, output.ISOWeek, output.ISOWeekYear, output.ISOWeekDate, output.Quarter),
+		fmt.Sprintf("Week (starting %s): %s.", output.WeekStart, describePeriod(output.Periods.Week)),
+		fmt.Sprintf("Month: %s.", describePeriod(output.Periods.Month)),
+		fmt.Sprintf("Quarter: %s.", describePeriod(output.Periods.Quarter)),
+		fmt.Sprintf("Year: %s; %d is %s.", describePeriod(output.Periods.Year), d.Year(), leap),
+	}, "\n")
+	return mcp_go.NewToolResultStructured(output, text), nil
+}
+
+// MonthCalendar lays out a month as a wall calendar.
+func (s *Server) MonthCalendar(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	weekStart, err := weekStartArgument(request)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	now := s.TimeManager.Now()
+	year := request.GetInt("year", now.Year())
+	if year < 1 || year > 9999 {
+		return mcp_go.NewToolResultError(fmt.Sprintf("invalid year %d; expected 1 to 9999", year)), nil
+	}
+	month := request.GetInt("month", int(now.Month()))
+	if month < 1 || month > 12 {
+		return mcp_go.NewToolResultError(fmt.Sprintf("invalid month %d; expected 1 to 12", month)), nil
+	}
+	output := newMonthCalendarOutput(year, time.Month(month), weekStart)
+	slog.InfoContext(ctx, "MonthCalendar", slog.Int("year", year), slog.Int("month", month), slog.String("week_start", output.WeekStart))
+	return mcp_go.NewToolResultStructured(output, output.Grid), nil
+}
+
+// weekStartArgument reads the weekStart argument, which defaults to Monday
+// as in ISO 8601.
+func weekStartArgument(request mcp_go.CallToolRequest) (time.Weekday, error) {
+	input := request.GetString("weekStart", "")
+	if input == "" {
+		return time.Monday, nil
+	}
+	return parseWeekday(input)
+}
+
+// FiscalDate finds the fiscal year, quarter, period and week of a date in
+// a fiscal calendar.
+func (s *Server) FiscalDate(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	calendar, err := s.fiscalCalendar(request)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	tz := request.GetString("timeZone", "UTC")
+	loc, err := s.loadLocation(tz)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	d := s.TimeManager.Now().In(loc)
+	if input := request.GetString("dateTime", ""); input != "" {
+		if d, err = ParseTime(&TimeOpts{input: input, reference: d, timeZone: tz}); err != nil {
+			return mcp_go.NewToolResultError(err.Error()), nil
+		}
+		d = d.In(loc)
+	}
+	if d.Year() < 2 || d.Year() > 9998 {
+		return mcp_go.NewToolResultError(fmt.Sprintf("invalid year %d; expected 2 to 9998", d.Year())), nil
+	}
+	output := newFiscalDateOutput(calendar, calendar.Date(d))
+	slog.InfoContext(ctx, "FiscalDate", slog.String("calendar", calendar.ID), slog.String("date", output.Date), slog.Int("fiscal_year", output.FiscalYear))
+
+	year := fmt.Sprintf("%s, %s", output.Label, plural(output.Periods.Year.Days, "day"))
+	if output.WeeksInYear > 0 {
+		year = fmt.Sprintf("%s, a %d-week year", output.Label, output.WeeksInYear)
+	}
+	text := strings.Join([]string{
+		fmt.Sprintf("%s is in %s of the %s fiscal calendar (%s): Q%d, period %d, week %d, day %d of the fiscal year.", output.Date, output.Label, calendar.ID, calendar.Name, output.Quarter, output.Period, output.Week, output.DayOfYear),
+		fmt.Sprintf("Week %d: %s.", output.Week, describePeriod(output.Periods.Week)),
+		fmt.Sprintf("Period %d: %s.", output.Period, describePeriod(output.Periods.Period)),
+		fmt.Sprintf("Q%d: %s.", output.Quarter, describePeriod(output.Periods.Quarter)),
+		fmt.Sprintf("Year (%s): %s.", year, describePeriod(output.Periods.Year)),
+	}, "\n")
+	return mcp_go.NewToolResultStructured(output, text), nil
+}
+
+// FiscalPeriods lists the quarters and periods of a fiscal year.
+func (s *Server) FiscalPeriods(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	calendar, err := s.fiscalCalendar(request)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	year := request.GetInt("fiscalYear", calendar.YearOf(s.TimeManager.Now()).Year)
+	if year < 2 || year > 9998 {
+		return mcp_go.NewToolResultError(fmt.Sprintf("invalid fiscal year %d; expected 2 to 9998", year)), nil
+	}
+	output := newFiscalYearOutput(calendar, calendar.Year(year))
+	slog.InfoContext(ctx, "FiscalPeriods", slog.String("calendar", calendar.ID), slog.Int("fiscal_year", year))
+
+	var b strings.Builder
+	size := plural(output.Days, "day")
+	if output.Weeks > 0 {
+		size = fmt.Sprintf("%s, %s", plural(output.Weeks, "week"), size)
+	}
+	fmt.Fprintf(&b, "%s of the %s fiscal calendar (%s) runs from %s to %s (%s).\nRules: %s.", output.Label, calendar.ID, calendar.Name, output.First, output.Last, size, output.Rules)
+	for _, q := range output.Quarters {
+		fmt.Fprintf(&b, "\nQ%d: %s", q.Number, describeFiscalPeriod(q))
+		for _, p := range output.Periods[3*q.Number-3 : 3*q.Number] {
+			fmt.Fprintf(&b, "\n  Period %d: %s", p.Number, describeFiscalPeriod(p))
+		}
+	}
+	return mcp_go.NewToolResultStructured(output, b.String()), nil
+}
+
+// fiscalCalendar looks up the calendar named by the fiscalCalendar
+// argument.
+func (s *Server) fiscalCalendar(request mcp_go.CallToolRequest) (*FiscalCalendar, error) {
+	id := request.GetString("fiscalCalendar", "")
+	if s.Fiscal == nil {
+		return nil, NewUnknownFiscalCalendarError(id, nil)
+	}
+	return s.Fiscal.Lookup(id)
+}
+
+// ConvertCalendar converts a date between the Gregorian calendar and the
+// Julian, ISO week, Hebrew, Islamic, Persian, Chinese, Coptic and Ethiopian
+// calendars.
+func (s *Server) ConvertCalendar(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	var fixed int
+	if from := request.GetString("fromCalendar", "gregorian"); !strings.EqualFold(from, "gregorian") || request.GetInt("year", 0) != 0 {
+		c, err := LookupCalendarSystem(from)
+		if err != nil {
+			return mcp_go.NewToolResultError(err.Error()), nil
+		}
+		year, month, day := request.GetInt("year", 0), request.GetInt("month", 0), request.GetInt("day", 0)
+		if year == 0 || month == 0 || day == 0 {
+			return mcp_go.NewToolResultError("year, month and day must be provided to convert from the " + c.Name + " calendar"), nil
+		}
+		if fixed, err = c.ToFixed(year, month, day, request.GetBool("leapMonth", false)); err != nil {
+			return mcp_go.NewToolResultError(err.Error()), nil
+		}
+	} else {
+		tz := request.GetString("timeZone", "UTC")
+		loc, err := s.loadLocation(tz)
+		if err != nil {
+			return mcp_go.NewToolResultError(err.Error()), nil
+		}
+		d := s.TimeManager.Now().In(loc)
+		if input := request.GetString("dateTime", ""); input != "" {
+			if d, err = ParseTime(&TimeOpts{input: input, reference: d, timeZone: tz}); err != nil {
+				return mcp_go.NewToolResultError(err.Error()), nil
+			}
+			d = d.In(loc)
+		}
+		if d.Year() < 1 || d.Year() > 9999 {
+			return mcp_go.NewToolResultError(fmt.Sprintf("invalid year %d; expected 1 to 9999", d.Year())), nil
+		}
+		fixed = fixedFromTime(d)
+	}
+
+	calendars := CalendarSystems
+	if to := request.GetString("toCalendar", ""); to != "" {
+		c, err := LookupCalendarSystem(to)
+		if err != nil {
+			return mcp_go.NewToolResultError(err.Error()), nil
+		}
+		if !c.Supports(fixed) {
+			return mcp_go.NewToolResultError(fmt.Sprintf("the %s calendar conversion supports Gregorian years %d to %d", c.Name, c.MinYear, c.MaxYear)), nil
+		}
+		calendars = []*CalendarSystem{c}
+	}
+
+	date := timeFromFixed(fixed)
+	output := ConvertCalendarOutput{
+		Date:            date.Format(dateFormat),
+		DayOfWeek:       date.Weekday().String(),
+		JulianDayNumber: fixed + fixedToJulianDayNumber,
+		Dates:           []CalendarDateOutput{},
+	}
+	lines := []string{fmt.Sprintf("%s (%s, Julian day %d) is:", output.Date, output.DayOfWeek, output.JulianDayNumber)}
+	for _, c := range calendars {
+		if !c.Supports(fixed) {
+			continue
+		}
+		d := newCalendarDateOutput(c, c.FromFixed(fixed))
+		output.Dates = append(output.Dates, d)
+		lines = append(lines, fmt.Sprintf("%s: %s", c.Name, d.Formatted))
+	}
+	slog.InfoContext(ctx, "ConvertCalendar", slog.String("date", output.Date), slog.Int("calendars", len(output.Dates)))
+	return mcp_go.NewToolResultStructured(output, strings.Join(lines, "\n")), nil
+}
+
+// coordinates reads the latitude and longitude arguments.
+func coordinates(request mcp_go.CallToolRequest) (latitude, longitude float64, err error) {
+	if latitude, err = request.RequireFloat("latitude"); err != nil {
+		return 0, 0, err
+	}
+	if longitude, err = request.RequireFloat("longitude"); err != nil {
+		return 0, 0, err
+	}
+	if math.Abs(latitude) > 90 || math.Abs(longitude) > 180 || math.IsNaN(latitude) || math.IsNaN(longitude) {
+		return 0, 0, NewInvalidCoordinatesError(latitude, longitude)
+	}
+	return latitude, longitude, nil
+}
+
+// SunTimes gives sunrise, sunset, twilight, solar noon and the day length
+// at a latitude and longitude on a date, in a time zone.
+func (s *Server) SunTimes(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	latitude, longitude, err := coordinates(request)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	tz := request.GetString("timeZone", "UTC")
+	loc, err := s.loadLocation(tz)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	date := s.TimeManager.Now().In(loc)
+	if input := request.GetString("dateTime", ""); input != "" {
+		if date, err = ParseTime(&TimeOpts{input: input, reference: date, timeZone: tz}); err != nil {
+			return mcp_go.NewToolResultError(err.Error()), nil
+		}
+		date = date.In(loc)
+	}
+	if date.Year() < 1600 || date.Year() > 2500 {
+		return mcp_go.NewToolResultError(fmt.Sprintf("invalid year %d; sun times are computed for 1600 to 2500", date.Year())), nil
+	}
+
+	day := sunDay(date, latitude, longitude)
+	output := newSunTimesOutput(day, date, latitude, longitude)
+	lines := []string{fmt.Sprintf("Sun times for %s on %s (%s), in %s:", formatCoordinates(latitude, longitude), output.Date, date.Weekday(), output.TimeZone)}
+	events := []struct {
+		name     string
+		crossing SunCrossing
+		altitude float64
+	}{
+		{"Astronomical dawn", day.AstronomicalDawn, astronomicalAltitude},
+		{"Nautical dawn", day.NauticalDawn, nauticalTwilightAltitude},
+		{"Civil dawn", day.CivilDawn, civilTwilightAltitude},
+		{"Sunrise", day.Sunrise, sunriseAltitude},
+		{"Sunset", day.Sunset, sunriseAltitude},
+		{"Civil dusk", day.CivilDusk, civilTwilightAltitude},
+		{"Nautical dusk", day.NauticalDusk, nauticalTwilightAltitude},
+		{"Astronomical dusk", day.AstronomicalDusk, astronomicalAltitude},
+	}
+	for i, event := range events {
+		if i == 4 {
+			lines = append(lines, fmt.Sprintf("Solar noon: %s, with the Sun %s", describeSunCrossing(SunCrossing{At: day.SolarNoon}, 0, date), describeElevation(day.NoonElevation)))
+		}
+		lines = append(lines, event.name+": "+describeSunCrossing(event.crossing, event.altitude, date))
+	}
+	switch output.Daylight {
+	case "polar day":
+		lines = append(lines, "Day length: 24 hours (polar day)")
+	case "polar night":
+		lines = append(lines, "Day length: none (polar night)")
+	default:
+		lines = append(lines, "Day length: "+output.DayLength)
+	}
+	slog.InfoContext(ctx, "SunTimes", slog.Float64("latitude", latitude), slog.Float64("longitude", longitude), slog.String("date", output.Date), slog.String("time_zone", output.TimeZone))
+	return mcp_go.NewToolResultStructured(output, strings.Join(lines, "\n")), nil
+}
+
+// SolarPosition gives the Sun's elevation and azimuth seen from a latitude
+// and longitude at an instant.
+func (s *Server) SolarPosition(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	latitude, longitude, err := coordinates(request)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	tz := request.GetString("timeZone", "UTC")
+	loc, err := s.loadLocation(tz)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	t := s.TimeManager.Now().In(loc)
+	if input := request.GetString("dateTime", ""); input != "" {
+		if t, err = ParseTime(&TimeOpts{input: input, reference: t, timeZone: tz}); err != nil {
+			return mcp_go.NewToolResultError(err.Error()), nil
+		}
+		t = t.In(loc)
+	}
+	if t.Year() < 1600 || t.Year() > 2500 {
+		return mcp_go.NewToolResultError(fmt.Sprintf("invalid year %d; the Sun's position is computed for 1600 to 2500", t.Year())), nil
+	}
+
+	elevation, azimuth := sunAltitudeAzimuth(momentFromTime(t), latitude, longitude)
+	output := SolarPositionOutput{
+		Time:      newZonedTime(t),
+		Latitude:  latitude,
+		Longitude: longitude,
+		Elevation: math.Round(elevation*100) / 100,
+		Azimuth:   math.Round(azimuth*100) / 100,
+		Direction: compassPoint(azimuth),
+		Phase:     solarPhase(elevation),
+	}
+	text := fmt.Sprintf("At %s, seen from %s, the Sun is %s at azimuth %.1f° (%s): %s.",
+		t.Format(time.RFC3339), formatCoordinates(latitude, longitude), describeElevation(elevation), azimuth, output.Direction, output.Phase)
+	slog.InfoContext(ctx, "SolarPosition", slog.Float64("latitude", latitude), slog.Float64("longitude", longitude), slog.String("time", output.Time.DateTime))
+	return mcp_go.NewToolResultStructured(output, text), nil
+}
+
+// maxDSTTransitions bounds the changes dstTransitions lists.
+const maxDSTTransitions = 200
+
+// DSTTransitions lists the changes of a zone's UTC offset in a year or
+// between two dates, such as the starts and ends of daylight saving time.
+func (s *Server) DSTTransitions(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	tz, err := request.RequireString("timeZone")
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	loc, err := s.loadLocation(tz)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	now := s.TimeManager.Now().In(loc)
+
+	var from, to time.Time
+	fromInput := request.GetString("from", "")
+	toInput := request.GetString("to", "")
+	if fromInput == "" && toInput == "" {
+		year := request.GetInt("year", now.Year())
+		if year < 1 || year > 9999 {
+			return mcp_go.NewToolResultError(fmt.Sprintf("invalid year %d; expected 1 to 9999", year)), nil
+		}
+		from = time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+		to = from.AddDate(1, 0, 0)
+	} else {
+		from = now
+		if fromInput != "" {
+			if from, err = ParseTime(&TimeOpts{input: fromInput, reference: now, timeZone: tz}); err != nil {
+				return mcp_go.NewToolResultError(err.Error()), nil
+			}
+		}
+		to = from.AddDate(1, 0, 0)
+		if toInput != "" {
+			if to, err = ParseTime(&TimeOpts{input: toInput, reference: now, timeZone: tz}); err != nil {
+				return mcp_go.NewToolResultError(err.Error()), nil
+			}
+		}
+		if !to.After(from) {
+			return mcp_go.NewToolResultError("to must be after from"), nil
+		}
+		if to.After(from.AddDate(maxTransitionYears, 0, 0)) {
+			return mcp_go.NewToolResultError(fmt.Sprintf("the window must be at most %d years long", maxTransitionYears)), nil
+		}
+	}
+	from, to = from.In(loc), to.In(loc)
+
+	transitions, truncated := zoneTransitions(loc, from, to, maxDSTTransitions)
+	slog.InfoContext(ctx, "DSTTransitions", slog.String("time_zone", loc.String()), slog.String("from", from.Format(dateTimeFormatTimeZone)), slog.String("to", to.Format(dateTimeFormatTimeZone)), slog.Int("transitions", len(transitions)))
+	output := DSTTransitionsOutput{
+		TimeZone:    loc.String(),
+		From:        from.Format(time.RFC3339),
+		To:          to.Format(time.RFC3339),
+		Transitions: make([]ZoneTransitionOutput, 0, len(transitions)),
+		Truncated:   truncated,
+	}
+	window := fmt.Sprintf("from %s to %s", from.Format(dateTimeFormatTimeZone), to.Format(dateTimeFormatTimeZone))
+	if len(transitions) == 0 {
+		abbreviation, offset := from.Zone()
+		return mcp_go.NewToolResultStructured(output, fmt.Sprintf("%s keeps %s (%s) %s; its clocks do not change.", loc, abbreviation, formatUTCOffset(offset), window)), nil
+	}
+	lines := []string{fmt.Sprintf("%s changes its clocks %s %s:", loc, plural(len(transitions), "time"), window)}
+	for _, tr := range transitions {
+		output.Transitions = append(output.Transitions, newZoneTransitionOutput(tr))
+		lines = append(lines, describeZoneTransition(tr))
+	}
+	if truncated {
+		lines = append(lines, fmt.Sprintf("Only the first %d changes are listed.", maxDSTTransitions))
+	}
+	return mcp_go.NewToolResultStructured(output, strings.Join(lines, "\n")), nil
+}
+
+// NextTransition reports the next change of a zone's UTC offset after the
+// current time, and the last one before it.
+func (s *Server) NextTransition(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	tz, err := request.RequireString("timeZone")
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	loc, err := s.loadLocation(tz)
+	if err != nil {
+		return mcp_go.NewToolResultError(err.Error()), nil
+	}
+	now := s.TimeManager.Now().In(loc)
+
+	output := NextTransitionOutput{Now: newZonedTime(now)}
+	var lines []string
+	next, ok := nextZoneTransition(loc, now)
+	if ok {
+		transition := newZoneTransitionOutput(next)
+		until := newDurationOutput(now, next.At, OutputStyleApproximate, "from now")
+		output.Next, output.Until = &transition, &until
+		lines = append(lines, fmt.Sprintf("Next change in %s, in %s: %s.", loc, approximateDuration(next.At.Sub(now)), describeZoneTransition(next)))
+	} else {
+		abbreviation, offset := now.Zone()
+		lines = append(lines, fmt.Sprintf("%s has no scheduled change; it keeps %s (%s).", loc, abbreviation, formatUTCOffset(offset)))
+	}
+	if previous, ok := previousZoneTransition(loc, now); ok {
+		transition := newZoneTransitionOutput(previous)
+		output.Previous = &transition
+		lines = append(lines, fmt.Sprintf("Last change, %s ago: %s.", approximateDuration(now.Sub(previous.At)), describeZoneTransition(previous)))
+	}
+	slog.InfoContext(ctx, "NextTransition", slog.String("time_zone", loc.String()), slog.Bool("found", ok))
+	return mcp_go.NewToolResultStructured(output, strings.Join(lines, "\n")), nil
+}
+
+// hostTimeZoneDatabase returns the host's copy of the time zone database,
+// looked up once.
+var hostTimeZoneDatabase = sync.OnceValues(SystemTimeZoneDatabase)
+
+// TimeZoneDatabaseInfo reports which release of the IANA time zone
+// database the server uses and where it was loaded from.
+func (s *Server) TimeZoneDatabaseInfo(ctx context.Context, request mcp_go.CallToolRequest) (*mcp_go.CallToolResult, error) {
+	db := CurrentTimeZoneDatabase()
+	output := TimeZoneDatabaseOutput{
+		Version:         db.Version,
+		Source:          db.Source,
+		TimeZones:       len(db.Names()),
+		EmbeddedVersion: EmbeddedTimeZoneDatabase().Version,
+	}
+	lines := []string{fmt.Sprintf("Time zones are loaded from %s, which has %s.", describeTimeZoneDatabase(db), plural(output.TimeZones, "time zone"))}
+	if host, err := hostTimeZoneDatabase(); err == nil {
+		output.SystemVersion, output.SystemSource = host.Version, host.Source
+		if host.Source != db.Source {
+			line := fmt.Sprintf("The host has %s", describeTimeZoneDatabase(host))
+			if host.NewerThan(db) {
+				line += ", which is newer"
+			}
+			lines = append(lines, line+".")
+		}
+	}
+	slog.InfoContext(ctx, "TimeZoneDatabaseInfo", slog.String("version", db.Version), slog.String("source", db.Source))
+	return mcp_go.NewToolResultStructured(output, strings.Join(lines, "  ")), nil
+}
+
+// describeZoneTime formats t with its zone abbreviation, UTC offset and
+// whether daylight saving time is in effect.
+func describeZoneTime(t time.Time) string {
+	abbreviation, offset := t.Zone()
+	dst := "standard time"
+	if t.IsDST() {
+		dst = "daylight saving time"
+	}
+	return fmt.Sprintf("%s (%s, %s, %s)", t.Format(dateTimeFormatTimeZone), abbreviation, formatUTCOffset(offset), dst)
+}
+
+// formatUTCOffset formats an offset in seconds as "UTC+05:30".
+func formatUTCOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	return fmt.Sprintf("UTC%s%02d:%02d", sign, offset/3600, offset%3600/60)
+}
+
+// daysFrom counts the calendar days from a to b on a's wall clock.
+func daysFrom(a, b time.Time) int {
+	b = b.In(a.Location())
+	return int(time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC).Sub(time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)).Hours() / 24)
+}
+
+// otherReading returns the candidate instant of a gap or fold that was not
+// chosen.
+func otherReading(wc WallClock) time.Time {
+	if wc.Time.Equal(wc.Earlier) {
+		return wc.Later
+	}
+	return wc.Earlier
+}
+
+// parseRelativeWallClock interprets opts.input as a natural-language
+// expression after it failed to parse as ISO 8601 with isoErr.
+func parseRelativeWallClock(opts *TimeOpts, loc *time.Location, isoErr error) (WallClock, error) {
+	reference := opts.reference
+	if reference.IsZero() {
+		reference = time.Now()
+	}
+	r, err := parseRelative(opts.input, reference, loc, opts.dstPolicy)
+	if err != nil {
+		// Inputs that got as far as a four digit year were most likely meant
+		// as ISO 8601, so report that grammar instead.
+		if formatErr, ok := isoErr.(*InvalidTimeFormatError); ok && formatErr.Position >= 4 {
+			return WallClock{}, isoErr
+		}
+		return WallClock{}, err
+	}
+	if r.exact {
+		t := r.t.In(loc)
+		return WallClock{Time: t, Kind: WallClockNormal, Earlier: t, Later: t, Explanation: r.explanation}, nil
+	}
+	wc, err := resolveWallClock(r.t.Year(), r.t.Month(), r.t.Day(), r.t.Hour(), r.t.Minute(), r.t.Second(), r.t.Nanosecond(), loc, opts.dstPolicy)
+	wc.Explanation = r.explanation
+	return wc, err
+}
+
+// dstNote explains how an input that fell in a DST gap or fold was resolved.
+// It returns an empty string for inputs that map to exactly one instant.
+func dstNote(input string, wc WallClock) string {
+	switch wc.Kind {
+	case WallClockGap:
+		return fmt.Sprintf(" (note: %q does not exist locally because of a daylight saving gap; it was interpreted as %s)", input, wc.Time.Format(dateTimeFormatTimeZone))
+	case WallClockFold:
+		return fmt.Sprintf(" (note: %q occurs twice locally because of a daylight saving fold; it was interpreted as %s)", input, wc.Time.Format(dateTimeFormatTimeZone))
+	default:
+		return ""
+	}
 }
 
 // parseWeekday maps a string to a time.Weekday value.
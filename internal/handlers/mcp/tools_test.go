@@ -2,9 +2,11 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
+	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
@@ -17,6 +19,12 @@ func (m *mockTmanager) LoadLocation(name string) (*time.Location, error) {
 	return time.LoadLocation(name)
 }
 
+// NewTimer returns a timer that never fires, since the mock clock never
+// moves.
+func (m *mockTmanager) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	return make(chan time.Time), func() bool { return true }
+}
+
 func TestParseTime(t *testing.T) {
 	testCases := []struct {
 		desc    string
@@ -30,7 +38,75 @@ func TestParseTime(t *testing.T) {
 				input:    "2023-10-01 12:30:00",
 				timeZone: "America/New_York",
 			},
-			want:    time.Date(2023, 10, 1, 12, 30, 0, 0, time.FixedZone("EST", 0)),
+			want:    time.Date(2023, 10, 1, 16, 30, 0, 0, time.UTC),
+			wanterr: false,
+		},
+		{
+			desc: "Local time in a DST gap defaults to the earlier instant",
+			opts: &TimeOpts{
+				input:    "2024-03-10 02:30:00",
+				timeZone: "America/New_York",
+			},
+			want:    time.Date(2024, 3, 10, 6, 30, 0, 0, time.UTC),
+			wanterr: false,
+		},
+		{
+			desc: "Local time in a DST gap with later policy",
+			opts: &TimeOpts{
+				input:     "2024-03-10 02:30:00",
+				timeZone:  "America/New_York",
+				dstPolicy: DSTPolicyLater,
+			},
+			want:    time.Date(2024, 3, 10, 7, 30, 0, 0, time.UTC),
+			wanterr: false,
+		},
+		{
+			desc: "Local time in a DST gap with error policy",
+			opts: &TimeOpts{
+				input:     "2024-03-10 02:30:00",
+				timeZone:  "America/New_York",
+				dstPolicy: DSTPolicyError,
+			},
+			want:    time.Time{},
+			wanterr: true,
+		},
+		{
+			desc: "Local time in a DST fold defaults to the earlier instant",
+			opts: &TimeOpts{
+				input:    "2024-11-03 01:30:00",
+				timeZone: "America/New_York",
+			},
+			want:    time.Date(2024, 11, 3, 5, 30, 0, 0, time.UTC),
+			wanterr: false,
+		},
+		{
+			desc: "Local time in a DST fold with later policy",
+			opts: &TimeOpts{
+				input:     "2024-11-03 01:30:00",
+				timeZone:  "America/New_York",
+				dstPolicy: DSTPolicyLater,
+			},
+			want:    time.Date(2024, 11, 3, 6, 30, 0, 0, time.UTC),
+			wanterr: false,
+		},
+		{
+			desc: "Local time in a DST fold with error policy",
+			opts: &TimeOpts{
+				input:     "2024-11-03 01:30:00",
+				timeZone:  "America/New_York",
+				dstPolicy: DSTPolicyError,
+			},
+			want:    time.Time{},
+			wanterr: true,
+		},
+		{
+			desc: "Southern hemisphere fold",
+			opts: &TimeOpts{
+				input:     "2024-04-07 02:30:00",
+				timeZone:  "Australia/Sydney",
+				dstPolicy: DSTPolicyLater,
+			},
+			want:    time.Date(2024, 4, 6, 16, 30, 0, 0, time.UTC),
 			wanterr: false,
 		},
 		{
@@ -77,10 +153,27 @@ func TestParseTime(t *testing.T) {
 			wanterr: true,
 		},
 		{
-			desc: "Invalid date format",
+			desc: "Reduced precision date and time",
 			opts: &TimeOpts{
 				input: "2023-10-01 12:30",
 			},
+			want:    time.Date(2023, 10, 1, 12, 30, 0, 0, time.UTC),
+			wanterr: false,
+		},
+		{
+			desc: "Inline offset takes precedence over time zone",
+			opts: &TimeOpts{
+				input:    "2023-10-01T12:30:00+02:00",
+				timeZone: "America/New_York",
+			},
+			want:    time.Date(2023, 10, 1, 10, 30, 0, 0, time.UTC),
+			wanterr: false,
+		},
+		{
+			desc: "Invalid date format",
+			opts: &TimeOpts{
+				input: "10/01/2023 12:30",
+			},
 			want:    time.Time{},
 			wanterr: true,
 		},
@@ -141,6 +234,18 @@ func TestCurrentDateTime(t *testing.T) {
 			want:    time.Date(2023, 10, 1, 2, 30, 0, 0, time.FixedZone("HST", -10*60*60)),
 			wantErr: false,
 		},
+		{
+			desc: "Valid request with a city for the timezone",
+			request: &mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: map[string]any{
+						"timeZone": "Honolulu",
+					},
+				},
+			},
+			want:    time.Date(2023, 10, 1, 2, 30, 0, 0, time.FixedZone("HST", -10*60*60)),
+			wantErr: false,
+		},
 	}
 	ctx := context.Background()
 	for _, tc := range testCases {
@@ -221,6 +326,32 @@ func TestTimeUntil(t *testing.T) {
 			want:    "14h30m0s",
 			wantErr: false,
 		},
+		{
+			desc: "Calendar output style",
+			request: &mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: map[string]any{
+						"dateTime":    "2022-08-01 10:00:00",
+						"outputStyle": "calendar",
+					},
+				},
+			},
+			want:    "1 year, 2 months, 2 hours, 30 minutes",
+			wantErr: false,
+		},
+		{
+			desc: "Approximate output style",
+			request: &mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: map[string]any{
+						"dateTime":    "2022-08-01 10:00:00",
+						"outputStyle": "approximate",
+					},
+				},
+			},
+			want:    "about 14 months ago",
+			wantErr: false,
+		},
 	}
 
 	ctx := context.Background()
@@ -321,6 +452,21 @@ func TestTimeDifference(t *testing.T) {
 			want:    "The first time is later than the second time by 1h0m0s",
 			wantErr: false,
 		},
+		{
+			desc: "Wall-clock times spanning a DST fall back transition",
+			request: &mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: map[string]any{
+						"firstDateTime":  "2024-11-03 00:30:00",
+						"firstTimeZone":  "America/New_York",
+						"secondDateTime": "2024-11-03 03:30:00",
+						"secondTimeZone": "America/New_York",
+					},
+				},
+			},
+			want:    "The first time is earlier than the second time by 4h0m0s",
+			wantErr: false,
+		},
 	}
 	ctx := context.Background()
 	for _, tc := range testCases {
@@ -513,6 +659,24 @@ func TestAddDuration(t *testing.T) {
 			duration: "-1h",
 			want:     "New time after adding duration: 2023-10-01 11:30:00 +0000",
 		},
+		{
+			desc:     "Add one month clamps to the end of February",
+			dateTime: "2024-01-31",
+			duration: "1 month",
+			want:     "New time after adding duration: 2024-02-29 00:00:00 +0000",
+		},
+		{
+			desc:     "Add ISO 8601 duration",
+			dateTime: "2023-10-01 12:30:00",
+			duration: "P1Y2M10DT2H30M",
+			want:     "New time after adding duration: 2024-12-11 15:00:00 +0000",
+		},
+		{
+			desc:     "Add human units",
+			dateTime: "2023-10-01 12:30:00",
+			duration: "3 weeks 2 days",
+			want:     "New time after adding duration: 2023-10-24 12:30:00 +0000",
+		},
 		{
 			desc:     "Missing dateTime",
 			dateTime: "",
@@ -531,6 +695,12 @@ func TestAddDuration(t *testing.T) {
 			duration: "abc",
 			wantErr:  true,
 		},
+		{
+			desc:     "Duration too large to add",
+			dateTime: "2024-01-31 00:00:00",
+			duration: "P99999999999999999999Y",
+			wantErr:  true,
+		},
 		{
 			desc:     "Invalid dateTime format",
 			dateTime: "not-a-date",
@@ -600,6 +770,12 @@ func TestSubtractDuration(t *testing.T) {
 			duration: "-1h",
 			want:     "New time after subtracting duration: 2023-10-01 13:30:00 +0000",
 		},
+		{
+			desc:     "Subtract one month clamps to the end of February",
+			dateTime: "2023-03-31",
+			duration: "1mo",
+			want:     "New time after subtracting duration: 2023-02-28 00:00:00 +0000",
+		},
 		{
 			desc:     "Missing dateTime",
 			dateTime: "",
@@ -1065,3 +1241,2412 @@ func TestDaysBetween(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveDateExpression(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		expression string
+		timeZone   string
+		want       string
+		wantErr    bool
+	}{
+		{
+			desc:       "Relative expression in UTC",
+			expression: "next Tuesday at 3pm",
+			want:       "\"next Tuesday at 3pm\" resolves to 2023-10-03 15:00:00 +0000 (Tuesday). Interpretation: the next Tuesday after Sunday 2023-10-01 is 2023-10-03; time of day set to 15:00:00.",
+		},
+		{
+			desc:       "Relative expression in another timezone",
+			expression: "tomorrow at 9am",
+			timeZone:   "America/New_York",
+			want:       "\"tomorrow at 9am\" resolves to 2023-10-02 09:00:00 -0400 (Monday). Interpretation: tomorrow is 2023-10-02; time of day set to 09:00:00.",
+		},
+		{
+			desc:       "ISO 8601 input",
+			expression: "2023-10-05T08:00:00Z",
+			want:       "\"2023-10-05T08:00:00Z\" resolves to 2023-10-05 08:00:00 +0000 (Thursday). Interpretation: parsed as an ISO 8601 date/time.",
+		},
+		{
+			desc:       "Missing expression",
+			expression: "",
+			wantErr:    true,
+		},
+		{
+			desc:       "Unknown expression",
+			expression: "the twelfth of never",
+			wantErr:    true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Server{
+				TimeManager: &mockTmanager{},
+			}
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: map[string]any{
+						"expression": tc.expression,
+						"timeZone":   tc.timeZone,
+					},
+				},
+			}
+			got, _ := s.ResolveDateExpression(ctx, req)
+			if tc.wantErr {
+				if got == nil || !got.IsError {
+					t.Errorf("ResolveDateExpression() expected error, got = %+v", got)
+				}
+				return
+			}
+			if got == nil || len(got.Content) == 0 {
+				t.Fatalf("ResolveDateExpression() got = nil or empty content")
+			}
+			gotTextContent, ok := got.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("ResolveDateExpression() got = %+v, want TextContent", got.Content[0])
+			}
+			if gotTextContent.Text != tc.want {
+				t.Errorf("ResolveDateExpression() got = %v, want %v", gotTextContent.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestConvertTimeZone(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		arguments map[string]any
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc: "Ambiguous source time converted to several zones",
+			arguments: map[string]any{
+				"dateTime":        "2025-11-02 01:30:00",
+				"timeZone":        "America/Chicago",
+				"targetTimeZones": []any{"Asia/Kolkata", "Europe/London"},
+			},
+			want: "Source America/Chicago: 2025-11-02 01:30:00 -0500 (CDT, UTC-05:00, daylight saving time)\n" +
+				"Warning: the local time \"2025-11-02 01:30:00\" occurs twice in America/Chicago because of a daylight saving transition; it was interpreted as 2025-11-02 01:30:00 -0500 (the other occurrence is 2025-11-02 01:30:00 -0600).\n" +
+				"Asia/Kolkata: 2025-11-02 12:00:00 +0530 (IST, UTC+05:30, standard time)\n" +
+				"Europe/London: 2025-11-02 06:30:00 +0000 (GMT, UTC+00:00, standard time)",
+		},
+		{
+			desc: "Skipped source time",
+			arguments: map[string]any{
+				"dateTime":        "2025-03-09 02:30:00",
+				"timeZone":        "America/Chicago",
+				"targetTimeZones": "UTC",
+				"dstPolicy":       "later",
+			},
+			want: "Source America/Chicago: 2025-03-09 03:30:00 -0500 (CDT, UTC-05:00, daylight saving time)\n" +
+				"Warning: the local time \"2025-03-09 02:30:00\" was skipped by a daylight saving transition in America/Chicago; it was interpreted as 2025-03-09 03:30:00 -0500 (the other reading is 2025-03-09 01:30:00 -0600).\n" +
+				"UTC: 2025-03-09 08:30:00 +0000 (UTC, UTC+00:00, standard time)",
+		},
+		{
+			desc: "Input offset takes precedence over the source time zone",
+			arguments: map[string]any{
+				"dateTime":        "2024-05-01T13:45:00+02:00",
+				"timeZone":        "America/Chicago",
+				"targetTimeZones": "America/Chicago",
+			},
+			want: "Source UTC+02:00: 2024-05-01 13:45:00 +0200 (UTC+02:00, UTC+02:00, standard time)\n" +
+				"America/Chicago: 2024-05-01 06:45:00 -0500 (CDT, UTC-05:00, daylight saving time)",
+		},
+		{
+			desc: "Current time when no date/time is given",
+			arguments: map[string]any{
+				"targetTimeZones": "Pacific/Honolulu, Asia/Tokyo",
+			},
+			want: "Source UTC: 2023-10-01 12:30:00 +0000 (UTC, UTC+00:00, standard time)\n" +
+				"Pacific/Honolulu: 2023-10-01 02:30:00 -1000 (HST, UTC-10:00, standard time)\n" +
+				"Asia/Tokyo: 2023-10-01 21:30:00 +0900 (JST, UTC+09:00, standard time)",
+		},
+		{
+			desc: "Missing target time zones",
+			arguments: map[string]any{
+				"dateTime": "2025-11-02 01:30:00",
+			},
+			wantErr: true,
+		},
+		{
+			desc: "Unknown target time zone",
+			arguments: map[string]any{
+				"dateTime":        "2025-11-02 01:30:00",
+				"targetTimeZones": []any{"Mars/Olympus_Mons"},
+			},
+			wantErr: true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Server{
+				TimeManager: &mockTmanager{},
+			}
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.arguments,
+				},
+			}
+			got, _ := s.ConvertTimeZone(ctx, req)
+			if tc.wantErr {
+				if got == nil || !got.IsError {
+					t.Errorf("ConvertTimeZone() expected error, got = %+v", got)
+				}
+				return
+			}
+			if got == nil || len(got.Content) == 0 {
+				t.Fatalf("ConvertTimeZone() got = nil or empty content")
+			}
+			gotTextContent, ok := got.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("ConvertTimeZone() got = %+v, want TextContent", got.Content[0])
+			}
+			if gotTextContent.Text != tc.want {
+				t.Errorf("ConvertTimeZone() got = %v, want %v", gotTextContent.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestFindTimeZone(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		arguments map[string]any
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc:      "City",
+			arguments: map[string]any{"query": "Tokyo time"},
+			want:      "\"Tokyo time\" resolves to Asia/Tokyo.\n1. Asia/Tokyo (JST, UTC+09:00): Tokyo, Japan",
+		},
+		{
+			desc:      "Ambiguous abbreviation",
+			arguments: map[string]any{"query": "IST"},
+			want: "\"IST\" is ambiguous between 3 time zones.\n" +
+				"1. Asia/Kolkata (IST, UTC+05:30): India Standard Time\n" +
+				"2. Asia/Jerusalem (IDT, UTC+03:00): Israel Standard Time\n" +
+				"3. Europe/Dublin (IST, UTC+01:00): Irish Standard Time",
+		},
+		{
+			desc:      "Ambiguous city limited",
+			arguments: map[string]any{"query": "Portland", "limit": 1},
+			want:      "\"Portland\" is ambiguous between 2 time zones.\n1. America/Los_Angeles (PDT, UTC-07:00): Portland, United States",
+		},
+		{
+			desc:      "Misspelled zone",
+			arguments: map[string]any{"query": "Amercia/New_Yrok"},
+			want:      "No time zone matches \"Amercia/New_Yrok\".  Did you mean one of these?\n1. America/New_York (EDT, UTC-04:00): similar to Amercia/New_Yrok",
+		},
+		{
+			desc:      "Nothing matches",
+			arguments: map[string]any{"query": "Olympus Mons"},
+			want:      "No time zone matches \"Olympus Mons\".",
+		},
+		{
+			desc:      "Missing query",
+			arguments: map[string]any{},
+			wantErr:   true,
+		},
+		{
+			desc:      "Limit out of range",
+			arguments: map[string]any{"query": "Tokyo", "limit": 0},
+			wantErr:   true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Server{
+				TimeManager: &mockTmanager{},
+			}
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.arguments,
+				},
+			}
+			got, _ := s.FindTimeZone(ctx, req)
+			if tc.wantErr {
+				if got == nil || !got.IsError {
+					t.Errorf("FindTimeZone() expected error, got = %+v", got)
+				}
+				return
+			}
+			if got == nil || len(got.Content) == 0 {
+				t.Fatalf("FindTimeZone() got = nil or empty content")
+			}
+			gotTextContent, ok := got.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("FindTimeZone() got = %+v, want TextContent", got.Content[0])
+			}
+			if gotTextContent.Text != tc.want {
+				t.Errorf("FindTimeZone() got = %v, want %v", gotTextContent.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestDateInfo(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		arguments map[string]any
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc:      "Today",
+			arguments: map[string]any{},
+			want: "2023-10-01 is a Sunday, day 274 of 2023 (2023-274), in ISO week 39 of 2023 (2023-W39-7) and Q4.\n" +
+				"Week (starting Monday): 2023-09-25 to 2023-10-01, day 7 of 7, 0 days left.\n" +
+				"Month: 2023-10-01 to 2023-10-31, day 1 of 31, 30 days left.\n" +
+				"Quarter: 2023-10-01 to 2023-12-31, day 1 of 92, 91 days left.\n" +
+				"Year: 2023-01-01 to 2023-12-31, day 274 of 365, 91 days left; 2023 is not a leap year.",
+		},
+		{
+			desc:      "ISO week belonging to the previous year",
+			arguments: map[string]any{"dateTime": "2027-01-01"},
+			want: "2027-01-01 is a Friday, day 1 of 2027 (2027-001), in ISO week 53 of 2026 (2026-W53-5) and Q1.\n" +
+				"Week (starting Monday): 2026-12-28 to 2027-01-03, day 5 of 7, 2 days left.\n" +
+				"Month: 2027-01-01 to 2027-01-31, day 1 of 31, 30 days left.\n" +
+				"Quarter: 2027-01-01 to 2027-03-31, day 1 of 90, 89 days left.\n" +
+				"Year: 2027-01-01 to 2027-12-31, day 1 of 365, 364 days left; 2027 is not a leap year.",
+		},
+		{
+			desc:      "ISO week belonging to the next year",
+			arguments: map[string]any{"dateTime": "2024-12-30", "weekStart": "Sunday"},
+			want: "2024-12-30 is a Monday, day 365 of 2024 (2024-365), in ISO week 1 of 2025 (2025-W01-1) and Q4.\n" +
+				"Week (starting Sunday): 2024-12-29 to 2025-01-04, day 2 of 7, 5 days left.\n" +
+				"Month: 2024-12-01 to 2024-12-31, day 30 of 31, 1 day left.\n" +
+				"Quarter: 2024-10-01 to 2024-12-31, day 91 of 92, 1 day left.\n" +
+				"Year: 2024-01-01 to 2024-12-31, day 365 of 366, 1 day left; 2024 is a leap year.",
+		},
+		{
+			desc:      "Leap day",
+			arguments: map[string]any{"dateTime": "2024-02-29 23:30:00", "timeZone": "America/New_York"},
+			want: "2024-02-29 is a Thursday, day 60 of 2024 (2024-060), in ISO week 9 of 2024 (2024-W09-4) and Q1.\n" +
+				"Week (starting Monday): 2024-02-26 to 2024-03-03, day 4 of 7, 3 days left.\n" +
+				"Month: 2024-02-01 to 2024-02-29, day 29 of 29, 0 days left.\n" +
+				"Quarter: 2024-01-01 to 2024-03-31, day 60 of 91, 31 days left.\n" +
+				"Year: 2024-01-01 to 2024-12-31, day 60 of 366, 306 days left; 2024 is a leap year.",
+		},
+		{
+			desc:      "Phrase",
+			arguments: map[string]any{"dateTime": "next Friday"},
+			want: "2023-10-06 is a Friday, day 279 of 2023 (2023-279), in ISO week 40 of 2023 (2023-W40-5) and Q4.\n" +
+				"Week (starting Monday): 2023-10-02 to 2023-10-08, day 5 of 7, 2 days left.\n" +
+				"Month: 2023-10-01 to 2023-10-31, day 6 of 31, 25 days left.\n" +
+				"Quarter: 2023-10-01 to 2023-12-31, day 6 of 92, 86 days left.\n" +
+				"Year: 2023-01-01 to 2023-12-31, day 279 of 365, 86 days left; 2023 is not a leap year.",
+		},
+		{
+			desc:      "Invalid week start",
+			arguments: map[string]any{"weekStart": "Funday"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Invalid date",
+			arguments: map[string]any{"dateTime": "2025-02-30"},
+			wantErr:   true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Server{
+				TimeManager: &mockTmanager{},
+			}
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.arguments,
+				},
+			}
+			got, _ := s.DateInfo(ctx, req)
+			if tc.wantErr {
+				if got == nil || !got.IsError {
+					t.Errorf("DateInfo() expected error, got = %+v", got)
+				}
+				return
+			}
+			if got == nil || len(got.Content) == 0 {
+				t.Fatalf("DateInfo() got = nil or empty content")
+			}
+			gotTextContent, ok := got.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("DateInfo() got = %+v, want TextContent", got.Content[0])
+			}
+			if gotTextContent.Text != tc.want {
+				t.Errorf("DateInfo() got = %v, want %v", gotTextContent.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestMonthCalendar(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		arguments map[string]any
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc:      "Current month",
+			arguments: map[string]any{},
+			want: "October 2023\n" +
+				"Mo Tu We Th Fr Sa Su\n" +
+				"                   1\n" +
+				" 2  3  4  5  6  7  8\n" +
+				" 9 10 11 12 13 14 15\n" +
+				"16 17 18 19 20 21 22\n" +
+				"23 24 25 26 27 28 29\n" +
+				"30 31",
+		},
+		{
+			desc:      "Weeks starting on Sunday",
+			arguments: map[string]any{"year": 2025, "month": 2, "weekStart": "Sunday"},
+			want: "February 2025\n" +
+				"Su Mo Tu We Th Fr Sa\n" +
+				"                   1\n" +
+				" 2  3  4  5  6  7  8\n" +
+				" 9 10 11 12 13 14 15\n" +
+				"16 17 18 19 20 21 22\n" +
+				"23 24 25 26 27 28",
+		},
+		{
+			desc:      "Weeks starting on Saturday",
+			arguments: map[string]any{"year": 2024, "month": 9, "weekStart": "saturday"},
+			want: "September 2024\n" +
+				"Sa Su Mo Tu We Th Fr\n" +
+				"    1  2  3  4  5  6\n" +
+				" 7  8  9 10 11 12 13\n" +
+				"14 15 16 17 18 19 20\n" +
+				"21 22 23 24 25 26 27\n" +
+				"28 29 30",
+		},
+		{
+			desc:      "Invalid month",
+			arguments: map[string]any{"year": 2025, "month": 13},
+			wantErr:   true,
+		},
+		{
+			desc:      "Invalid year",
+			arguments: map[string]any{"year": 0, "month": 1},
+			wantErr:   true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Server{
+				TimeManager: &mockTmanager{},
+			}
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.arguments,
+				},
+			}
+			got, _ := s.MonthCalendar(ctx, req)
+			if tc.wantErr {
+				if got == nil || !got.IsError {
+					t.Errorf("MonthCalendar() expected error, got = %+v", got)
+				}
+				return
+			}
+			if got == nil || len(got.Content) == 0 {
+				t.Fatalf("MonthCalendar() got = nil or empty content")
+			}
+			gotTextContent, ok := got.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("MonthCalendar() got = %+v, want TextContent", got.Content[0])
+			}
+			if gotTextContent.Text != tc.want {
+				t.Errorf("MonthCalendar() got = %v, want %v", gotTextContent.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestFiscalDate(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		arguments map[string]any
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc:      "Today in the US federal calendar",
+			arguments: map[string]any{"fiscalCalendar": "US"},
+			want: "2023-10-01 is in FY2024 of the US fiscal calendar (United States federal government): Q1, period 1, week 1, day 1 of the fiscal year.\n" +
+				"Week 1: 2023-10-01 to 2023-10-07, day 1 of 7, 6 days left.\n" +
+				"Period 1: 2023-10-01 to 2023-10-31, day 1 of 31, 30 days left.\n" +
+				"Q1: 2023-10-01 to 2023-12-31, day 1 of 92, 91 days left.\n" +
+				"Year (FY2024, 366 days): 2023-10-01 to 2024-09-30, day 1 of 366, 365 days left.",
+		},
+		{
+			desc:      "Last week of a 53-week retail year",
+			arguments: map[string]any{"fiscalCalendar": "nrf", "dateTime": "2024-02-01"},
+			want: "2024-02-01 is in FY2023 of the NRF fiscal calendar (National Retail Federation 4-5-4 calendar): Q4, period 12, week 53, day 369 of the fiscal year.\n" +
+				"Week 53: 2024-01-28 to 2024-02-03, day 5 of 7, 2 days left.\n" +
+				"Period 12: 2023-12-31 to 2024-02-03, day 33 of 35, 2 days left.\n" +
+				"Q4: 2023-10-29 to 2024-02-03, day 96 of 98, 2 days left.\n" +
+				"Year (FY2023, a 53-week year): 2023-01-29 to 2024-02-03, day 369 of 371, 2 days left.",
+		},
+		{
+			desc:      "Unknown calendar",
+			arguments: map[string]any{"fiscalCalendar": "Atlantis"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Missing calendar",
+			arguments: map[string]any{},
+			wantErr:   true,
+		},
+		{
+			desc:      "Invalid date",
+			arguments: map[string]any{"fiscalCalendar": "US", "dateTime": "not a date"},
+			wantErr:   true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Server{
+				TimeManager: &mockTmanager{},
+				Fiscal:      NewFiscalCalendarRegistry(),
+			}
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.arguments,
+				},
+			}
+			got, _ := s.FiscalDate(ctx, req)
+			if tc.wantErr {
+				if got == nil || !got.IsError {
+					t.Errorf("FiscalDate() expected error, got = %+v", got)
+				}
+				return
+			}
+			if got == nil || len(got.Content) == 0 {
+				t.Fatalf("FiscalDate() got = nil or empty content")
+			}
+			gotTextContent, ok := got.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("FiscalDate() got = %+v, want TextContent", got.Content[0])
+			}
+			if gotTextContent.Text != tc.want {
+				t.Errorf("FiscalDate() got = %v, want %v", gotTextContent.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestFiscalPeriods(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		arguments map[string]any
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc:      "Current year of a month-based calendar",
+			arguments: map[string]any{"fiscalCalendar": "UK"},
+			want: "FY2023 of the UK fiscal calendar (United Kingdom government) runs from 2023-04-01 to 2024-03-31 (366 days).\n" +
+				"Rules: calendar months, years starting in April and named for the year they start in.\n" +
+				"Q1: 2023-04-01 to 2023-06-30 (91 days)\n" +
+				"  Period 1: 2023-04-01 to 2023-04-30 (30 days)\n" +
+				"  Period 2: 2023-05-01 to 2023-05-31 (31 days)\n" +
+				"  Period 3: 2023-06-01 to 2023-06-30 (30 days)\n" +
+				"Q2: 2023-07-01 to 2023-09-30 (92 days)\n" +
+				"  Period 4: 2023-07-01 to 2023-07-31 (31 days)\n" +
+				"  Period 5: 2023-08-01 to 2023-08-31 (31 days)\n" +
+				"  Period 6: 2023-09-01 to 2023-09-30 (30 days)\n" +
+				"Q3: 2023-10-01 to 2023-12-31 (92 days)\n" +
+				"  Period 7: 2023-10-01 to 2023-10-31 (31 days)\n" +
+				"  Period 8: 2023-11-01 to 2023-11-30 (30 days)\n" +
+				"  Period 9: 2023-12-01 to 2023-12-31 (31 days)\n" +
+				"Q4: 2024-01-01 to 2024-03-31 (91 days)\n" +
+				"  Period 10: 2024-01-01 to 2024-01-31 (31 days)\n" +
+				"  Period 11: 2024-02-01 to 2024-02-29 (29 days)\n" +
+				"  Period 12: 2024-03-01 to 2024-03-31 (31 days)",
+		},
+		{
+			desc:      "53-week retail year",
+			arguments: map[string]any{"fiscalCalendar": "NRF", "fiscalYear": 2023},
+			want: "FY2023 of the NRF fiscal calendar (National Retail Federation 4-5-4 calendar) runs from 2023-01-29 to 2024-02-03 (53 weeks, 371 days).\n" +
+				"Rules: 4-5-4 weeks, years ending on the Saturday nearest the end of January and named for the year they start in.\n" +
+				"Q1: 2023-01-29 to 2023-04-29 (13 weeks)\n" +
+				"  Period 1: 2023-01-29 to 2023-02-25 (4 weeks)\n" +
+				"  Period 2: 2023-02-26 to 2023-04-01 (5 weeks)\n" +
+				"  Period 3: 2023-04-02 to 2023-04-29 (4 weeks)\n" +
+				"Q2: 2023-04-30 to 2023-07-29 (13 weeks)\n" +
+				"  Period 4: 2023-04-30 to 2023-05-27 (4 weeks)\n" +
+				"  Period 5: 2023-05-28 to 2023-07-01 (5 weeks)\n" +
+				"  Period 6: 2023-07-02 to 2023-07-29 (4 weeks)\n" +
+				"Q3: 2023-07-30 to 2023-10-28 (13 weeks)\n" +
+				"  Period 7: 2023-07-30 to 2023-08-26 (4 weeks)\n" +
+				"  Period 8: 2023-08-27 to 2023-09-30 (5 weeks)\n" +
+				"  Period 9: 2023-10-01 to 2023-10-28 (4 weeks)\n" +
+				"Q4: 2023-10-29 to 2024-02-03 (14 weeks)\n" +
+				"  Period 10: 2023-10-29 to 2023-11-25 (4 weeks)\n" +
+				"  Period 11: 2023-11-26 to 2023-12-30 (5 weeks)\n" +
+				"  Period 12: 2023-12-31 to 2024-02-03 (5 weeks)",
+		},
+		{
+			desc:      "Invalid year",
+			arguments: map[string]any{"fiscalCalendar": "US", "fiscalYear": 0},
+			wantErr:   true,
+		},
+		{
+			desc:      "Unknown calendar",
+			arguments: map[string]any{"fiscalCalendar": "Atlantis", "fiscalYear": 2024},
+			wantErr:   true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Server{
+				TimeManager: &mockTmanager{},
+				Fiscal:      NewFiscalCalendarRegistry(),
+			}
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.arguments,
+				},
+			}
+			got, _ := s.FiscalPeriods(ctx, req)
+			if tc.wantErr {
+				if got == nil || !got.IsError {
+					t.Errorf("FiscalPeriods() expected error, got = %+v", got)
+				}
+				return
+			}
+			if got == nil || len(got.Content) == 0 {
+				t.Fatalf("FiscalPeriods() got = nil or empty content")
+			}
+			gotTextContent, ok := got.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("FiscalPeriods() got = %+v, want TextContent", got.Content[0])
+			}
+			if gotTextContent.Text != tc.want {
+				t.Errorf("FiscalPeriods() got = %v, want %v", gotTextContent.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestConvertCalendar(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		arguments map[string]any
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc:      "Today in every calendar",
+			arguments: map[string]any{},
+			want: "2023-10-01 (Sunday, Julian day 2460219) is:\n" +
+				"Gregorian: 1 October 2023\n" +
+				"Julian: 18 September 2023\n" +
+				"ISO 8601 week date: 2023-W39-7\n" +
+				"Hebrew: 16 Tishri 5784 AM\n" +
+				"Islamic (tabular, civil epoch): 16 Rabi' al-awwal 1445 AH\n" +
+				"Persian (Solar Hijri): 9 Mehr 1402 SH\n" +
+				"Chinese lunisolar: Bayue 17, Gui-Mao (Water Rabbit) year\n" +
+				"Coptic: 20 Thout 1740 AM\n" +
+				"Ethiopian: 20 Meskerem 2016 EC",
+		},
+		{
+			desc:      "From the Hebrew calendar",
+			arguments: map[string]any{"fromCalendar": "hebrew", "year": 5786, "month": 7, "day": 10, "toCalendar": "gregorian"},
+			want: "2025-10-02 (Thursday, Julian day 2460951) is:\n" +
+				"Gregorian: 2 October 2025",
+		},
+		{
+			desc:      "From a Chinese leap month",
+			arguments: map[string]any{"fromCalendar": "chinese", "year": 2025, "month": 6, "day": 1, "leapMonth": true, "toCalendar": "Gregorian"},
+			want: "2025-07-25 (Friday, Julian day 2460882) is:\n" +
+				"Gregorian: 25 July 2025",
+		},
+		{
+			desc:      "To the Persian calendar",
+			arguments: map[string]any{"dateTime": "2025-03-21", "toCalendar": "persian"},
+			want: "2025-03-21 (Friday, Julian day 2460756) is:\n" +
+				"Persian (Solar Hijri): 1 Farvardin 1404 SH",
+		},
+		{
+			desc:      "Missing day",
+			arguments: map[string]any{"fromCalendar": "islamic", "year": 1447, "month": 9},
+			wantErr:   true,
+		},
+		{
+			desc:      "Date the calendar does not have",
+			arguments: map[string]any{"fromCalendar": "chinese", "year": 2024, "month": 6, "day": 1, "leapMonth": true},
+			wantErr:   true,
+		},
+		{
+			desc:      "Unknown calendar",
+			arguments: map[string]any{"toCalendar": "mayan"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Outside the years the calendar supports",
+			arguments: map[string]any{"dateTime": "1500-01-01", "toCalendar": "chinese"},
+			wantErr:   true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Server{
+				TimeManager: &mockTmanager{},
+			}
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.arguments,
+				},
+			}
+			got, _ := s.ConvertCalendar(ctx, req)
+			if tc.wantErr {
+				if got == nil || !got.IsError {
+					t.Errorf("ConvertCalendar() expected error, got = %+v", got)
+				}
+				return
+			}
+			if got == nil || len(got.Content) == 0 {
+				t.Fatalf("ConvertCalendar() got = nil or empty content")
+			}
+			gotTextContent, ok := got.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("ConvertCalendar() got = %+v, want TextContent", got.Content[0])
+			}
+			if gotTextContent.Text != tc.want {
+				t.Errorf("ConvertCalendar() got = %v, want %v", gotTextContent.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestSunTimes(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		arguments map[string]any
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc:      "Denver in June",
+			arguments: map[string]any{"latitude": 39.7392, "longitude": -104.9903, "dateTime": "2025-06-03", "timeZone": "America/Denver"},
+			want: "Sun times for 39.7392°N, 104.9903°W on 2025-06-03 (Tuesday), in America/Denver:\n" +
+				"Astronomical dawn: 03:34 MDT\n" +
+				"Nautical dawn: 04:20 MDT\n" +
+				"Civil dawn: 05:01 MDT\n" +
+				"Sunrise: 05:33 MDT\n" +
+				"Solar noon: 12:58 MDT, with the Sun 72.7° above the horizon\n" +
+				"Sunset: 20:23 MDT\n" +
+				"Civil dusk: 20:55 MDT\n" +
+				"Nautical dusk: 21:36 MDT\n" +
+				"Astronomical dusk: 22:22 MDT\n" +
+				"Day length: 14 hours, 51 minutes",
+		},
+		{
+			desc:      "Polar day",
+			arguments: map[string]any{"latitude": 69.6492, "longitude": 18.9553, "dateTime": "2025-06-21", "timeZone": "Europe/Oslo"},
+			want: "Sun times for 69.6492°N, 18.9553°E on 2025-06-21 (Saturday), in Europe/Oslo:\n" +
+				"Astronomical dawn: none, the Sun stays above -18° all day\n" +
+				"Nautical dawn: none, the Sun stays above -12° all day\n" +
+				"Civil dawn: none, the Sun stays above -6° all day\n" +
+				"Sunrise: none, the Sun stays above -0.833° all day\n" +
+				"Solar noon: 12:46 CEST, with the Sun 43.8° above the horizon\n" +
+				"Sunset: none, the Sun stays above -0.833° all day\n" +
+				"Civil dusk: none, the Sun stays above -6° all day\n" +
+				"Nautical dusk: none, the Sun stays above -12° all day\n" +
+				"Astronomical dusk: none, the Sun stays above -18° all day\n" +
+				"Day length: 24 hours (polar day)",
+		},
+		{
+			desc:      "Polar night",
+			arguments: map[string]any{"latitude": 69.6492, "longitude": 18.9553, "dateTime": "2025-12-21", "timeZone": "Europe/Oslo"},
+			want: "Sun times for 69.6492°N, 18.9553°E on 2025-12-21 (Sunday), in Europe/Oslo:\n" +
+				"Astronomical dawn: 06:28 CET\n" +
+				"Nautical dawn: 07:46 CET\n" +
+				"Civil dawn: 09:31 CET\n" +
+				"Sunrise: none, the Sun stays below -0.833° all day\n" +
+				"Solar noon: 11:42 CET, with the Sun 3.1° below the horizon\n" +
+				"Sunset: none, the Sun stays below -0.833° all day\n" +
+				"Civil dusk: 13:53 CET\n" +
+				"Nautical dusk: 15:37 CET\n" +
+				"Astronomical dusk: 16:56 CET\n" +
+				"Day length: none (polar night)",
+		},
+		{
+			desc:      "No astronomical night in a London summer",
+			arguments: map[string]any{"latitude": 51.5074, "longitude": -0.1278, "dateTime": "2025-06-21", "timeZone": "Europe/London"},
+			want: "Sun times for 51.5074°N, 0.1278°W on 2025-06-21 (Saturday), in Europe/London:\n" +
+				"Astronomical dawn: none, the Sun stays above -18° all day\n" +
+				"Nautical dawn: 02:40 BST\n" +
+				"Civil dawn: 03:55 BST\n" +
+				"Sunrise: 04:43 BST\n" +
+				"Solar noon: 13:02 BST, with the Sun 61.9° above the horizon\n" +
+				"Sunset: 21:21 BST\n" +
+				"Civil dusk: 22:09 BST\n" +
+				"Nautical dusk: 23:24 BST\n" +
+				"Astronomical dusk: none, the Sun stays above -18° all day\n" +
+				"Day length: 16 hours, 38 minutes",
+		},
+		{
+			desc:      "Defaults to today in UTC",
+			arguments: map[string]any{"latitude": -33.8688, "longitude": 151.2093},
+			want: "Sun times for 33.8688°S, 151.2093°E on 2023-10-01 (Sunday), in UTC:\n" +
+				"Astronomical dawn: 18:09 UTC on 2023-09-30\n" +
+				"Nautical dawn: 18:38 UTC on 2023-09-30\n" +
+				"Civil dawn: 19:08 UTC on 2023-09-30\n" +
+				"Sunrise: 19:33 UTC on 2023-09-30\n" +
+				"Solar noon: 01:45 UTC, with the Sun 59.2° above the horizon\n" +
+				"Sunset: 07:57 UTC\n" +
+				"Civil dusk: 08:22 UTC\n" +
+				"Nautical dusk: 08:51 UTC\n" +
+				"Astronomical dusk: 09:21 UTC\n" +
+				"Day length: 12 hours, 24 minutes",
+		},
+		{
+			desc:      "Missing longitude",
+			arguments: map[string]any{"latitude": 39.7392},
+			wantErr:   true,
+		},
+		{
+			desc:      "Latitude out of range",
+			arguments: map[string]any{"latitude": 91, "longitude": 0},
+			wantErr:   true,
+		},
+		{
+			desc:      "Unknown time zone",
+			arguments: map[string]any{"latitude": 0, "longitude": 0, "timeZone": "Mars/Olympus_Mons"},
+			wantErr:   true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Server{
+				TimeManager: &mockTmanager{},
+			}
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.arguments,
+				},
+			}
+			got, _ := s.SunTimes(ctx, req)
+			if tc.wantErr {
+				if got == nil || !got.IsError {
+					t.Errorf("SunTimes() expected error, got = %+v", got)
+				}
+				return
+			}
+			if got == nil || len(got.Content) == 0 {
+				t.Fatalf("SunTimes() got = nil or empty content")
+			}
+			gotTextContent, ok := got.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("SunTimes() got = %+v, want TextContent", got.Content[0])
+			}
+			if gotTextContent.Text != tc.want {
+				t.Errorf("SunTimes() got = %v, want %v", gotTextContent.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestSolarPosition(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		arguments map[string]any
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc:      "Afternoon in Denver",
+			arguments: map[string]any{"latitude": 39.7392, "longitude": -104.9903, "dateTime": "2025-06-03 17:00", "timeZone": "America/Denver"},
+			want:      "At 2025-06-03T17:00:00-06:00, seen from 39.7392°N, 104.9903°W, the Sun is 36.5° above the horizon at azimuth 270.1° (W): day.",
+		},
+		{
+			desc:      "Now, at night",
+			arguments: map[string]any{"latitude": 35.6762, "longitude": 139.6503},
+			want:      "At 2023-10-01T12:30:00Z, seen from 35.6762°N, 139.6503°E, the Sun is 47.1° below the horizon at azimuth 312.3° (NW): night.",
+		},
+		{
+			desc:      "Longitude out of range",
+			arguments: map[string]any{"latitude": 0, "longitude": 200},
+			wantErr:   true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Server{
+				TimeManager: &mockTmanager{},
+			}
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.arguments,
+				},
+			}
+			got, _ := s.SolarPosition(ctx, req)
+			if tc.wantErr {
+				if got == nil || !got.IsError {
+					t.Errorf("SolarPosition() expected error, got = %+v", got)
+				}
+				return
+			}
+			if got == nil || len(got.Content) == 0 {
+				t.Fatalf("SolarPosition() got = nil or empty content")
+			}
+			gotTextContent, ok := got.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("SolarPosition() got = %+v, want TextContent", got.Content[0])
+			}
+			if gotTextContent.Text != tc.want {
+				t.Errorf("SolarPosition() got = %v, want %v", gotTextContent.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestDSTTransitions(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		arguments map[string]any
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc:      "Year with daylight saving time",
+			arguments: map[string]any{"timeZone": "America/New_York", "year": 2025},
+			want: "America/New_York changes its clocks 2 times from 2025-01-01 00:00:00 -0500 to 2026-01-01 00:00:00 -0500:\n" +
+				"Sunday 2025-03-09: at 02:00:00 EST clocks go forward 1 hour to 03:00:00 EDT (UTC-05:00 to UTC-04:00)\n" +
+				"Sunday 2025-11-02: at 02:00:00 EDT clocks go back 1 hour to 01:00:00 EST (UTC-04:00 to UTC-05:00)",
+		},
+		{
+			desc:      "Southern hemisphere",
+			arguments: map[string]any{"timeZone": "Australia/Sydney", "year": 2025},
+			want: "Australia/Sydney changes its clocks 2 times from 2025-01-01 00:00:00 +1100 to 2026-01-01 00:00:00 +1100:\n" +
+				"Sunday 2025-04-06: at 03:00:00 AEDT clocks go back 1 hour to 02:00:00 AEST (UTC+11:00 to UTC+10:00)\n" +
+				"Sunday 2025-10-05: at 02:00:00 AEST clocks go forward 1 hour to 03:00:00 AEDT (UTC+10:00 to UTC+11:00)",
+		},
+		{
+			desc:      "Half-hour shift",
+			arguments: map[string]any{"timeZone": "Australia/Lord_Howe", "year": 2025},
+			want: "Australia/Lord_Howe changes its clocks 2 times from 2025-01-01 00:00:00 +1100 to 2026-01-01 00:00:00 +1100:\n" +
+				"Sunday 2025-04-06: at 02:00:00 +11 clocks go back 30 minutes to 01:30:00 +1030 (UTC+11:00 to UTC+10:30)\n" +
+				"Sunday 2025-10-05: at 02:00:00 +1030 clocks go forward 30 minutes to 02:30:00 +11 (UTC+10:30 to UTC+11:00)",
+		},
+		{
+			desc:      "Zone without daylight saving time",
+			arguments: map[string]any{"timeZone": "America/Phoenix", "year": 2025},
+			want:      "America/Phoenix keeps MST (UTC-07:00) from 2025-01-01 00:00:00 -0700 to 2026-01-01 00:00:00 -0700; its clocks do not change.",
+		},
+		{
+			desc:      "Window",
+			arguments: map[string]any{"timeZone": "Europe/London", "from": "2024-10-01", "to": "2025-04-01"},
+			want: "Europe/London changes its clocks 2 times from 2024-10-01 00:00:00 +0100 to 2025-04-01 00:00:00 +0100:\n" +
+				"Sunday 2024-10-27: at 02:00:00 BST clocks go back 1 hour to 01:00:00 GMT (UTC+01:00 to UTC+00:00)\n" +
+				"Sunday 2025-03-30: at 01:00:00 GMT clocks go forward 1 hour to 02:00:00 BST (UTC+00:00 to UTC+01:00)",
+		},
+		{
+			desc:      "Change of standard offset",
+			arguments: map[string]any{"timeZone": "Europe/Volgograd", "year": 2020},
+			want: "Europe/Volgograd changes its clocks 1 time from 2020-01-01 00:00:00 +0400 to 2021-01-01 00:00:00 +0300:\n" +
+				"Sunday 2020-12-27: at 02:00:00 +04 clocks go back 1 hour to 01:00:00 MSK (UTC+04:00 to UTC+03:00)",
+		},
+		{
+			desc:      "Window defaults to a year from now",
+			arguments: map[string]any{"timeZone": "Europe/Berlin", "from": "2023-10-01"},
+			want: "Europe/Berlin changes its clocks 2 times from 2023-10-01 00:00:00 +0200 to 2024-10-01 00:00:00 +0200:\n" +
+				"Sunday 2023-10-29: at 03:00:00 CEST clocks go back 1 hour to 02:00:00 CET (UTC+02:00 to UTC+01:00)\n" +
+				"Sunday 2024-03-31: at 02:00:00 CET clocks go forward 1 hour to 03:00:00 CEST (UTC+01:00 to UTC+02:00)",
+		},
+		{
+			desc:      "Missing time zone",
+			arguments: map[string]any{},
+			wantErr:   true,
+		},
+		{
+			desc:      "Unknown time zone",
+			arguments: map[string]any{"timeZone": "Mars/Olympus_Mons"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Window backwards",
+			arguments: map[string]any{"timeZone": "Europe/London", "from": "2025-04-01", "to": "2024-10-01"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Window too long",
+			arguments: map[string]any{"timeZone": "Europe/London", "from": "1900-01-01", "to": "2100-01-01"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Invalid year",
+			arguments: map[string]any{"timeZone": "Europe/London", "year": 0},
+			wantErr:   true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Server{
+				TimeManager: &mockTmanager{},
+			}
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.arguments,
+				},
+			}
+			got, _ := s.DSTTransitions(ctx, req)
+			if tc.wantErr {
+				if got == nil || !got.IsError {
+					t.Errorf("DSTTransitions() expected error, got = %+v", got)
+				}
+				return
+			}
+			if got == nil || len(got.Content) == 0 {
+				t.Fatalf("DSTTransitions() got = nil or empty content")
+			}
+			gotTextContent, ok := got.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("DSTTransitions() got = %+v, want TextContent", got.Content[0])
+			}
+			if gotTextContent.Text != tc.want {
+				t.Errorf("DSTTransitions() got = %v, want %v", gotTextContent.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestNextTransition(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		arguments map[string]any
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc:      "Daylight saving time ends next",
+			arguments: map[string]any{"timeZone": "America/New_York"},
+			want: "Next change in America/New_York, in about a month: Sunday 2023-11-05: at 02:00:00 EDT clocks go back 1 hour to 01:00:00 EST (UTC-04:00 to UTC-05:00).\n" +
+				"Last change, about 7 months ago: Sunday 2023-03-12: at 02:00:00 EST clocks go forward 1 hour to 03:00:00 EDT (UTC-05:00 to UTC-04:00).",
+		},
+		{
+			desc:      "Southern hemisphere",
+			arguments: map[string]any{"timeZone": "Australia/Sydney"},
+			want: "Next change in Australia/Sydney, in about 6 months: Sunday 2024-04-07: at 03:00:00 AEDT clocks go back 1 hour to 02:00:00 AEST (UTC+11:00 to UTC+10:00).\n" +
+				"Last change, about 21 hours ago: Sunday 2023-10-01: at 02:00:00 AEST clocks go forward 1 hour to 03:00:00 AEDT (UTC+10:00 to UTC+11:00).",
+		},
+		{
+			desc:      "Zone without daylight saving time",
+			arguments: map[string]any{"timeZone": "Asia/Tokyo"},
+			want: "Asia/Tokyo has no scheduled change; it keeps JST (UTC+09:00).\n" +
+				"Last change, about 72 years ago: Sunday 1951-09-09: at 01:00:00 JDT clocks go back 1 hour to 00:00:00 JST (UTC+10:00 to UTC+09:00).",
+		},
+		{
+			desc:      "UTC",
+			arguments: map[string]any{"timeZone": "UTC"},
+			want:      "UTC has no scheduled change; it keeps UTC (UTC+00:00).",
+		},
+		{
+			desc:      "Missing time zone",
+			arguments: map[string]any{},
+			wantErr:   true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Server{
+				TimeManager: &mockTmanager{},
+			}
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.arguments,
+				},
+			}
+			got, _ := s.NextTransition(ctx, req)
+			if tc.wantErr {
+				if got == nil || !got.IsError {
+					t.Errorf("NextTransition() expected error, got = %+v", got)
+				}
+				return
+			}
+			if got == nil || len(got.Content) == 0 {
+				t.Fatalf("NextTransition() got = nil or empty content")
+			}
+			gotTextContent, ok := got.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("NextTransition() got = %+v, want TextContent", got.Content[0])
+			}
+			if gotTextContent.Text != tc.want {
+				t.Errorf("NextTransition() got = %v, want %v", gotTextContent.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsBusinessDay(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		arguments map[string]any
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc:      "Weekday without a holiday calendar",
+			arguments: map[string]any{"dateTime": "2024-12-24"},
+			want:      "2024-12-24 is a business day.",
+		},
+		{
+			desc:      "Holiday in the US calendar",
+			arguments: map[string]any{"dateTime": "2024-12-25", "holidayCalendar": "US"},
+			want:      "2024-12-25 is not a business day (Christmas Day, US).",
+		},
+		{
+			desc:      "Observed holiday in the US calendar",
+			arguments: map[string]any{"dateTime": "2026-07-03", "holidayCalendar": "us"},
+			want:      "2026-07-03 is not a business day (Independence Day, US).",
+		},
+		{
+			desc:      "Weekend",
+			arguments: map[string]any{"dateTime": "2024-12-28", "holidayCalendar": "UK"},
+			want:      "2024-12-28 is not a business day (weekend: Saturday, Sunday).",
+		},
+		{
+			desc:      "Friday in a Friday and Saturday weekend",
+			arguments: map[string]any{"dateTime": "2024-10-04", "weekend": "Friday,Saturday"},
+			want:      "2024-10-04 is not a business day (weekend: Friday, Saturday).",
+		},
+		{
+			desc:      "Sunday in a Friday and Saturday weekend",
+			arguments: map[string]any{"dateTime": "2024-10-06", "weekend": "Friday,Saturday"},
+			want:      "2024-10-06 is a business day.",
+		},
+		{
+			desc:      "Unknown calendar",
+			arguments: map[string]any{"dateTime": "2024-10-06", "holidayCalendar": "Atlantis"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Invalid weekend",
+			arguments: map[string]any{"dateTime": "2024-10-06", "weekend": "Caturday"},
+			wantErr:   true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Server{
+				TimeManager: &mockTmanager{},
+				Holidays:    NewHolidayRegistry(),
+			}
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.arguments,
+				},
+			}
+			got, _ := s.IsBusinessDay(ctx, req)
+			if tc.wantErr {
+				if got == nil || !got.IsError {
+					t.Errorf("IsBusinessDay() expected error, got = %+v", got)
+				}
+				return
+			}
+			if got == nil || len(got.Content) == 0 {
+				t.Fatalf("IsBusinessDay() got = nil or empty content")
+			}
+			gotTextContent, ok := got.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("IsBusinessDay() got = %+v, want TextContent", got.Content[0])
+			}
+			if gotTextContent.Text != tc.want {
+				t.Errorf("IsBusinessDay() got = %v, want %v", gotTextContent.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		arguments map[string]any
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc:      "Across a weekend",
+			arguments: map[string]any{"dateTime": "2024-12-20 09:00:00", "days": 1},
+			want:      "Adding 1 business day to 2024-12-20 09:00:00 +0000 gives 2024-12-23 09:00:00 +0000.",
+		},
+		{
+			desc:      "Across a holiday",
+			arguments: map[string]any{"dateTime": "2024-12-20 09:00:00", "days": 5, "holidayCalendar": "US"},
+			want:      "Adding 5 business days to 2024-12-20 09:00:00 +0000 gives 2024-12-30 09:00:00 +0000 (skipped holidays: Christmas Day on 2024-12-25).",
+		},
+		{
+			desc:      "Backwards across substitute days",
+			arguments: map[string]any{"dateTime": "2021-12-29 17:00:00", "days": -1, "holidayCalendar": "UK", "timeZone": "Europe/London"},
+			want:      "Adding -1 business day to 2021-12-29 17:00:00 +0000 gives 2021-12-24 17:00:00 +0000 (skipped holidays: Boxing Day on 2021-12-28, Christmas Day on 2021-12-27).",
+		},
+		{
+			desc:      "Sunday to Thursday working week",
+			arguments: map[string]any{"dateTime": "2024-10-03 10:00:00", "days": 1, "weekend": "Friday,Saturday", "timeZone": "Asia/Dubai"},
+			want:      "Adding 1 business day to 2024-10-03 10:00:00 +0400 gives 2024-10-06 10:00:00 +0400.",
+		},
+		{
+			desc:      "Keeps the local time of day across a DST change",
+			arguments: map[string]any{"dateTime": "2024-03-08 09:00:00", "days": 1, "timeZone": "America/New_York"},
+			want:      "Adding 1 business day to 2024-03-08 09:00:00 -0500 gives 2024-03-11 09:00:00 -0400.",
+		},
+		{
+			desc:      "Missing date",
+			arguments: map[string]any{"days": 1},
+			wantErr:   true,
+		},
+		{
+			desc:      "Unknown calendar",
+			arguments: map[string]any{"dateTime": "2024-12-20", "days": 1, "holidayCalendar": "Atlantis"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Weekend of every day",
+			arguments: map[string]any{"dateTime": "2024-12-20", "days": 1, "weekend": "mon,tue,wed,thu,fri,sat,sun"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Too many days",
+			arguments: map[string]any{"dateTime": "2024-12-20", "days": 100000000},
+			wantErr:   true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Server{
+				TimeManager: &mockTmanager{},
+				Holidays:    NewHolidayRegistry(),
+			}
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.arguments,
+				},
+			}
+			got, _ := s.AddBusinessDays(ctx, req)
+			if tc.wantErr {
+				if got == nil || !got.IsError {
+					t.Errorf("AddBusinessDays() expected error, got = %+v", got)
+				}
+				return
+			}
+			if got == nil || len(got.Content) == 0 {
+				t.Fatalf("AddBusinessDays() got = nil or empty content")
+			}
+			gotTextContent, ok := got.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("AddBusinessDays() got = %+v, want TextContent", got.Content[0])
+			}
+			if gotTextContent.Text != tc.want {
+				t.Errorf("AddBusinessDays() got = %v, want %v", gotTextContent.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestBusinessDaysBetween(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		arguments map[string]any
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc:      "Weekends only",
+			arguments: map[string]any{"firstDate": "2024-12-20", "secondDate": "2025-01-02"},
+			want:      "There are 9 business days between 2024-12-20 and 2025-01-02 (13 calendar days, 4 weekend days).",
+		},
+		{
+			desc:      "With US holidays",
+			arguments: map[string]any{"firstDate": "2024-12-20", "secondDate": "2025-01-02", "holidayCalendar": "US"},
+			want:      "There are 7 business days between 2024-12-20 and 2025-01-02 (13 calendar days, 4 weekend days, 2 holidays: Christmas Day on 2024-12-25, New Year's Day on 2025-01-01).",
+		},
+		{
+			desc:      "Second date first",
+			arguments: map[string]any{"firstDate": "2024-04-08", "secondDate": "2024-03-25", "holidayCalendar": "TARGET2"},
+			want:      "There are -8 business days between 2024-04-08 and 2024-03-25 (-14 calendar days, -4 weekend days, 2 holidays: Good Friday on 2024-03-29, Easter Monday on 2024-04-01).",
+		},
+		{
+			desc:      "Same date",
+			arguments: map[string]any{"firstDate": "2024-04-08", "secondDate": "2024-04-08"},
+			want:      "There are 0 business days between 2024-04-08 and 2024-04-08 (0 calendar days, 0 weekend days).",
+		},
+		{
+			desc:      "Missing second date",
+			arguments: map[string]any{"firstDate": "2024-04-08"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Span too long",
+			arguments: map[string]any{"firstDate": "1500-01-01", "secondDate": "2000-01-01"},
+			wantErr:   true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Server{
+				TimeManager: &mockTmanager{},
+				Holidays:    NewHolidayRegistry(),
+			}
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.arguments,
+				},
+			}
+			got, _ := s.BusinessDaysBetween(ctx, req)
+			if tc.wantErr {
+				if got == nil || !got.IsError {
+					t.Errorf("BusinessDaysBetween() expected error, got = %+v", got)
+				}
+				return
+			}
+			if got == nil || len(got.Content) == 0 {
+				t.Fatalf("BusinessDaysBetween() got = nil or empty content")
+			}
+			gotTextContent, ok := got.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("BusinessDaysBetween() got = %+v, want TextContent", got.Content[0])
+			}
+			if gotTextContent.Text != tc.want {
+				t.Errorf("BusinessDaysBetween() got = %v, want %v", gotTextContent.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestListHolidays(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		arguments map[string]any
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc:      "TARGET2 in a given year",
+			arguments: map[string]any{"holidayCalendar": "TARGET2", "year": 2024},
+			want:      "6 holidays in 2024 (TARGET2 (euro area payment system) closing days):\n2024-01-01 (Monday): New Year's Day\n2024-03-29 (Friday): Good Friday\n2024-04-01 (Monday): Easter Monday\n2024-05-01 (Wednesday): Labour Day\n2024-12-25 (Wednesday): Christmas Day\n2024-12-26 (Thursday): Christmas Holiday",
+		},
+		{
+			desc:      "Observed dates are explained",
+			arguments: map[string]any{"holidayCalendar": "UK", "year": 2021},
+			want:      "8 holidays in 2021 (United Kingdom bank holidays (England and Wales)):\n2021-01-01 (Friday): New Year's Day\n2021-04-02 (Friday): Good Friday\n2021-04-05 (Monday): Easter Monday\n2021-05-03 (Monday): Early May bank holiday\n2021-05-31 (Monday): Spring bank holiday\n2021-08-30 (Monday): Summer bank holiday\n2021-12-27 (Monday): Christmas Day (observed; falls on Saturday 2021-12-25)\n2021-12-28 (Tuesday): Boxing Day (observed; falls on Sunday 2021-12-26)",
+		},
+		{
+			desc:      "Defaults to the current year",
+			arguments: map[string]any{"holidayCalendar": "GR"},
+			want:      "12 holidays in 2023 (Greece public holidays):\n2023-01-01 (Sunday): New Year's Day\n2023-01-06 (Friday): Epiphany\n2023-02-27 (Monday): Clean Monday\n2023-03-25 (Saturday): Independence Day\n2023-04-14 (Friday): Orthodox Good Friday\n2023-04-17 (Monday): Orthodox Easter Monday\n2023-05-01 (Monday): Labour Day\n2023-06-05 (Monday): Whit Monday\n2023-08-15 (Tuesday): Assumption of Mary\n2023-10-28 (Saturday): Ochi Day\n2023-12-25 (Monday): Christmas Day\n2023-12-26 (Tuesday): Synaxis of the Mother of God",
+		},
+		{
+			desc:      "Missing calendar",
+			arguments: map[string]any{"year": 2024},
+			wantErr:   true,
+		},
+		{
+			desc:      "Unknown calendar",
+			arguments: map[string]any{"holidayCalendar": "Atlantis"},
+			wantErr:   true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Server{
+				TimeManager: &mockTmanager{},
+				Holidays:    NewHolidayRegistry(),
+			}
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.arguments,
+				},
+			}
+			got, _ := s.ListHolidays(ctx, req)
+			if tc.wantErr {
+				if got == nil || !got.IsError {
+					t.Errorf("ListHolidays() expected error, got = %+v", got)
+				}
+				return
+			}
+			if got == nil || len(got.Content) == 0 {
+				t.Fatalf("ListHolidays() got = nil or empty content")
+			}
+			gotTextContent, ok := got.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("ListHolidays() got = %+v, want TextContent", got.Content[0])
+			}
+			if gotTextContent.Text != tc.want {
+				t.Errorf("ListHolidays() got = %v, want %v", gotTextContent.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestNextHoliday(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		arguments map[string]any
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc:      "Next US holiday",
+			arguments: map[string]any{"holidayCalendar": "US"},
+			want:      "Next holiday in United States federal holidays from 2023-10-01: 2023-10-09 (Monday, in 8 days): Columbus Day",
+		},
+		{
+			desc:      "Several holidays including an observed one",
+			arguments: map[string]any{"holidayCalendar": "US", "count": 3},
+			want:      "Next 3 holidays in United States federal holidays from 2023-10-01:\n2023-10-09 (Monday, in 8 days): Columbus Day\n2023-11-10 (Friday, in 40 days): Veterans Day (observed; falls on Saturday 2023-11-11)\n2023-11-23 (Thursday, in 53 days): Thanksgiving Day",
+		},
+		{
+			desc:      "Spans into the next year",
+			arguments: map[string]any{"holidayCalendar": "TARGET2", "count": 3},
+			want:      "Next 3 holidays in TARGET2 (euro area payment system) closing days from 2023-10-01:\n2023-12-25 (Monday, in 85 days): Christmas Day\n2023-12-26 (Tuesday, in 86 days): Christmas Holiday\n2024-01-01 (Monday, in 92 days): New Year's Day",
+		},
+		{
+			desc:      "Today in another time zone",
+			arguments: map[string]any{"holidayCalendar": "GR", "timeZone": "Pacific/Kiritimati"},
+			want:      "Next holiday in Greece public holidays from 2023-10-02: 2023-10-28 (Saturday, in 26 days): Ochi Day",
+		},
+		{
+			desc:      "Invalid count",
+			arguments: map[string]any{"holidayCalendar": "US", "count": 0},
+			wantErr:   true,
+		},
+		{
+			desc:      "Unknown time zone",
+			arguments: map[string]any{"holidayCalendar": "US", "timeZone": "Mars/Olympus_Mons"},
+			wantErr:   true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Server{
+				TimeManager: &mockTmanager{},
+				Holidays:    NewHolidayRegistry(),
+			}
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.arguments,
+				},
+			}
+			got, _ := s.NextHoliday(ctx, req)
+			if tc.wantErr {
+				if got == nil || !got.IsError {
+					t.Errorf("NextHoliday() expected error, got = %+v", got)
+				}
+				return
+			}
+			if got == nil || len(got.Content) == 0 {
+				t.Fatalf("NextHoliday() got = nil or empty content")
+			}
+			gotTextContent, ok := got.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("NextHoliday() got = %+v, want TextContent", got.Content[0])
+			}
+			if gotTextContent.Text != tc.want {
+				t.Errorf("NextHoliday() got = %v, want %v", gotTextContent.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestExpandRecurrence(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		arguments map[string]any
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc:      "Weekly rule with a count",
+			arguments: map[string]any{"rrule": "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=3", "dtstart": "2024-01-01T09:00:00", "timeZone": "America/New_York"},
+			want:      "Every week on Monday and Wednesday at 09:00, 3 times, starting 2024-01-01 09:00:00 -0500 in America/New_York.\n3 occurrences:\n2024-01-01 09:00:00 -0500 (Monday)\n2024-01-03 09:00:00 -0500 (Wednesday)\n2024-01-08 09:00:00 -0500 (Monday)",
+		},
+		{
+			desc:      "iCalendar text with a zone and an exclusion",
+			arguments: map[string]any{"rrule": "DTSTART;TZID=Europe/Paris:20240105T090000\nRRULE:FREQ=MONTHLY;BYDAY=1FR\nEXDATE;TZID=Europe/Paris:20240202T090000", "count": 2},
+			want:      "Every month on the 1st Friday of the month at 09:00, starting 2024-01-05 09:00:00 +0100 in Europe/Paris.\n2 occurrences:\n2024-01-05 09:00:00 +0100 (Friday)\n2024-03-01 09:00:00 +0100 (Friday)\n...",
+		},
+		{
+			desc:      "Starts now by default",
+			arguments: map[string]any{"rrule": "FREQ=DAILY", "count": 2},
+			want:      "Every day at 12:30, starting 2023-10-01 12:30:00 +0000 in UTC.\n2 occurrences:\n2023-10-01 12:30:00 +0000 (Sunday)\n2023-10-02 12:30:00 +0000 (Monday)\n...",
+		},
+		{
+			desc:      "Window with extra and excluded dates",
+			arguments: map[string]any{"rrule": "FREQ=DAILY", "dtstart": "2024-01-01T09:00", "after": "2024-01-05", "before": "2024-01-08T12:00", "exdates": []any{"2024-01-06"}, "rdates": []any{"2024-01-07T15:00"}},
+			want:      "Every day at 09:00, starting 2024-01-01 09:00:00 +0000 in UTC.\n4 occurrences:\n2024-01-05 09:00:00 +0000 (Friday)\n2024-01-07 09:00:00 +0000 (Sunday)\n2024-01-07 15:00:00 +0000 (Sunday)\n2024-01-08 09:00:00 +0000 (Monday)",
+		},
+		{
+			desc:      "No occurrences in the window",
+			arguments: map[string]any{"rrule": "FREQ=YEARLY;COUNT=1", "dtstart": "2024-01-01", "after": "2025-01-01"},
+			want:      "Every year in January on the 1st day of the month at 00:00, once, starting 2024-01-01 00:00:00 +0000 in UTC.\nNo occurrences.",
+		},
+		{
+			desc:      "Missing rule",
+			arguments: map[string]any{},
+			wantErr:   true,
+		},
+		{
+			desc:      "Invalid rule",
+			arguments: map[string]any{"rrule": "FREQ=FORTNIGHTLY"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Invalid count",
+			arguments: map[string]any{"rrule": "FREQ=DAILY", "count": 501},
+			wantErr:   true,
+		},
+		{
+			desc:      "Unknown time zone",
+			arguments: map[string]any{"rrule": "FREQ=DAILY", "timeZone": "Mars/Olympus_Mons"},
+			wantErr:   true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Server{
+				TimeManager: &mockTmanager{},
+			}
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.arguments,
+				},
+			}
+			got, _ := s.ExpandRecurrence(ctx, req)
+			if tc.wantErr {
+				if got == nil || !got.IsError {
+					t.Errorf("ExpandRecurrence() expected error, got = %+v", got)
+				}
+				return
+			}
+			if got == nil || len(got.Content) == 0 {
+				t.Fatalf("ExpandRecurrence() got = nil or empty content")
+			}
+			gotTextContent, ok := got.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("ExpandRecurrence() got = %+v, want TextContent", got.Content[0])
+			}
+			if gotTextContent.Text != tc.want {
+				t.Errorf("ExpandRecurrence() got = %v, want %v", gotTextContent.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestCronRuns(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		tool      string
+		arguments map[string]any
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc:      "Next runs",
+			tool:      "nextCronRuns",
+			arguments: map[string]any{"expression": "0 9 * * MON-FRI", "count": 2},
+			want:      "\"0 9 * * MON-FRI\": At 09:00 on Monday through Friday.\nNext 2 runs from 2023-10-01 12:30:00 +0000 in UTC:\n2023-10-02 09:00:00 +0000 (Monday, in about 21 hours)\n2023-10-03 09:00:00 +0000 (Tuesday, in about 2 days)",
+		},
+		{
+			desc:      "Previous runs in another time zone",
+			tool:      "previousCronRuns",
+			arguments: map[string]any{"expression": "@hourly", "count": 2, "timeZone": "Asia/Kolkata"},
+			want:      "\"@hourly\": Every hour.\nPrevious 2 runs from 2023-10-01 18:00:00 +0530 in Asia/Kolkata:\n2023-10-01 17:00:00 +0530 (Sunday, about an hour ago)\n2023-10-01 16:00:00 +0530 (Sunday, about 2 hours ago)",
+		},
+		{
+			desc:      "No runs",
+			tool:      "nextCronRuns",
+			arguments: map[string]any{"expression": "0 0 0 1 1 ? 2020"},
+			want:      "\"0 0 0 1 1 ? 2020\": At 00:00 on day 1 of the month in January in 2020.\nNo next runs found from 2023-10-01 12:30:00 +0000.",
+		},
+		{
+			desc:      "Invalid expression",
+			tool:      "nextCronRuns",
+			arguments: map[string]any{"expression": "0 25 * * *"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Invalid count",
+			tool:      "previousCronRuns",
+			arguments: map[string]any{"expression": "@daily", "count": 101},
+			wantErr:   true,
+		},
+		{
+			desc:      "Unknown time zone",
+			tool:      "nextCronRuns",
+			arguments: map[string]any{"expression": "@daily", "timeZone": "Mars/Olympus_Mons"},
+			wantErr:   true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Server{
+				TimeManager: &mockTmanager{},
+			}
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.arguments,
+				},
+			}
+			handler := s.NextCronRuns
+			if tc.tool == "previousCronRuns" {
+				handler = s.PreviousCronRuns
+			}
+			got, _ := handler(ctx, req)
+			if tc.wantErr {
+				if got == nil || !got.IsError {
+					t.Errorf("%s() expected error, got = %+v", tc.tool, got)
+				}
+				return
+			}
+			if got == nil || len(got.Content) == 0 {
+				t.Fatalf("%s() got = nil or empty content", tc.tool)
+			}
+			gotTextContent, ok := got.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("%s() got = %+v, want TextContent", tc.tool, got.Content[0])
+			}
+			if gotTextContent.Text != tc.want {
+				t.Errorf("%s() got = %v, want %v", tc.tool, gotTextContent.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestExplainCron(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		arguments map[string]any
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc:      "Standard expression",
+			arguments: map[string]any{"expression": "*/15 9-17 * * MON-FRI"},
+			want:      "\"*/15 9-17 * * MON-FRI\" (standard cron): Every 15 minutes between 09:00 and 17:59 on Monday through Friday.",
+		},
+		{
+			desc:      "Six fields read as Quartz",
+			arguments: map[string]any{"expression": "0 0 1 * * 1", "dialect": "quartz"},
+			want:      "\"0 0 1 * * 1\" (quartz cron): At 01:00 on Sunday.",
+		},
+		{
+			desc:      "Invalid dialect",
+			arguments: map[string]any{"expression": "* * * * *", "dialect": "systemd"},
+			wantErr:   true,
+		},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := &Server{
+				TimeManager: &mockTmanager{},
+			}
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.arguments,
+				},
+			}
+			got, _ := s.ExplainCron(ctx, req)
+			if tc.wantErr {
+				if got == nil || !got.IsError {
+					t.Errorf("ExplainCron() expected error, got = %+v", got)
+				}
+				return
+			}
+			if got == nil || len(got.Content) == 0 {
+				t.Fatalf("ExplainCron() got = nil or empty content")
+			}
+			gotTextContent, ok := got.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("ExplainCron() got = %+v, want TextContent", got.Content[0])
+			}
+			if gotTextContent.Text != tc.want {
+				t.Errorf("ExplainCron() got = %v, want %v", gotTextContent.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestSessionTimeline(t *testing.T) {
+	started := time.Date(2023, time.October, 1, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		desc      string
+		calls     []SessionCall
+		arguments map[string]any
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc: "Several earlier calls",
+			calls: []SessionCall{
+				{Tool: "timeSince", Time: started.Add(5 * time.Minute)},
+				{Tool: "convertTimeZone", Time: started.Add(25 * time.Minute)},
+				{Tool: "currentDateTime", Time: started.Add(28 * time.Minute)},
+			},
+			arguments: map[string]any{},
+			want:      "Session started 2023-10-01 12:00:00 +0000, 30 minutes ago. 3 tool calls before this one; the last was currentDateTime, 2 minutes ago. Longest gap: 20 minutes, between timeSince at 2023-10-01 12:05:00 and convertTimeZone at 2023-10-01 12:25:00; average gap 12 minutes.\nRecent tool calls:\n2023-10-01 12:05:00 +0000 timeSince (5 minutes after the session started)\n2023-10-01 12:25:00 +0000 convertTimeZone (20 minutes after the previous call)\n2023-10-01 12:28:00 +0000 currentDateTime (3 minutes after the previous call)",
+		},
+		{
+			desc: "Limit and time zone",
+			calls: []SessionCall{
+				{Tool: "timeSince", Time: started.Add(5 * time.Minute)},
+				{Tool: "currentDateTime", Time: started.Add(28 * time.Minute)},
+			},
+			arguments: map[string]any{"limit": 1, "timeZone": "Asia/Kolkata"},
+			want:      "Session started 2023-10-01 17:30:00 +0530, 30 minutes ago. 2 tool calls before this one; the last was currentDateTime, 2 minutes ago. Longest gap: 23 minutes, between timeSince at 2023-10-01 17:35:00 and currentDateTime at 2023-10-01 17:58:00; average gap 23 minutes.\nRecent tool calls:\n2023-10-01 17:58:00 +0530 currentDateTime (23 minutes after the previous call)",
+		},
+		{
+			desc:      "No earlier calls",
+			arguments: map[string]any{},
+			want:      "Session started 2023-10-01 12:30:00 +0000, a few seconds ago. No earlier tool calls in this session.",
+		},
+		{
+			desc:      "Invalid limit",
+			arguments: map[string]any{"limit": 201},
+			wantErr:   true,
+		},
+		{
+			desc:      "Unknown time zone",
+			arguments: map[string]any{"timeZone": "Mars/Olympus_Mons"},
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := NewServer()
+			s.TimeManager = &mockTmanager{}
+			ctx := context.Background()
+			if tc.calls != nil {
+				session := newTestSession("session-1")
+				ctx = s.MCPServer.WithContext(ctx, session)
+				s.Sessions.Start(session.SessionID(), started)
+				for _, call := range tc.calls {
+					s.Sessions.Record(session.SessionID(), call.Tool, call.Time)
+				}
+			}
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Arguments: tc.arguments,
+				},
+			}
+			got, _ := s.SessionTimeline(ctx, req)
+			if tc.wantErr {
+				if got == nil || !got.IsError {
+					t.Errorf("SessionTimeline() expected error, got = %+v", got)
+				}
+				return
+			}
+			if got == nil || len(got.Content) == 0 {
+				t.Fatalf("SessionTimeline() got = nil or empty content")
+			}
+			gotTextContent, ok := got.Content[0].(mcp.TextContent)
+			if !ok {
+				t.Fatalf("SessionTimeline() got = %+v, want TextContent", got.Content[0])
+			}
+			if gotTextContent.Text != tc.want {
+				t.Errorf("SessionTimeline() got = %v, want %v", gotTextContent.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestTimerTools(t *testing.T) {
+	clock := newFakeClock(time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC))
+	s := NewServer()
+	s.TimeManager = clock
+	first := s.MCPServer.WithContext(context.Background(), newTestSession("first"))
+	second := s.MCPServer.WithContext(context.Background(), newTestSession("second"))
+
+	steps := []struct {
+		desc      string
+		ctx       context.Context
+		advance   time.Duration
+		handler   func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+		arguments map[string]any
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc:      "Start a session timer",
+			ctx:       first,
+			handler:   s.StartTimer,
+			arguments: map[string]any{"name": "build"},
+			want:      "Started timer \"build\" at 2024-01-01 09:00:00 +0000.",
+		},
+		{
+			desc:      "Start a global timer",
+			ctx:       first,
+			handler:   s.StartTimer,
+			arguments: map[string]any{"name": "release", "scope": "global", "timeZone": "Europe/Paris"},
+			want:      "Started timer \"release\" at 2024-01-01 10:00:00 +0100.",
+		},
+		{
+			desc:      "Cannot start a running timer",
+			ctx:       first,
+			handler:   s.StartTimer,
+			arguments: map[string]any{"name": "build"},
+			wantErr:   true,
+		},
+		{
+			desc:      "First lap",
+			ctx:       first,
+			advance:   90 * time.Second,
+			handler:   s.LapTimer,
+			arguments: map[string]any{"name": "build", "label": "compiled"},
+			want:      "Lap 1 (compiled) of timer \"build\" at 2024-01-01 09:01:30 +0000: 1 minute, 30 seconds since the start, 1 minute, 30 seconds in total.",
+		},
+		{
+			desc:      "Second lap",
+			ctx:       first,
+			advance:   2 * time.Minute,
+			handler:   s.LapTimer,
+			arguments: map[string]any{"name": "build"},
+			want:      "Lap 2 of timer \"build\" at 2024-01-01 09:03:30 +0000: 2 minutes since the previous lap, 3 minutes, 30 seconds in total.",
+		},
+		{
+			desc:      "Another session cannot see the session timer",
+			ctx:       second,
+			handler:   s.StopTimer,
+			arguments: map[string]any{"name": "build"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Another session can lap the global timer",
+			ctx:       second,
+			handler:   s.LapTimer,
+			arguments: map[string]any{"name": "release"},
+			want:      "Lap 1 of timer \"release\" at 2024-01-01 09:03:30 +0000: 3 minutes, 30 seconds since the start, 3 minutes, 30 seconds in total.",
+		},
+		{
+			desc:      "List timers",
+			ctx:       first,
+			advance:   time.Hour,
+			handler:   s.ListTimers,
+			arguments: map[string]any{},
+			want:      "2 timers at 2024-01-01 10:03:30 +0000:\nbuild (session): running for 1 hour, 3 minutes, 30 seconds, 2 laps; started 2024-01-01 09:00:00 +0000\nrelease (global): running for 1 hour, 3 minutes, 30 seconds, 1 lap; started 2024-01-01 09:00:00 +0000",
+		},
+		{
+			desc:      "Stop a timer",
+			ctx:       first,
+			handler:   s.StopTimer,
+			arguments: map[string]any{"name": "build"},
+			want:      "Stopped timer \"build\" at 2024-01-01 10:03:30 +0000 after 1 hour, 3 minutes, 30 seconds (2 laps).",
+		},
+		{
+			desc:      "Cannot lap a stopped timer",
+			ctx:       first,
+			handler:   s.LapTimer,
+			arguments: map[string]any{"name": "build"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Stop and remove a global timer",
+			ctx:       second,
+			handler:   s.StopTimer,
+			arguments: map[string]any{"name": "release", "scope": "global", "remove": true},
+			want:      "Stopped timer \"release\" at 2024-01-01 10:03:30 +0000 after 1 hour, 3 minutes, 30 seconds (1 lap).",
+		},
+		{
+			desc:      "List the other session's timers",
+			ctx:       second,
+			handler:   s.ListTimers,
+			arguments: map[string]any{},
+			want:      "No timers.",
+		},
+		{
+			desc:      "Missing name",
+			ctx:       first,
+			handler:   s.StartTimer,
+			arguments: map[string]any{},
+			wantErr:   true,
+		},
+		{
+			desc:      "Invalid scope",
+			ctx:       first,
+			handler:   s.ListTimers,
+			arguments: map[string]any{"scope": "team"},
+			wantErr:   true,
+		},
+	}
+
+	for _, step := range steps {
+		clock.Advance(step.advance)
+		req := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: step.arguments,
+			},
+		}
+		got, _ := step.handler(step.ctx, req)
+		if step.wantErr {
+			if got == nil || !got.IsError {
+				t.Errorf("%s: expected error, got = %+v", step.desc, got)
+			}
+			continue
+		}
+		if got == nil || len(got.Content) == 0 {
+			t.Fatalf("%s: got = nil or empty content", step.desc)
+		}
+		gotTextContent, ok := got.Content[0].(mcp.TextContent)
+		if !ok {
+			t.Fatalf("%s: got = %+v, want TextContent", step.desc, got.Content[0])
+		}
+		if gotTextContent.Text != step.want {
+			t.Errorf("%s: got = %v, want %v", step.desc, gotTextContent.Text, step.want)
+		}
+	}
+}
+
+func TestEvents(t *testing.T) {
+	clock := newFakeClock(time.Date(2025, time.September, 29, 21, 0, 0, 0, time.UTC))
+	s := NewServer()
+	s.TimeManager = clock
+
+	steps := []struct {
+		desc      string
+		advance   time.Duration
+		handler   func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+		arguments map[string]any
+		want      string
+		wantErr   bool
+	}{
+		{
+			desc:      "Add a deadline",
+			handler:   s.AddEvent,
+			arguments: map[string]any{"name": "Q3 report", "dateTime": "2025-09-30 17:00", "timeZone": "America/New_York", "outputStyle": "calendar"},
+			want:      "Added event \"Q3 report\": due 2025-09-30 17:00:00 -0400, in 1 day.",
+		},
+		{
+			desc:      "Add an event with a relative time",
+			handler:   s.AddEvent,
+			arguments: map[string]any{"name": "Retro", "dateTime": "tomorrow at 9am", "description": "Sprint 12 retrospective"},
+			want:      "Added event \"Retro\": due 2025-09-30 09:00:00 +0000, in 12h0m0s.",
+		},
+		{
+			desc:      "Replace an event",
+			handler:   s.AddEvent,
+			arguments: map[string]any{"name": "retro", "dateTime": "2025-10-02T09:00:00Z", "description": "Sprint 12 retrospective"},
+			want:      "Updated event \"retro\": due 2025-10-02 09:00:00 +0000, in 60h0m0s.",
+		},
+		{
+			desc:      "Get an event",
+			advance:   12 * time.Hour,
+			handler:   s.GetEvent,
+			arguments: map[string]any{"name": "Q3 REPORT", "outputStyle": "approximate"},
+			want:      "Event \"Q3 report\" is due 2025-09-30 17:00:00 -0400, in about 12 hours.",
+		},
+		{
+			desc:      "List events",
+			handler:   s.ListEvents,
+			arguments: map[string]any{"outputStyle": "calendar"},
+			want:      "2 events at 2025-09-30 09:00:00 +0000:\nQ3 report: due 2025-09-30 17:00:00 -0400, in 12 hours\nretro: due 2025-10-02 09:00:00 +0000, in 2 days",
+		},
+		{
+			desc:      "Due event",
+			advance:   12*time.Hour + 30*time.Second,
+			handler:   s.GetEvent,
+			arguments: map[string]any{"name": "Q3 report"},
+			want:      "Event \"Q3 report\" is due 2025-09-30 17:00:00 -0400, due now.",
+		},
+		{
+			desc:      "Overdue event",
+			advance:   2*time.Hour - 30*time.Second,
+			handler:   s.GetEvent,
+			arguments: map[string]any{"name": "Q3 report", "outputStyle": "calendar"},
+			want:      "Event \"Q3 report\" was due 2025-09-30 17:00:00 -0400, overdue by 2 hours.",
+		},
+		{
+			desc:      "Past events are hidden by default",
+			handler:   s.ListEvents,
+			arguments: map[string]any{},
+			want:      "1 event at 2025-09-30 23:00:00 +0000 (1 past event hidden; set includePast to list them):\nretro: due 2025-10-02 09:00:00 +0000, in 34h0m0s",
+		},
+		{
+			desc:      "List past events",
+			handler:   s.ListEvents,
+			arguments: map[string]any{"includePast": true},
+			want:      "2 events at 2025-09-30 23:00:00 +0000:\nQ3 report: due 2025-09-30 17:00:00 -0400, overdue by 2h0m0s\nretro: due 2025-10-02 09:00:00 +0000, in 34h0m0s",
+		},
+		{
+			desc:      "Remove an event",
+			handler:   s.RemoveEvent,
+			arguments: map[string]any{"name": "Q3 report"},
+			want:      "Removed event \"Q3 report\", which was due 2025-09-30 17:00:00 -0400.",
+		},
+		{
+			desc:      "Get a removed event",
+			handler:   s.GetEvent,
+			arguments: map[string]any{"name": "Q3 report"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Missing name",
+			handler:   s.AddEvent,
+			arguments: map[string]any{"dateTime": "2025-10-01"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Invalid date",
+			handler:   s.AddEvent,
+			arguments: map[string]any{"name": "Launch", "dateTime": "someday"},
+			wantErr:   true,
+		},
+		{
+			desc:      "Invalid time zone",
+			handler:   s.AddEvent,
+			arguments: map[string]any{"name": "Launch", "dateTime": "2025-10-01", "timeZone": "Mars/Olympus"},
+			wantErr:   true,
+		},
+	}
+
+	for _, step := range steps {
+		clock.Advance(step.advance)
+		req := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: step.arguments,
+			},
+		}
+		got, _ := step.handler(context.Background(), req)
+		if step.wantErr {
+			if got == nil || !got.IsError {
+				t.Errorf("%s: expected error, got = %+v", step.desc, got)
+			}
+			continue
+		}
+		if got == nil || len(got.Content) == 0 {
+			t.Fatalf("%s: got = nil or empty content", step.desc)
+		}
+		gotTextContent, ok := got.Content[0].(mcp.TextContent)
+		if !ok {
+			t.Fatalf("%s: got = %+v, want TextContent", step.desc, got.Content[0])
+		}
+		if gotTextContent.Text != step.want {
+			t.Errorf("%s: got = %v, want %v", step.desc, gotTextContent.Text, step.want)
+		}
+	}
+}
+
+func TestOutputSchemas(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		tool      string
+		arguments map[string]any
+		// want holds expected values for top-level fields of the structured
+		// content.
+		want map[string]any
+	}{
+		{
+			desc:      "currentDateTime",
+			tool:      "currentDateTime",
+			arguments: map[string]any{"timeZone": "America/New_York"},
+			want:      map[string]any{"dateTime": "2023-10-01T08:30:00-04:00", "epochSeconds": float64(1696163400), "isDST": true, "utcOffset": "-04:00"},
+		},
+		{
+			desc:      "timeSince",
+			tool:      "timeSince",
+			arguments: map[string]any{"dateTime": "2023-09-30 12:30:00", "outputStyle": "calendar"},
+		},
+		{
+			desc:      "timeSince now",
+			tool:      "timeSince",
+			arguments: map[string]any{"dateTime": "2023-10-01 12:30:00"},
+		},
+		{
+			desc:      "timeUntil in a DST gap",
+			tool:      "timeUntil",
+			arguments: map[string]any{"dateTime": "2024-03-10 02:30:00", "timeZone": "America/New_York"},
+		},
+		{
+			desc:      "timeDifference",
+			tool:      "timeDifference",
+			arguments: map[string]any{"firstDateTime": "2023-10-02 12:30:00", "secondDateTime": "2023-10-01 12:30:00"},
+			want:      map[string]any{"comparison": "later", "seconds": float64(-86400)},
+		},
+		{
+			desc:      "isLeapYear",
+			tool:      "isLeapYear",
+			arguments: map[string]any{"year": 2024},
+			want:      map[string]any{"isLeapYear": true, "daysInYear": float64(366)},
+		},
+		{
+			desc:      "dayOfWeek",
+			tool:      "dayOfWeek",
+			arguments: map[string]any{"dateTime": "2023-10-01"},
+			want:      map[string]any{"dayOfWeek": "Sunday", "isoWeekday": float64(7)},
+		},
+		{
+			desc:      "nextOccurrence",
+			tool:      "nextOccurrence",
+			arguments: map[string]any{"dateTime": "2023-10-01 12:30:00", "dayOfWeek": "Wednesday"},
+			want:      map[string]any{"days": float64(3)},
+		},
+		{
+			desc:      "previousOccurrence",
+			tool:      "previousOccurrence",
+			arguments: map[string]any{"dateTime": "2023-10-01 12:30:00", "dayOfWeek": "Wednesday"},
+			want:      map[string]any{"days": float64(-4)},
+		},
+		{
+			desc:      "addDuration",
+			tool:      "addDuration",
+			arguments: map[string]any{"dateTime": "2024-01-31 09:00:00", "duration": "1 month"},
+			want:      map[string]any{"duration": "P1M"},
+		},
+		{
+			desc:      "subtractDuration",
+			tool:      "subtractDuration",
+			arguments: map[string]any{"dateTime": "2024-01-31 09:00:00", "duration": "PT90M"},
+			want:      map[string]any{"duration": "-PT1H30M"},
+		},
+		{
+			desc:      "isWeekend",
+			tool:      "isWeekend",
+			arguments: map[string]any{"dateTime": "2023-10-01"},
+			want:      map[string]any{"isWeekend": true},
+		},
+		{
+			desc:      "isWeekday",
+			tool:      "isWeekday",
+			arguments: map[string]any{"dateTime": "2023-10-01"},
+			want:      map[string]any{"isWeekday": false},
+		},
+		{
+			desc:      "daysBetween",
+			tool:      "daysBetween",
+			arguments: map[string]any{"firstDateTime": "2023-10-01", "secondDateTime": "2023-10-11"},
+			want:      map[string]any{"days": float64(10)},
+		},
+		{
+			desc:      "resolveDateExpression",
+			tool:      "resolveDateExpression",
+			arguments: map[string]any{"expression": "tomorrow at noon"},
+			want:      map[string]any{"localTime": "normal"},
+		},
+		{
+			desc:      "resolveDateExpression in a DST fold",
+			tool:      "resolveDateExpression",
+			arguments: map[string]any{"expression": "2025-11-02 01:30:00", "timeZone": "America/New_York"},
+			want:      map[string]any{"localTime": "fold"},
+		},
+		{
+			desc:      "convertTimeZone",
+			tool:      "convertTimeZone",
+			arguments: map[string]any{"dateTime": "2024-07-01 09:00:00", "timeZone": "Europe/London", "targetTimeZones": []any{"Asia/Kolkata", "America/Los_Angeles"}},
+		},
+		{
+			desc:      "findTimeZone",
+			tool:      "findTimeZone",
+			arguments: map[string]any{"query": "Tokyo"},
+			want:      map[string]any{"query": "Tokyo", "resolved": "Asia/Tokyo"},
+		},
+		{
+			desc:      "timeZoneDatabaseInfo",
+			tool:      "timeZoneDatabaseInfo",
+			arguments: map[string]any{},
+			want:      map[string]any{"source": "embedded", "version": EmbeddedTimeZoneDatabase().Version},
+		},
+		{
+			desc:      "dateInfo",
+			tool:      "dateInfo",
+			arguments: map[string]any{"dateTime": "2027-01-01"},
+			want:      map[string]any{"isoWeekYear": float64(2026), "isoWeek": float64(53), "quarter": float64(1)},
+		},
+		{
+			desc:      "monthCalendar",
+			tool:      "monthCalendar",
+			arguments: map[string]any{"year": 2025, "month": 2, "weekStart": "Sunday"},
+			want:      map[string]any{"daysInMonth": float64(28), "weekStart": "Sunday"},
+		},
+		{
+			desc:      "fiscalDate",
+			tool:      "fiscalDate",
+			arguments: map[string]any{"fiscalCalendar": "US", "dateTime": "2024-09-30"},
+			want:      map[string]any{"fiscalYear": float64(2024), "label": "FY2024", "quarter": float64(4), "period": float64(12)},
+		},
+		{
+			desc:      "fiscalPeriods",
+			tool:      "fiscalPeriods",
+			arguments: map[string]any{"fiscalCalendar": "NRF", "fiscalYear": 2023},
+			want:      map[string]any{"first": "2023-01-29", "last": "2024-02-03", "weeks": float64(53)},
+		},
+		{
+			desc:      "convertCalendar",
+			tool:      "convertCalendar",
+			arguments: map[string]any{"dateTime": "2025-10-01", "toCalendar": "hebrew"},
+			want:      map[string]any{"date": "2025-10-01", "julianDayNumber": float64(2460950)},
+		},
+		{
+			desc:      "sunTimes",
+			tool:      "sunTimes",
+			arguments: map[string]any{"latitude": 39.7392, "longitude": -104.9903, "dateTime": "2025-06-03", "timeZone": "America/Denver"},
+			want:      map[string]any{"date": "2025-06-03", "timeZone": "America/Denver", "daylight": "normal"},
+		},
+		{
+			desc:      "solarPosition",
+			tool:      "solarPosition",
+			arguments: map[string]any{"latitude": 0, "longitude": 0, "dateTime": "2025-03-20T12:07:00Z"},
+			want:      map[string]any{"phase": "day"},
+		},
+		{
+			desc:      "dstTransitions",
+			tool:      "dstTransitions",
+			arguments: map[string]any{"timeZone": "America/New_York", "year": 2025},
+			want:      map[string]any{"timeZone": "America/New_York", "truncated": false},
+		},
+		{
+			desc:      "dstTransitions without changes",
+			tool:      "dstTransitions",
+			arguments: map[string]any{"timeZone": "Asia/Tokyo", "year": 2025},
+		},
+		{
+			desc:      "nextTransition",
+			tool:      "nextTransition",
+			arguments: map[string]any{"timeZone": "Europe/London"},
+		},
+		{
+			desc:      "nextTransition without changes",
+			tool:      "nextTransition",
+			arguments: map[string]any{"timeZone": "UTC"},
+		},
+		{
+			desc:      "isBusinessDay on a holiday",
+			tool:      "isBusinessDay",
+			arguments: map[string]any{"dateTime": "2024-12-25", "holidayCalendar": "US"},
+			want:      map[string]any{"isBusinessDay": false, "isWeekend": false, "calendar": "US"},
+		},
+		{
+			desc:      "isBusinessDay without a calendar",
+			tool:      "isBusinessDay",
+			arguments: map[string]any{"dateTime": "2024-12-24"},
+			want:      map[string]any{"isBusinessDay": true},
+		},
+		{
+			desc:      "addBusinessDays",
+			tool:      "addBusinessDays",
+			arguments: map[string]any{"dateTime": "2024-12-20 09:00:00", "days": 5, "holidayCalendar": "US"},
+			want:      map[string]any{"businessDays": float64(5)},
+		},
+		{
+			desc:      "businessDaysBetween",
+			tool:      "businessDaysBetween",
+			arguments: map[string]any{"firstDate": "2024-12-20", "secondDate": "2025-01-02", "holidayCalendar": "US"},
+			want:      map[string]any{"businessDays": float64(7), "calendarDays": float64(13)},
+		},
+		{
+			desc:      "listHolidays",
+			tool:      "listHolidays",
+			arguments: map[string]any{"holidayCalendar": "UK", "year": 2021},
+			want:      map[string]any{"calendar": "UK", "year": float64(2021)},
+		},
+		{
+			desc:      "nextHoliday",
+			tool:      "nextHoliday",
+			arguments: map[string]any{"holidayCalendar": "US", "count": 2},
+			want:      map[string]any{"today": "2023-10-01"},
+		},
+		{
+			desc:      "expandRecurrence",
+			tool:      "expandRecurrence",
+			arguments: map[string]any{"rrule": "FREQ=MONTHLY;BYDAY=-1FR;COUNT=2", "dtstart": "2024-01-01T09:00:00"},
+			want:      map[string]any{"rule": "FREQ=MONTHLY;COUNT=2;BYDAY=-1FR", "hasMore": false},
+		},
+		{
+			desc:      "nextCronRuns",
+			tool:      "nextCronRuns",
+			arguments: map[string]any{"expression": "0 9 * * MON-FRI"},
+			want:      map[string]any{"dialect": "standard", "description": "At 09:00 on Monday through Friday"},
+		},
+		{
+			desc:      "previousCronRuns",
+			tool:      "previousCronRuns",
+			arguments: map[string]any{"expression": "0 0 12 ? * 6L"},
+			want:      map[string]any{"dialect": "quartz"},
+		},
+		{
+			desc:      "explainCron",
+			tool:      "explainCron",
+			arguments: map[string]any{"expression": "@daily"},
+			want:      map[string]any{"description": "At 00:00"},
+		},
+		{
+			desc:      "sessionTimeline",
+			tool:      "sessionTimeline",
+			arguments: map[string]any{},
+			want:      map[string]any{"toolCalls": float64(0)},
+		},
+		{
+			desc:      "startTimer",
+			tool:      "startTimer",
+			arguments: map[string]any{"name": "build"},
+			want:      map[string]any{"name": "build", "running": true},
+		},
+		{
+			desc:      "lapTimer",
+			tool:      "lapTimer",
+			arguments: map[string]any{"name": "build", "label": "compiled"},
+			want:      map[string]any{"scope": "session"},
+		},
+		{
+			desc:      "stopTimer",
+			tool:      "stopTimer",
+			arguments: map[string]any{"name": "build"},
+			want:      map[string]any{"running": false},
+		},
+		{
+			desc:      "listTimers",
+			tool:      "listTimers",
+			arguments: map[string]any{},
+		},
+		{
+			desc:      "addEvent",
+			tool:      "addEvent",
+			arguments: map[string]any{"name": "Q4 report", "dateTime": "2023-12-31 17:00", "timeZone": "America/New_York"},
+			want:      map[string]any{"name": "Q4 report", "status": "upcoming"},
+		},
+		{
+			desc:      "getEvent",
+			tool:      "getEvent",
+			arguments: map[string]any{"name": "Q4 report"},
+			want:      map[string]any{"name": "Q4 report"},
+		},
+		{
+			desc:      "listEvents",
+			tool:      "listEvents",
+			arguments: map[string]any{},
+			want:      map[string]any{"hidden": float64(0)},
+		},
+		{
+			desc:      "removeEvent",
+			tool:      "removeEvent",
+			arguments: map[string]any{"name": "Q4 report"},
+			want:      map[string]any{"name": "Q4 report"},
+		},
+	}
+
+	s := NewServer()
+	s.TimeManager = &mockTmanager{}
+
+	covered := map[string]bool{}
+	ctx := context.Background()
+	for _, tc := range testCases {
+		covered[tc.tool] = true
+		t.Run(tc.desc, func(t *testing.T) {
+			tool := s.GetTool(tc.tool)
+			if tool == nil {
+				t.Fatalf("tool %q is not registered", tc.tool)
+			}
+			schema := resolveOutputSchema(t, tool.Tool)
+			// Arguments must be ones the tool declares, or a client reading
+			// the input schema could never send them.
+			for name := range tc.arguments {
+				if _, ok := tool.Tool.InputSchema.Properties[name]; !ok {
+					t.Errorf("%s() argument %q is not in the input schema", tc.tool, name)
+				}
+			}
+
+			req := mcp.CallToolRequest{
+				Params: mcp.CallToolParams{
+					Name:      tc.tool,
+					Arguments: tc.arguments,
+				},
+			}
+			got, err := tool.Handler(ctx, req)
+			if err != nil {
+				t.Fatalf("%s() error = %v", tc.tool, err)
+			}
+			if got == nil || got.IsError {
+				t.Fatalf("%s() got = %+v, want a successful result", tc.tool, got)
+			}
+			if _, ok := got.Content[0].(mcp.TextContent); !ok {
+				t.Errorf("%s() got = %+v, want TextContent", tc.tool, got.Content[0])
+			}
+
+			// Round trip through JSON as a client would see it.
+			raw, err := json.Marshal(got.StructuredContent)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			var structured map[string]any
+			if err := json.Unmarshal(raw, &structured); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if err := schema.Validate(structured); err != nil {
+				t.Errorf("%s() structured content %s does not match output schema: %v", tc.tool, raw, err)
+			}
+			for key, want := range tc.want {
+				if structured[key] != want {
+					t.Errorf("%s() %s = %v, want %v", tc.tool, key, structured[key], want)
+				}
+			}
+		})
+	}
+
+	for name := range s.ListTools() {
+		if !covered[name] {
+			t.Errorf("tool %q has no output schema test case", name)
+		}
+	}
+}
+
+// resolveOutputSchema returns the validator for a tool's declared output
+// schema.
+func resolveOutputSchema(t *testing.T, tool mcp.Tool) *jsonschema.Resolved {
+	t.Helper()
+	if len(tool.OutputSchema.Properties) == 0 {
+		t.Fatalf("tool %q declares no output schema", tool.Name)
+	}
+	raw, err := json.Marshal(tool.OutputSchema)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	resolved, err := schema.Resolve(nil)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	return resolved
+}
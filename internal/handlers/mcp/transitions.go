@@ -0,0 +1,120 @@
+package mcp
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxTransitionYears bounds the window dstTransitions searches and how far
+// ahead nextTransition looks.
+const maxTransitionYears = 100
+
+// ZoneTransition is a change of a zone's UTC offset, abbreviation or
+// daylight saving time flag.
+type ZoneTransition struct {
+	// At is the instant the change takes effect, in the zone.
+	At               time.Time
+	FromAbbreviation string
+	FromOffset       int
+	FromDST          bool
+	ToAbbreviation   string
+	ToOffset         int
+	ToDST            bool
+}
+
+// newZoneTransition describes the change between the zones in effect just
+// before at and at at.
+func newZoneTransition(at time.Time) ZoneTransition {
+	before := at.Add(-time.Nanosecond)
+	tr := ZoneTransition{At: at, FromDST: before.IsDST(), ToDST: at.IsDST()}
+	tr.FromAbbreviation, tr.FromOffset = before.Zone()
+	tr.ToAbbreviation, tr.ToOffset = at.Zone()
+	return tr
+}
+
+// changed reports whether anything a client can see changes at tr.  The
+// zone data may split a period of the same rules in two.
+func (tr ZoneTransition) changed() bool {
+	return tr.FromOffset != tr.ToOffset || tr.FromAbbreviation != tr.ToAbbreviation || tr.FromDST != tr.ToDST
+}
+
+// Direction is "forward" when clocks go forward at tr, "back" when they go
+// back and "unchanged" when only the abbreviation or DST flag changes.
+func (tr ZoneTransition) Direction() string {
+	switch {
+	case tr.ToOffset > tr.FromOffset:
+		return "forward"
+	case tr.ToOffset < tr.FromOffset:
+		return "back"
+	default:
+		return "unchanged"
+	}
+}
+
+// LocalBefore is the wall-clock reading at tr under the old offset, e.g.
+// 02:00 when clocks go forward to 03:00.
+func (tr ZoneTransition) LocalBefore() time.Time {
+	return tr.At.In(time.FixedZone(tr.FromAbbreviation, tr.FromOffset))
+}
+
+// zoneTransitions returns the changes in loc at or after from and before
+// to, at most limit of them.  It reports whether there were more.
+func zoneTransitions(loc *time.Location, from, to time.Time, limit int) ([]ZoneTransition, bool) {
+	var transitions []ZoneTransition
+	t := from.In(loc)
+	if start, _ := t.ZoneBounds(); start.Equal(t) {
+		// from is itself a transition.
+		t = t.Add(-time.Nanosecond)
+	}
+	for {
+		_, end := t.ZoneBounds()
+		if end.IsZero() || !end.Before(to) || !end.After(t) {
+			return transitions, false
+		}
+		if tr := newZoneTransition(end); tr.changed() {
+			if len(transitions) == limit {
+				return transitions, true
+			}
+			transitions = append(transitions, tr)
+		}
+		t = end
+	}
+}
+
+// nextZoneTransition returns the first change in loc after t, looking up
+// to maxTransitionYears ahead.
+func nextZoneTransition(loc *time.Location, t time.Time) (ZoneTransition, bool) {
+	transitions, _ := zoneTransitions(loc, t.Add(time.Nanosecond), t.AddDate(maxTransitionYears, 0, 0), 1)
+	if len(transitions) == 0 {
+		return ZoneTransition{}, false
+	}
+	return transitions[0], true
+}
+
+// previousZoneTransition returns the last change in loc at or before t.
+func previousZoneTransition(loc *time.Location, t time.Time) (ZoneTransition, bool) {
+	t = t.In(loc)
+	for {
+		start, _ := t.ZoneBounds()
+		if start.IsZero() {
+			return ZoneTransition{}, false
+		}
+		if tr := newZoneTransition(start); tr.changed() {
+			return tr, true
+		}
+		t = start.Add(-time.Nanosecond)
+	}
+}
+
+// describeZoneTransition says what happens to the clocks at tr, e.g.
+// "Sunday 2025-03-09: at 02:00:00 EST clocks go forward 1 hour to 03:00:00
+// EDT (UTC-05:00 to UTC-04:00)".
+func describeZoneTransition(tr ZoneTransition) string {
+	before := tr.LocalBefore()
+	day := fmt.Sprintf("%s %s", before.Weekday(), before.Format(dateFormat))
+	offsets := fmt.Sprintf("(%s to %s)", formatUTCOffset(tr.FromOffset), formatUTCOffset(tr.ToOffset))
+	if tr.Direction() == "unchanged" {
+		return fmt.Sprintf("%s: at %s %s the zone changes to %s without moving the clocks (%s)", day, before.Format("15:04:05"), tr.FromAbbreviation, tr.ToAbbreviation, formatUTCOffset(tr.ToOffset))
+	}
+	return fmt.Sprintf("%s: at %s %s clocks go %s %s to %s %s %s", day, before.Format("15:04:05"), tr.FromAbbreviation, tr.Direction(), offsetAmount(tr.ToOffset-tr.FromOffset), tr.At.Format("15:04:05"), tr.ToAbbreviation, offsets)
+}
@@ -0,0 +1,71 @@
+package mcp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestZoneTransitions(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	springForward := time.Date(2025, time.March, 9, 7, 0, 0, 0, time.UTC)
+	fallBack := time.Date(2025, time.November, 2, 6, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc          string
+		from, to      time.Time
+		limit         int
+		want          []time.Time
+		wantTruncated bool
+	}{
+		{desc: "Year", from: time.Date(2025, time.January, 1, 0, 0, 0, 0, newYork), to: time.Date(2026, time.January, 1, 0, 0, 0, 0, newYork), limit: 10, want: []time.Time{springForward, fallBack}},
+		{desc: "Window starting at a change", from: springForward, to: fallBack, limit: 10, want: []time.Time{springForward}},
+		{desc: "Window ending at a change", from: springForward.Add(time.Nanosecond), to: fallBack, limit: 10},
+		{desc: "Limited", from: time.Date(2025, time.January, 1, 0, 0, 0, 0, newYork), to: time.Date(2026, time.January, 1, 0, 0, 0, 0, newYork), limit: 1, want: []time.Time{springForward}, wantTruncated: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, truncated := zoneTransitions(newYork, tc.from, tc.to, tc.limit)
+			if len(got) != len(tc.want) || truncated != tc.wantTruncated {
+				t.Fatalf("zoneTransitions() = %d changes, truncated %t, want %d, %t", len(got), truncated, len(tc.want), tc.wantTruncated)
+			}
+			for i, tr := range got {
+				if !tr.At.Equal(tc.want[i]) {
+					t.Errorf("change %d at %s, want %s", i, tr.At.UTC(), tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestZoneTransitionDirection(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, ok := nextZoneTransition(london, time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC))
+	if !ok {
+		t.Fatal("nextZoneTransition() found no change")
+	}
+	if tr.Direction() != "back" || tr.FromAbbreviation != "BST" || tr.ToAbbreviation != "GMT" || !tr.FromDST || tr.ToDST {
+		t.Errorf("change = %+v, want BST back to GMT", tr)
+	}
+	if got := tr.LocalBefore().Format("15:04"); got != "02:00" {
+		t.Errorf("LocalBefore() = %s, want 02:00", got)
+	}
+
+	previous, ok := previousZoneTransition(london, tr.At)
+	if !ok || !previous.At.Equal(tr.At) {
+		t.Errorf("previousZoneTransition() at a change = %+v, %t, want that change", previous, ok)
+	}
+	previous, ok = previousZoneTransition(london, tr.At.Add(-time.Nanosecond))
+	if !ok || previous.Direction() != "forward" {
+		t.Errorf("previousZoneTransition() = %+v, %t, want the spring change", previous, ok)
+	}
+
+	if _, ok := nextZoneTransition(time.UTC, time.Now()); ok {
+		t.Error("nextZoneTransition(UTC) found a change")
+	}
+}
@@ -0,0 +1,512 @@
+package mcp
+
+import (
+	_ "embed"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed data/cities.tsv
+var citiesTSV string
+
+//go:embed data/countries.tsv
+var countriesTSV string
+
+// TimeZoneMatch is what a time zone query matched.
+type TimeZoneMatch string
+
+const (
+	MatchTimeZone     TimeZoneMatch = "timeZone"
+	MatchAlias        TimeZoneMatch = "alias"
+	MatchOffset       TimeZoneMatch = "offset"
+	MatchAbbreviation TimeZoneMatch = "abbreviation"
+	MatchCity         TimeZoneMatch = "city"
+	MatchCountry      TimeZoneMatch = "country"
+	MatchSimilar      TimeZoneMatch = "similar"
+)
+
+// Scores rank the candidates for a query.  A query resolves to its best
+// candidate if that scores at least minResolvedScore and no other candidate
+// scores as much; weaker matches are only suggestions.
+const (
+	scoreTimeZone     = 100
+	scoreAlias        = 95
+	scoreOffset       = 90
+	scoreAbbreviation = 85
+	scoreMinorMeaning = 80
+	scoreCity         = 80
+	scoreMinorCity    = 75
+	scoreCountry      = 70
+	scoreMinorCountry = 65
+	scoreSimilar      = 50
+	minResolvedScore  = scoreCountry
+)
+
+// maxAmbiguousMeanings bounds the meanings an AmbiguousTimeZoneError lists.
+const maxAmbiguousMeanings = 5
+
+// dominantPopulation is how many times larger than any other a city sharing
+// its name must be for the name to mean it.
+const dominantPopulation = 5
+
+// TimeZoneCandidate is a time zone that a query may refer to.
+type TimeZoneCandidate struct {
+	Location *time.Location
+	Match    TimeZoneMatch
+	// Description names what matched, e.g. "Tokyo, Japan" or "India
+	// Standard Time".
+	Description string
+	Score       int
+}
+
+// timeZoneAbbreviation is one meaning of a time zone abbreviation.
+type timeZoneAbbreviation struct {
+	zone string
+	name string
+	// minor marks a meaning that is rare enough beside the first not to
+	// make the abbreviation ambiguous.
+	minor bool
+}
+
+// timeZoneAbbreviations maps common abbreviations to the zones they are
+// used for, most common meaning first.  Abbreviations with more than one
+// meaning that is not minor are ambiguous.  Abbreviations that are zones in
+// tzdata themselves, such as EST and CET, load as those zones before this
+// table is consulted, but their names, such as "Eastern Standard Time", are
+// looked up here.
+var timeZoneAbbreviations = map[string][]timeZoneAbbreviation{
+	"ACDT": {{zone: "Australia/Adelaide", name: "Australian Central Daylight Time"}},
+	"ACST": {{zone: "Australia/Adelaide", name: "Australian Central Standard Time"}},
+	"ADT":  {{zone: "America/Halifax", name: "Atlantic Daylight Time"}},
+	"AEDT": {{zone: "Australia/Sydney", name: "Australian Eastern Daylight Time"}},
+	"AEST": {{zone: "Australia/Sydney", name: "Australian Eastern Standard Time"}},
+	"AKDT": {{zone: "America/Anchorage", name: "Alaska Daylight Time"}},
+	"AKST": {{zone: "America/Anchorage", name: "Alaska Standard Time"}},
+	"ART":  {{zone: "America/Argentina/Buenos_Aires", name: "Argentina Time"}},
+	"AST":  {{zone: "America/Halifax", name: "Atlantic Standard Time"}, {zone: "Asia/Riyadh", name: "Arabia Standard Time"}},
+	"AWST": {{zone: "Australia/Perth", name: "Australian Western Standard Time"}},
+	"BRT":  {{zone: "America/Sao_Paulo", name: "Brasília Time"}},
+	"BST":  {{zone: "Europe/London", name: "British Summer Time"}, {zone: "Asia/Dhaka", name: "Bangladesh Standard Time", minor: true}},
+	"CAT":  {{zone: "Africa/Maputo", name: "Central Africa Time"}},
+	"CDT":  {{zone: "America/Chicago", name: "Central Daylight Time"}, {zone: "America/Havana", name: "Cuba Daylight Time", minor: true}},
+	"CEST": {{zone: "Europe/Paris", name: "Central European Summer Time"}},
+	"CET":  {{zone: "Europe/Paris", name: "Central European Time"}},
+	"CLT":  {{zone: "America/Santiago", name: "Chile Standard Time"}},
+	"CST":  {{zone: "America/Chicago", name: "Central Standard Time"}, {zone: "Asia/Shanghai", name: "China Standard Time"}, {zone: "America/Havana", name: "Cuba Standard Time", minor: true}},
+	"CT":   {{zone: "America/Chicago", name: "Central Time"}},
+	"EAT":  {{zone: "Africa/Nairobi", name: "East Africa Time"}},
+	"EDT":  {{zone: "America/New_York", name: "Eastern Daylight Time"}},
+	"EEST": {{zone: "Europe/Athens", name: "Eastern European Summer Time"}},
+	"EET":  {{zone: "Europe/Athens", name: "Eastern European Time"}},
+	"EST":  {{zone: "America/New_York", name: "Eastern Standard Time"}},
+	"ET":   {{zone: "America/New_York", name: "Eastern Time"}},
+	"GMT":  {{zone: "GMT", name: "Greenwich Mean Time"}},
+	"GST":  {{zone: "Asia/Dubai", name: "Gulf Standard Time"}, {zone: "Atlantic/South_Georgia", name: "South Georgia Time", minor: true}},
+	"HKT":  {{zone: "Asia/Hong_Kong", name: "Hong Kong Time"}},
+	"HST":  {{zone: "Pacific/Honolulu", name: "Hawaii Standard Time"}},
+	"HT":   {{zone: "Pacific/Honolulu", name: "Hawaii Time"}},
+	"ICT":  {{zone: "Asia/Bangkok", name: "Indochina Time"}},
+	"IDT":  {{zone: "Asia/Jerusalem", name: "Israel Daylight Time"}},
+	"IST":  {{zone: "Asia/Kolkata", name: "India Standard Time"}, {zone: "Asia/Jerusalem", name: "Israel Standard Time"}, {zone: "Europe/Dublin", name: "Irish Standard Time"}},
+	"JST":  {{zone: "Asia/Tokyo", name: "Japan Standard Time"}},
+	"KST":  {{zone: "Asia/Seoul", name: "Korea Standard Time"}},
+	"MDT":  {{zone: "America/Denver", name: "Mountain Daylight Time"}},
+	"MSK":  {{zone: "Europe/Moscow", name: "Moscow Time"}},
+	"MST":  {{zone: "America/Denver", name: "Mountain Standard Time"}},
+	"MT":   {{zone: "America/Denver", name: "Mountain Time"}},
+	"NDT":  {{zone: "America/St_Johns", name: "Newfoundland Daylight Time"}},
+	"NPT":  {{zone: "Asia/Kathmandu", name: "Nepal Time"}},
+	"NST":  {{zone: "America/St_Johns", name: "Newfoundland Standard Time"}},
+	"NZDT": {{zone: "Pacific/Auckland", name: "New Zealand Daylight Time"}},
+	"NZST": {{zone: "Pacific/Auckland", name: "New Zealand Standard Time"}},
+	"PDT":  {{zone: "America/Los_Angeles", name: "Pacific Daylight Time"}},
+	"PHT":  {{zone: "Asia/Manila", name: "Philippine Time"}},
+	"PKT":  {{zone: "Asia/Karachi", name: "Pakistan Standard Time"}},
+	"PST":  {{zone: "America/Los_Angeles", name: "Pacific Standard Time"}, {zone: "Asia/Manila", name: "Philippine Standard Time", minor: true}},
+	"PT":   {{zone: "America/Los_Angeles", name: "Pacific Time"}},
+	"SAST": {{zone: "Africa/Johannesburg", name: "South Africa Standard Time"}},
+	"SGT":  {{zone: "Asia/Singapore", name: "Singapore Time"}},
+	"UTC":  {{zone: "UTC", name: "Coordinated Universal Time"}},
+	"WAT":  {{zone: "Africa/Lagos", name: "West Africa Time"}},
+	"WEST": {{zone: "Europe/Lisbon", name: "Western European Summer Time"}},
+	"WET":  {{zone: "Europe/Lisbon", name: "Western European Time"}},
+	"WIB":  {{zone: "Asia/Jakarta", name: "Western Indonesia Time"}},
+	"Z":    {{zone: "UTC", name: "Zulu Time"}},
+}
+
+// countryAliases maps everyday country names that differ from those in
+// tzdata's iso3166.tab to country codes.
+var countryAliases = map[string]string{
+	"burma":                    "MM",
+	"czechia":                  "CZ",
+	"england":                  "GB",
+	"great britain":            "GB",
+	"holland":                  "NL",
+	"ivory coast":              "CI",
+	"north korea":              "KP",
+	"northern ireland":         "GB",
+	"scotland":                 "GB",
+	"south korea":              "KR",
+	"swaziland":                "SZ",
+	"turkiye":                  "TR",
+	"uae":                      "AE",
+	"uk":                       "GB",
+	"united kingdom":           "GB",
+	"united states of america": "US",
+	"usa":                      "US",
+	"wales":                    "GB",
+}
+
+// city is a place in the city dataset.
+type city struct {
+	name, country, zone string
+	population          int
+}
+
+// country is a country and the zones it uses, most populous first.
+type country struct {
+	code, name string
+	zones      []countryZone
+}
+
+// countryZone is a zone used in a country and the region it covers there.
+type countryZone struct {
+	zone, region string
+}
+
+// timeZoneResolver maps the ways people name time zones to IANA zones.
+type timeZoneResolver struct {
+	// cities and countries are keyed by placeKey; countries also by
+	// lower-case code.
+	cities    map[string][]city
+	countries map[string]*country
+	// abbreviations are keyed by upper-case abbreviation and by placeKey of
+	// their names.
+	abbreviations map[string][]timeZoneAbbreviation
+}
+
+// defaultTimeZoneResolver returns the resolver built from the embedded
+// datasets.
+var defaultTimeZoneResolver = sync.OnceValue(func() *timeZoneResolver {
+	return newTimeZoneResolver(citiesTSV, countriesTSV, timeZoneAbbreviations)
+})
+
+func newTimeZoneResolver(citiesData, countriesData string, abbreviations map[string][]timeZoneAbbreviation) *timeZoneResolver {
+	r := &timeZoneResolver{
+		cities:        map[string][]city{},
+		countries:     map[string]*country{},
+		abbreviations: map[string][]timeZoneAbbreviation{},
+	}
+	seen := map[[2]string]bool{}
+	addCity := func(c city) {
+		key := placeKey(c.name)
+		if !seen[[2]string{key, c.zone}] {
+			seen[[2]string{key, c.zone}] = true
+			r.cities[key] = append(r.cities[key], c)
+		}
+	}
+	for _, fields := range tsvRecords(citiesData, 4) {
+		population, _ := strconv.Atoi(fields[3])
+		addCity(city{name: fields[0], country: fields[1], zone: fields[2], population: population})
+	}
+	for _, fields := range tsvRecords(countriesData, 4) {
+		code, zone := fields[0], fields[2]
+		c, ok := r.countries[strings.ToLower(code)]
+		if !ok {
+			c = &country{code: code, name: fields[1]}
+			r.countries[strings.ToLower(code)] = c
+			r.countries[placeKey(fields[1])] = c
+			// "Myanmar (Burma)" is also "Myanmar".
+			if i := strings.Index(fields[1], " ("); i > 0 {
+				r.countries[placeKey(fields[1][:i])] = c
+			}
+		}
+		c.zones = append(c.zones, countryZone{zone: zone, region: fields[3]})
+		// The zone's own city, such as "Ulaanbaatar" for Asia/Ulaanbaatar.
+		addCity(city{name: strings.ReplaceAll(zone[strings.LastIndex(zone, "/")+1:], "_", " "), country: code, zone: zone})
+	}
+	for name, code := range countryAliases {
+		if c, ok := r.countries[strings.ToLower(code)]; ok {
+			r.countries[name] = c
+		}
+	}
+	for abbreviation, meanings := range abbreviations {
+		r.abbreviations[abbreviation] = meanings
+		for _, m := range meanings {
+			key := placeKey(m.name)
+			r.abbreviations[key] = append(r.abbreviations[key], m)
+		}
+	}
+	return r
+}
+
+// tsvRecords splits tab-separated data into records of n fields, skipping
+// comments and blank lines and padding short records.
+func tsvRecords(data string, n int) [][]string {
+	var records [][]string
+	for _, line := range strings.Split(data, "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		for len(fields) < n {
+			fields = append(fields, "")
+		}
+		records = append(records, fields)
+	}
+	return records
+}
+
+// accents maps accented letters to their plain forms for placeKey.
+var accents = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a", "å", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o", "ø", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n", "ß", "ss",
+)
+
+// placeKey normalizes a place name for matching: lower case, without
+// accents, and with punctuation, underscores and runs of spaces as single
+// spaces, so "São Paulo", "sao_paulo" and "Sao Paulo" match.
+func placeKey(name string) string {
+	name = accents.Replace(strings.ToLower(name))
+	return strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}), " ")
+}
+
+// timeZoneQuerySuffixes are trimmed from queries such as "Tokyo time".
+var timeZoneQuerySuffixes = []string{" time zone", " timezone", " time"}
+
+// trimTimeZoneQuery removes surrounding space and a trailing "time" or
+// "time zone" from a query.
+func trimTimeZoneQuery(query string) string {
+	query = strings.TrimSpace(query)
+	lower := strings.ToLower(query)
+	for _, suffix := range timeZoneQuerySuffixes {
+		if strings.HasSuffix(lower, suffix) && len(query) > len(suffix) {
+			return strings.TrimSpace(query[:len(query)-len(suffix)])
+		}
+	}
+	return query
+}
+
+var fixedOffsetPattern = regexp.MustCompile(`^(?i:utc|gmt)?\s*([+\-−])\s*(\d{1,2})(?::?(\d{2}))?$`)
+
+// parseFixedOffset reads offsets such as "UTC+5:30", "GMT-3" and "+0100"
+// as fixed zones named like "UTC+05:30".
+func parseFixedOffset(s string) (*time.Location, bool) {
+	m := fixedOffsetPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return nil, false
+	}
+	hours, _ := strconv.Atoi(m[2])
+	minutes := 0
+	if m[3] != "" {
+		minutes, _ = strconv.Atoi(m[3])
+	}
+	if hours > 14 || minutes >= 60 || hours == 14 && minutes > 0 {
+		return nil, false
+	}
+	offset := hours*3600 + minutes*60
+	if m[1] != "+" {
+		offset = -offset
+	}
+	return time.FixedZone(formatUTCOffset(offset), offset), true
+}
+
+// Find returns the time zones that query may refer to, most likely first:
+// a zone of that name, a zone under an alias or written differently, a
+// fixed offset, the zones an abbreviation is used for, a city's zone and a
+// country's zones.  When nothing matches well, zones with similar names
+// follow.  Zones are loaded with load, and countries whose zones keep the
+// same offsets through the year of now count as having one zone.
+func (r *timeZoneResolver) Find(query string, now time.Time, load func(string) (*time.Location, error)) []TimeZoneCandidate {
+	var candidates []TimeZoneCandidate
+	index := map[string]int{}
+	add := func(loc *time.Location, match TimeZoneMatch, description string, score int) {
+		if i, ok := index[loc.String()]; ok {
+			if candidates[i].Score < score {
+				candidates[i] = TimeZoneCandidate{loc, match, description, score}
+			}
+			return
+		}
+		index[loc.String()] = len(candidates)
+		candidates = append(candidates, TimeZoneCandidate{loc, match, description, score})
+	}
+	addZone := func(zone string, match TimeZoneMatch, description string, score int) {
+		if loc, err := load(zone); err == nil {
+			add(loc, match, description, score)
+		}
+	}
+
+	raw := strings.TrimSpace(query)
+	if raw == "" {
+		return nil
+	}
+	q := trimTimeZoneQuery(raw)
+	if loc, err := load(q); err == nil {
+		add(loc, MatchTimeZone, "time zone "+loc.String(), scoreTimeZone)
+	}
+	if zone, ok := timeZoneIndex().Lookup(q); ok {
+		addZone(zone, MatchAlias, "time zone "+zone, scoreAlias)
+	}
+	if loc, ok := parseFixedOffset(q); ok {
+		add(loc, MatchOffset, "fixed offset "+loc.String(), scoreOffset)
+	}
+	for _, key := range []string{strings.ToUpper(q), placeKey(raw), placeKey(q)} {
+		for _, a := range r.abbreviations[key] {
+			score := scoreAbbreviation
+			if a.minor {
+				score = scoreMinorMeaning
+			}
+			addZone(a.zone, MatchAbbreviation, a.name, score)
+		}
+	}
+	r.findCities(q, addZone)
+	r.findCountries(q, now, load, addZone)
+
+	if len(candidates) == 0 || candidates[0].Score < minResolvedScore {
+		for i, zone := range timeZoneIndex().Suggest(q, 5) {
+			addZone(zone, MatchSimilar, "similar to "+q, scoreSimilar-i)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Score > candidates[j].Score })
+	return candidates
+}
+
+// findCities adds the zones of the cities named by q, which may be followed
+// by a comma and a country.  The most populous city gets the higher score
+// unless another with that name is nearly as large.
+func (r *timeZoneResolver) findCities(q string, addZone func(string, TimeZoneMatch, string, int)) {
+	cities := r.cities[placeKey(q)]
+	if len(cities) == 0 {
+		if i := strings.LastIndex(q, ","); i > 0 {
+			if c, ok := r.countries[placeKey(q[i+1:])]; ok {
+				for _, city := range r.cities[placeKey(q[:i])] {
+					if city.country == c.code {
+						cities = append(cities, city)
+					}
+				}
+			}
+		}
+	}
+	cities = append([]city(nil), cities...)
+	sort.SliceStable(cities, func(i, j int) bool { return cities[i].population > cities[j].population })
+	for i, c := range cities {
+		score := scoreCity
+		if i > 0 && cities[0].population >= dominantPopulation*max(c.population, 1) {
+			score = scoreMinorCity
+		}
+		description := c.name
+		if country, ok := r.countries[strings.ToLower(c.country)]; ok {
+			description += ", " + country.name
+		}
+		addZone(c.zone, MatchCity, description, score)
+	}
+}
+
+// findCountries adds the zones of the country named or coded by q.  A zone
+// whose offsets match those of a more populous zone of the country all year
+// scores lower, so that a country with one time, such as Germany, resolves
+// to its main zone.
+func (r *timeZoneResolver) findCountries(q string, now time.Time, load func(string) (*time.Location, error), addZone func(string, TimeZoneMatch, string, int)) {
+	key := placeKey(q)
+	if len(q) == 2 {
+		key = strings.ToLower(q)
+	}
+	c, ok := r.countries[key]
+	if !ok {
+		return
+	}
+	var major []*time.Location
+	for _, z := range c.zones {
+		loc, err := load(z.zone)
+		if err != nil {
+			continue
+		}
+		score := scoreCountry
+		if slices.ContainsFunc(major, func(m *time.Location) bool { return sameOffsets(m, loc, now.Year()) }) {
+			score = scoreMinorCountry
+		} else {
+			major = append(major, loc)
+		}
+		description := c.name
+		if z.region != "" {
+			description += ": " + z.region
+		}
+		addZone(z.zone, MatchCountry, description, score)
+	}
+}
+
+// sameOffsets reports whether a and b have the same offsets in the middle of
+// January and of July of year.
+func sameOffsets(a, b *time.Location, year int) bool {
+	for _, month := range []time.Month{time.January, time.July} {
+		t := time.Date(year, month, 15, 12, 0, 0, 0, time.UTC)
+		_, aOffset := t.In(a).Zone()
+		_, bOffset := t.In(b).Zone()
+		if aOffset != bOffset {
+			return false
+		}
+	}
+	return true
+}
+
+// pickTimeZone returns the candidate a query resolves to.  If the best
+// candidates tie it returns them as rivals instead, and if none is a strong
+// enough match it returns neither.
+func pickTimeZone(candidates []TimeZoneCandidate) (*TimeZoneCandidate, []TimeZoneCandidate) {
+	if len(candidates) == 0 || candidates[0].Score < minResolvedScore {
+		return nil, nil
+	}
+	n := 1
+	for n < len(candidates) && candidates[n].Score == candidates[0].Score {
+		n++
+	}
+	if n > 1 {
+		return nil, candidates[:n]
+	}
+	return &candidates[0], nil
+}
+
+// resolveTimeZone loads the zone name with load.  If load fails, name is
+// resolved as a city, country, abbreviation, fixed offset or differently
+// written zone name; an ambiguous name is an AmbiguousTimeZoneError, and an
+// unknown one a TimeZoneLoadError suggesting similar names.
+func resolveTimeZone(name string, now time.Time, load func(string) (*time.Location, error)) (*time.Location, error) {
+	loc, err := load(name)
+	if err == nil {
+		return loc, nil
+	}
+	best, rivals := pickTimeZone(defaultTimeZoneResolver().Find(name, now, load))
+	switch {
+	case best != nil:
+		return best.Location, nil
+	case len(rivals) > 0:
+		var meanings []string
+		for _, c := range rivals[:min(len(rivals), maxAmbiguousMeanings)] {
+			meanings = append(meanings, fmt.Sprintf("%s (%s)", c.Location, c.Description))
+		}
+		if len(rivals) > maxAmbiguousMeanings {
+			meanings = append(meanings, fmt.Sprintf("%d more", len(rivals)-maxAmbiguousMeanings))
+		}
+		return nil, NewAmbiguousTimeZoneError(name, meanings)
+	default:
+		return nil, NewTimeZoneLoadError(name, err)
+	}
+}
+
+// loadLocation loads a time zone argument through s.TimeManager, accepting
+// the names resolveTimeZone does.
+func (s *Server) loadLocation(name string) (*time.Location, error) {
+	return resolveTimeZone(name, s.TimeManager.Now(), s.TimeManager.LoadLocation)
+}
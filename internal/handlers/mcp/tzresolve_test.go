@@ -0,0 +1,121 @@
+package mcp
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFindTimeZoneCandidates(t *testing.T) {
+	now := time.Date(2023, time.October, 1, 12, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc  string
+		query string
+		// want lists the leading candidates' zones.
+		want      string
+		wantMatch TimeZoneMatch
+	}{
+		{desc: "Zone name", query: "Asia/Tokyo", want: "Asia/Tokyo", wantMatch: MatchTimeZone},
+		{desc: "Zone name written differently", query: "america/new york", want: "America/New_York", wantMatch: MatchAlias},
+		{desc: "City with a suffix", query: "Tokyo time", want: "Asia/Tokyo", wantMatch: MatchCity},
+		{desc: "City with accents left out", query: "Sao Paulo", want: "America/Sao_Paulo", wantMatch: MatchCity},
+		{desc: "City and country", query: "Portland, US", want: "America/Los_Angeles, America/New_York", wantMatch: MatchCity},
+		{desc: "Country code", query: "DE", want: "Europe/Berlin", wantMatch: MatchCountry},
+		{desc: "Country name", query: "India", want: "Asia/Kolkata", wantMatch: MatchCountry},
+		{desc: "Country alias", query: "uk", want: "Europe/London", wantMatch: MatchCountry},
+		{desc: "Abbreviation", query: "PST", want: "America/Los_Angeles", wantMatch: MatchAbbreviation},
+		{desc: "Long name", query: "Eastern Standard Time", want: "America/New_York", wantMatch: MatchAbbreviation},
+		{desc: "Fixed offset", query: "UTC+5:30", want: "UTC+05:30", wantMatch: MatchOffset},
+		{desc: "Fixed offset without UTC", query: "+0100", want: "UTC+01:00", wantMatch: MatchOffset},
+		{desc: "Misspelled city", query: "Londn", want: "Europe/London", wantMatch: MatchSimilar},
+		{desc: "Nothing close", query: "Olympus Mons", want: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			candidates := defaultTimeZoneResolver().Find(tc.query, now, time.LoadLocation)
+			var zones []string
+			for _, c := range candidates {
+				zones = append(zones, c.Location.String())
+			}
+			if got := strings.Join(zones, ", "); !strings.HasPrefix(got, tc.want) || (tc.want == "" && got != "") {
+				t.Errorf("Find(%q) = %s, want %s first", tc.query, got, tc.want)
+			}
+			if len(candidates) > 0 && candidates[0].Match != tc.wantMatch {
+				t.Errorf("Find(%q) matched %s, want %s", tc.query, candidates[0].Match, tc.wantMatch)
+			}
+		})
+	}
+}
+
+func TestResolveTimeZone(t *testing.T) {
+	now := time.Date(2023, time.October, 1, 12, 30, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc          string
+		name          string
+		want          string
+		wantAmbiguous bool
+		wantErr       string
+	}{
+		{desc: "Zone name", name: "Europe/London", want: "Europe/London"},
+		{desc: "City", name: "London", want: "Europe/London"},
+		{desc: "City much larger than its namesakes", name: "Melbourne", want: "Australia/Melbourne"},
+		{desc: "Country with one time", name: "Germany", want: "Europe/Berlin"},
+		{desc: "Abbreviation with a rare second meaning", name: "BST", want: "Europe/London"},
+		{desc: "Fixed offset", name: "GMT-3", want: "UTC-03:00"},
+		{desc: "Ambiguous abbreviation", name: "IST", wantAmbiguous: true, wantErr: `time zone "IST" is ambiguous; it may mean Asia/Kolkata (India Standard Time), Asia/Jerusalem (Israel Standard Time), Europe/Dublin (Irish Standard Time).  Use an IANA time zone name instead`},
+		{desc: "Ambiguous city", name: "Portland", wantAmbiguous: true},
+		{desc: "Country with several times", name: "United States", wantAmbiguous: true},
+		{desc: "Misspelling", name: "Londn", wantErr: "did you mean Europe/London?"},
+		{desc: "Unknown", name: "Olympus Mons", wantErr: "Olympus Mons"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			loc, err := resolveTimeZone(tc.name, now, time.LoadLocation)
+			if tc.wantErr != "" || tc.wantAmbiguous {
+				var ambiguous *AmbiguousTimeZoneError
+				if errors.As(err, &ambiguous) != tc.wantAmbiguous {
+					t.Errorf("resolveTimeZone(%q) error = %v, want ambiguous %t", tc.name, err, tc.wantAmbiguous)
+				}
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("resolveTimeZone(%q) error = %v, want %q", tc.name, err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveTimeZone(%q) error = %v", tc.name, err)
+			}
+			if loc.String() != tc.want {
+				t.Errorf("resolveTimeZone(%q) = %s, want %s", tc.name, loc, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseFixedOffset(t *testing.T) {
+	testCases := []struct {
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{input: "UTC+5:30", want: "UTC+05:30", wantOK: true},
+		{input: "GMT-03", want: "UTC-03:00", wantOK: true},
+		{input: "+0100", want: "UTC+01:00", wantOK: true},
+		{input: "UTC−8", want: "UTC-08:00", wantOK: true},
+		{input: "UTC+15", wantOK: false},
+		{input: "UTC+5:75", wantOK: false},
+		{input: "Tokyo", wantOK: false},
+	}
+	for _, tc := range testCases {
+		loc, ok := parseFixedOffset(tc.input)
+		if ok != tc.wantOK {
+			t.Errorf("parseFixedOffset(%q) ok = %t, want %t", tc.input, ok, tc.wantOK)
+			continue
+		}
+		if ok && loc.String() != tc.want {
+			t.Errorf("parseFixedOffset(%q) = %s, want %s", tc.input, loc, tc.want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package mcp
+
+import (
+	"strings"
+	"time"
+)
+
+// DSTPolicy selects how a wall-clock time that is skipped (gap) or repeated
+// (fold) by a daylight saving transition is mapped onto an instant.
+type DSTPolicy string
+
+const (
+	// DSTPolicyEarlier picks the earlier of the two candidate instants.
+	DSTPolicyEarlier DSTPolicy = "earlier"
+	// DSTPolicyLater picks the later of the two candidate instants.
+	DSTPolicyLater DSTPolicy = "later"
+	// DSTPolicyError rejects gaps and folds with an error.
+	DSTPolicyError DSTPolicy = "error"
+)
+
+// DefaultDSTPolicy is used when no policy is requested.
+const DefaultDSTPolicy = DSTPolicyEarlier
+
+// ParseDSTPolicy maps a string to a DSTPolicy.  An empty string yields the
+// default policy.
+func ParseDSTPolicy(s string) (DSTPolicy, error) {
+	switch p := DSTPolicy(strings.ToLower(strings.TrimSpace(s))); p {
+	case "":
+		return DefaultDSTPolicy, nil
+	case DSTPolicyEarlier, DSTPolicyLater, DSTPolicyError:
+		return p, nil
+	default:
+		return "", NewInvalidDSTPolicyError(s)
+	}
+}
+
+// WallClockKind describes how a local wall-clock time maps onto instants.
+type WallClockKind int
+
+const (
+	// WallClockNormal means the wall-clock time occurs exactly once.
+	WallClockNormal WallClockKind = iota
+	// WallClockGap means the wall-clock time was skipped by a transition.
+	WallClockGap
+	// WallClockFold means the wall-clock time occurs twice.
+	WallClockFold
+)
+
+func (k WallClockKind) String() string {
+	switch k {
+	case WallClockGap:
+		return "gap"
+	case WallClockFold:
+		return "fold"
+	default:
+		return "normal"
+	}
+}
+
+// WallClock is the result of resolving a local wall-clock time in a zone.
+type WallClock struct {
+	Time time.Time
+	Kind WallClockKind
+	// Earlier and Later hold the two candidate instants for a gap or fold.
+	// For a normal wall-clock time both equal Time.
+	Earlier time.Time
+	Later   time.Time
+	// Explanation describes how a natural-language input was interpreted.  It
+	// is empty for ISO 8601 input.
+	Explanation string
+}
+
+// resolveWallClock interprets the given wall-clock fields as local time in loc
+// and applies policy when the time falls in a DST gap or fold.
+func resolveWallClock(year int, month time.Month, day, hour, min, sec, nsec int, loc *time.Location, policy DSTPolicy) (WallClock, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	if policy == "" {
+		policy = DefaultDSTPolicy
+	}
+
+	// The wall-clock time read as if it were UTC.  Every candidate instant is
+	// this value minus one of the zone's offsets around it.
+	naive := time.Date(year, month, day, hour, min, sec, nsec, time.UTC)
+
+	// Offsets in effect a day either side cover any single transition.
+	_, offBefore := naive.Add(-24 * time.Hour).In(loc).Zone()
+	_, offAfter := naive.Add(24 * time.Hour).In(loc).Zone()
+
+	var candidates []time.Time
+	for _, off := range []int{offBefore, offAfter} {
+		instant := naive.Add(-time.Duration(off) * time.Second).In(loc)
+		if _, got := instant.Zone(); got != off {
+			continue
+		}
+		if len(candidates) == 1 && candidates[0].Equal(instant) {
+			continue
+		}
+		candidates = append(candidates, instant)
+	}
+
+	switch len(candidates) {
+	case 1:
+		return WallClock{Time: candidates[0], Kind: WallClockNormal, Earlier: candidates[0], Later: candidates[0]}, nil
+	case 2:
+		wc := WallClock{Kind: WallClockFold, Earlier: candidates[0], Later: candidates[1]}
+		if wc.Later.Before(wc.Earlier) {
+			wc.Earlier, wc.Later = wc.Later, wc.Earlier
+		}
+		return wc.choose(policy, naive, loc)
+	default:
+		// No offset reproduces the wall-clock time, so it lies in a gap.  Reading
+		// it with either offset yields the instants either side of the gap.
+		wc := WallClock{
+			Kind:    WallClockGap,
+			Earlier: naive.Add(-time.Duration(offAfter) * time.Second).In(loc),
+			Later:   naive.Add(-time.Duration(offBefore) * time.Second).In(loc),
+		}
+		if wc.Later.Before(wc.Earlier) {
+			wc.Earlier, wc.Later = wc.Later, wc.Earlier
+		}
+		return wc.choose(policy, naive, loc)
+	}
+}
+
+func (wc WallClock) choose(policy DSTPolicy, naive time.Time, loc *time.Location) (WallClock, error) {
+	switch policy {
+	case DSTPolicyLater:
+		wc.Time = wc.Later
+	case DSTPolicyError:
+		wall := naive.Format(dateTimeFormat)
+		if wc.Kind == WallClockGap {
+			return wc, NewNonexistentLocalTimeError(wall, loc.String())
+		}
+		return wc, NewAmbiguousLocalTimeError(wall, loc.String(), wc.Earlier, wc.Later)
+	default:
+		wc.Time = wc.Earlier
+	}
+	return wc, nil
+}
@@ -0,0 +1,249 @@
+package mcp
+
+import (
+	"sort"
+	"strings"
+)
+
+// zoneAliases maps names that tzdata has renamed or keeps only for backward
+// compatibility to the zones that replaced them.  Completions and
+// suggestions offer the current name.
+var zoneAliases = map[string]string{
+	"America/Buenos_Aires": "America/Argentina/Buenos_Aires",
+	"America/Indianapolis": "America/Indiana/Indianapolis",
+	"America/Montreal":     "America/Toronto",
+	"Asia/Calcutta":        "Asia/Kolkata",
+	"Asia/Katmandu":        "Asia/Kathmandu",
+	"Asia/Rangoon":         "Asia/Yangon",
+	"Asia/Saigon":          "Asia/Ho_Chi_Minh",
+	"Australia/ACT":        "Australia/Sydney",
+	"Australia/NSW":        "Australia/Sydney",
+	"Brazil/East":          "America/Sao_Paulo",
+	"Canada/Central":       "America/Winnipeg",
+	"Canada/Eastern":       "America/Toronto",
+	"Canada/Mountain":      "America/Edmonton",
+	"Canada/Pacific":       "America/Vancouver",
+	"EST5EDT":              "America/New_York",
+	"CST6CDT":              "America/Chicago",
+	"MST7MDT":              "America/Denver",
+	"PST8PDT":              "America/Los_Angeles",
+	"Europe/Kiev":          "Europe/Kyiv",
+	"GB":                   "Europe/London",
+	"Japan":                "Asia/Tokyo",
+	"PRC":                  "Asia/Shanghai",
+	"Pacific/Truk":         "Pacific/Chuuk",
+	"US/Alaska":            "America/Anchorage",
+	"US/Arizona":           "America/Phoenix",
+	"US/Central":           "America/Chicago",
+	"US/Eastern":           "America/New_York",
+	"US/Hawaii":            "Pacific/Honolulu",
+	"US/Mountain":          "America/Denver",
+	"US/Pacific":           "America/Los_Angeles",
+	"Zulu":                 "UTC",
+}
+
+// zoneIndex looks up time zone names the way people type them: in any case,
+// with spaces for underscores, by city, under an old name or misspelled.
+type zoneIndex struct {
+	// entries holds every known name and alias, sorted by key.
+	entries []zoneEntry
+}
+
+// zoneEntry is one name in a zoneIndex.
+type zoneEntry struct {
+	// key and city are the normalized name and its last part.
+	key, city string
+	// zone is the name to offer for the entry.
+	zone string
+}
+
+// timeZoneIndex returns the index of the zones in the current database.
+func timeZoneIndex() *zoneIndex {
+	return CurrentTimeZoneDatabase().index()
+}
+
+// newZoneIndex indexes names and those aliases whose zone is among them.
+func newZoneIndex(names []string, aliases map[string]string) *zoneIndex {
+	known := make(map[string]bool, len(names))
+	for _, name := range names {
+		known[name] = true
+	}
+	byKey := make(map[string]string, len(names)+len(aliases))
+	for _, name := range names {
+		byKey[zoneKey(name)] = name
+	}
+	for alias, zone := range aliases {
+		if known[zone] {
+			byKey[zoneKey(alias)] = zone
+		}
+	}
+	ix := &zoneIndex{entries: make([]zoneEntry, 0, len(byKey))}
+	for key, zone := range byKey {
+		ix.entries = append(ix.entries, zoneEntry{key: key, city: key[strings.LastIndex(key, "/")+1:], zone: zone})
+	}
+	sort.Slice(ix.entries, func(i, j int) bool { return ix.entries[i].key < ix.entries[j].key })
+	return ix
+}
+
+// zoneKey normalizes a time zone name for matching: lower case, with runs of
+// spaces and underscores as a single underscore.
+func zoneKey(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(strings.TrimSpace(name)), func(r rune) bool {
+		return r == ' ' || r == '_'
+	})
+	return strings.Join(fields, "_")
+}
+
+// Complete returns the zones whose name, or whose last part such as
+// "New_York", starts with prefix.  If none do, it returns those whose name
+// nearly starts with it, to allow for typing mistakes.
+func (ix *zoneIndex) Complete(prefix string) []string {
+	key := zoneKey(prefix)
+	if strings.HasSuffix(prefix, " ") || strings.HasSuffix(prefix, "_") {
+		key += "_"
+	}
+	var matches []string
+	for _, e := range ix.entries {
+		if strings.HasPrefix(e.key, key) || strings.HasPrefix(e.city, key) {
+			matches = append(matches, e.zone)
+		}
+	}
+	if len(matches) == 0 {
+		limit := typoLimit(key)
+		for _, e := range ix.entries {
+			if editDistance(key, truncate(e.key, len(key))) <= limit || editDistance(key, truncate(e.city, len(key))) <= limit {
+				matches = append(matches, e.zone)
+			}
+		}
+	}
+	return uniqueSorted(matches)
+}
+
+// Lookup returns the zone that name is, or is an alias of, when written in
+// any case and with spaces for underscores.
+func (ix *zoneIndex) Lookup(name string) (string, bool) {
+	key := zoneKey(name)
+	i := sort.Search(len(ix.entries), func(i int) bool { return ix.entries[i].key >= key })
+	if key != "" && i < len(ix.entries) && ix.entries[i].key == key {
+		return ix.entries[i].zone, true
+	}
+	return "", false
+}
+
+// Suggest returns up to n zones that name is likely meant to be.  A name
+// that is a known zone or alias when written differently gives just that
+// zone; otherwise the suggestions are zones with that city, then zones whose
+// name or city is a few typing mistakes away.
+func (ix *zoneIndex) Suggest(name string, n int) []string {
+	key := zoneKey(name)
+	if key == "" {
+		return nil
+	}
+	if zone, ok := ix.Lookup(name); ok {
+		return []string{zone}
+	}
+	city := key[strings.LastIndex(key, "/")+1:]
+
+	type candidate struct {
+		zone     string
+		distance int
+	}
+	best := map[string]int{}
+	for _, e := range ix.entries {
+		d := -1
+		if full := editDistance(key, e.key); full <= typoLimit(key) {
+			d = full
+		}
+		// A matching city counts for a little less than a matching name.
+		if c := editDistance(city, e.city); c <= typoLimit(city) && (d < 0 || c+1 < d) {
+			d = c + 1
+		}
+		if d < 0 {
+			continue
+		}
+		if prev, ok := best[e.zone]; !ok || d < prev {
+			best[e.zone] = d
+		}
+	}
+	candidates := make([]candidate, 0, len(best))
+	for zone, d := range best {
+		candidates = append(candidates, candidate{zone, d})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance == candidates[j].distance {
+			return candidates[i].zone < candidates[j].zone
+		}
+		return candidates[i].distance < candidates[j].distance
+	})
+	var zones []string
+	for _, c := range candidates {
+		if len(zones) == n {
+			break
+		}
+		zones = append(zones, c.zone)
+	}
+	return zones
+}
+
+// typoLimit is the edit distance within which s is taken to be a misspelling:
+// none for very short names, where any guess would be a stretch, and more for
+// longer ones.
+func typoLimit(s string) int {
+	switch n := len(s); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	case n < 16:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// editDistance returns the optimal string alignment distance between a and
+// b: the number of insertions, deletions, substitutions and transpositions
+// of adjacent bytes that turn one into the other.
+func editDistance(a, b string) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
+
+// truncate returns the first n bytes of s, or s if it is shorter.
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+// uniqueSorted sorts values and removes duplicates.
+func uniqueSorted(values []string) []string {
+	sort.Strings(values)
+	unique := values[:0]
+	for i, v := range values {
+		if i == 0 || v != values[i-1] {
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
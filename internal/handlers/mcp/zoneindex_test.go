@@ -0,0 +1,107 @@
+package mcp
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestZoneIndexSuggest(t *testing.T) {
+	ix := newZoneIndex(
+		[]string{"America/New_York", "America/Toronto", "Asia/Kolkata", "Asia/Tokyo", "Europe/Kirov", "Europe/Kyiv", "Europe/London", "US/Eastern", "UTC"},
+		zoneAliases,
+	)
+
+	testCases := []struct {
+		desc  string
+		input string
+		want  string
+	}{
+		{desc: "Spaces for underscores", input: "America/New York", want: "America/New_York"},
+		{desc: "Wrong case", input: "asia/KOLKATA", want: "Asia/Kolkata"},
+		{desc: "City alone", input: "new york", want: "America/New_York"},
+		{desc: "Old name", input: "Asia/Calcutta", want: "Asia/Kolkata"},
+		{desc: "Link to a current zone", input: "US/Eastern", want: "America/New_York"},
+		{desc: "POSIX-style name", input: "EST5EDT", want: "America/New_York"},
+		{desc: "Transposed letters", input: "Amercia/New_Yrok", want: "America/New_York"},
+		{desc: "Misspelled city", input: "Tokio", want: "Asia/Tokyo"},
+		{desc: "Misspelled old name", input: "Europe/Kiv", want: "Europe/Kyiv, Europe/Kirov"},
+		{desc: "Misspelled link", input: "US/Estern", want: "America/New_York, America/Toronto"},
+		{desc: "Nothing close", input: "Mars/Olympus", want: ""},
+		{desc: "Too short to guess", input: "UT", want: ""},
+		{desc: "Empty", input: "", want: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := strings.Join(ix.Suggest(tc.input, 3), ", "); got != tc.want {
+				t.Errorf("Suggest(%q) = %s, want %s", tc.input, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestZoneIndexComplete(t *testing.T) {
+	ix := newZoneIndex(
+		[]string{"America/New_York", "America/North_Dakota/New_Salem", "Asia/Kolkata", "Europe/London", "US/Eastern"},
+		zoneAliases,
+	)
+
+	testCases := []struct {
+		desc   string
+		prefix string
+		want   string
+	}{
+		{desc: "Region", prefix: "america/n", want: "America/New_York, America/North_Dakota/New_Salem"},
+		{desc: "City", prefix: "new_", want: "America/New_York, America/North_Dakota/New_Salem"},
+		{desc: "Space for an underscore", prefix: "America/New Y", want: "America/New_York"},
+		{desc: "Old name", prefix: "Asia/Calc", want: "Asia/Kolkata"},
+		{desc: "Link to a current zone", prefix: "US/East", want: "America/New_York"},
+		{desc: "Typing mistake", prefix: "Amercia/New", want: "America/New_York"},
+		{desc: "Nothing close", prefix: "Mars/", want: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := strings.Join(ix.Complete(tc.prefix), ", "); got != tc.want {
+				t.Errorf("Complete(%q) = %s, want %s", tc.prefix, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTimeZoneLoadErrorSuggestions(t *testing.T) {
+	_, err := (&LiveTimeManager{}).LoadLocation("America/New York")
+	var loadErr *TimeZoneLoadError
+	if !errors.As(err, &loadErr) {
+		t.Fatalf("LoadLocation() error = %v, want TimeZoneLoadError", err)
+	}
+	if !strings.HasSuffix(err.Error(), "; did you mean America/New_York?") {
+		t.Errorf("error = %q, want a suggestion of America/New_York", err)
+	}
+	if again := NewTimeZoneLoadError("America/New York", err); again != loadErr {
+		t.Errorf("wrapping the error again = %q, want it unchanged", again)
+	}
+
+	if _, err := (&LiveTimeManager{}).LoadLocation("Mars/Olympus"); err == nil || strings.Contains(err.Error(), "did you mean") {
+		t.Errorf("error for a zone with no likely match = %v", err)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	testCases := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"london", "london", 0},
+		{"", "abc", 3},
+		{"londn", "london", 1},
+		{"tokio", "tokyo", 1},
+		{"amercia", "america", 1},
+		{"kitten", "sitting", 3},
+	}
+	for _, tc := range testCases {
+		if got := editDistance(tc.a, tc.b); got != tc.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}